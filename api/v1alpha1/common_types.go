@@ -37,11 +37,13 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // CopyMethodType defines the methods for creating point-in-time copies of
 // volumes.
-// +kubebuilder:validation:Enum=Direct;None;Clone;Snapshot
+// +kubebuilder:validation:Enum=Direct;None;Clone;Snapshot;GroupSnapshot
 type CopyMethodType string
 
 const (
@@ -54,6 +56,10 @@ const (
 	// CopyMethodSnapshot indicates a copy should be created using a volume
 	// snapshot.
 	CopyMethodSnapshot CopyMethodType = "Snapshot"
+	// CopyMethodGroupSnapshot indicates copies of all the volumes selected by
+	// sourcePVCSelector should be created together using a volume group
+	// snapshot. Only supported by ReplicationSources.
+	CopyMethodGroupSnapshot CopyMethodType = "GroupSnapshot"
 
 	// Namespace annotation to indicate that elevated permissions are ok for movers
 	PrivilegedMoversNamespaceAnnotation = "volsync.backube/privileged-movers"
@@ -69,6 +75,44 @@ const (
 	SynchronizingReasonManual  string = "WaitingForManual"
 	SynchronizingReasonError   string = "Error"
 	SynchronizingReasonCleanup string = "CleanupInProgress"
+	SynchronizingReasonRetry   string = "WaitingForRetry"
+)
+
+const (
+	// ConditionVerified reports the result of the last verification of a
+	// backup by the mover
+	ConditionVerified       string = "Verified"
+	VerifiedReasonSucceeded string = "VerifySucceeded"
+	VerifiedReasonFailed    string = "VerifyFailed"
+)
+
+const (
+	// ConditionRetriesExhausted is True when a synchronization failed and
+	// won't be retried, either because all the attempts allowed by the
+	// retryPolicy failed or because the failure isn't retryable
+	ConditionRetriesExhausted           string = "RetriesExhausted"
+	RetriesExhaustedReasonMaxAttempts   string = "MaxAttemptsReached"
+	RetriesExhaustedReasonNotRetryable  string = "FailureNotRetryable"
+	RetriesExhaustedReasonSyncSucceeded string = "SyncSucceeded"
+)
+
+const (
+	// ConditionCapacityExpansionFailed is True when the destination volume of
+	// a ReplicationDestination with the FollowSource capacityPolicy can't be
+	// expanded to the capacity of the source
+	ConditionCapacityExpansionFailed    string = "CapacityExpansionFailed"
+	CapacityReasonExpansionNotAllowed   string = "ExpansionNotAllowed"
+	CapacityReasonSourceCapacityUnknown string = "SourceCapacityUnknown"
+	CapacityReasonCapacitySufficient    string = "CapacitySufficient"
+)
+
+const (
+	// ConditionStale is True when the last successful synchronization is
+	// older than the maxStaleness of a ReplicationSource
+	ConditionStale         string = "Stale"
+	StaleReasonSyncTooOld  string = "SyncTooOld"
+	StaleReasonNeverSynced string = "NeverSynced"
+	StaleReasonSyncRecent  string = "SyncRecent"
 )
 
 const (
@@ -134,6 +178,28 @@ const (
 type MoverStatus struct {
 	Result MoverResult `json:"result,omitempty"`
 	Logs   string      `json:"logs,omitempty"`
+	// snapshotID is the ID of the snapshot that the mover created or
+	// restored, as reported by the mover.
+	//+optional
+	SnapshotID string `json:"snapshotID,omitempty"`
+	// bytesProcessed is the number of bytes the mover processed, as reported
+	// by the mover.
+	//+optional
+	BytesProcessed *int64 `json:"bytesProcessed,omitempty"`
+	// filesProcessed is the number of files the mover processed, as reported
+	// by the mover.
+	//+optional
+	FilesProcessed *int64 `json:"filesProcessed,omitempty"`
+	// duration is how long the mover ran.
+	//+optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// errors are the errors reported by the mover.
+	//+optional
+	Errors []string `json:"errors,omitempty"`
+	// sourceCapacity is the capacity of the source volume, as reported by
+	// the mover. It is used by the FollowSource capacityPolicy.
+	//+optional
+	SourceCapacity *resource.Quantity `json:"sourceCapacity,omitempty"`
 }
 
 type CustomCASpec struct {
@@ -204,6 +270,51 @@ type MoverConfig struct {
 	// This should only be used by advanced users.
 	// +optional
 	MoverVolumes []MoverVolume `json:"moverVolumes,omitempty"`
+	// transferPolicy limits the network bandwidth used by the data mover.
+	// +optional
+	TransferPolicy *TransferPolicy `json:"transferPolicy,omitempty"`
+}
+
+// TransferPolicy limits the rate at which a data mover transfers data. Limits
+// are in bytes per second (e.g., "10Mi"). Not all movers support every limit:
+// the rsync-based movers only limit uploads from the source, and the Syncthing
+// mover doesn't support a transferPolicy.
+type TransferPolicy struct {
+	// uploadLimit is the maximum rate at which the mover sends data. If not
+	// set, uploads are not limited.
+	// +optional
+	UploadLimit *resource.Quantity `json:"uploadLimit,omitempty"`
+	// downloadLimit is the maximum rate at which the mover receives data. If
+	// not set, downloads are not limited.
+	// +optional
+	DownloadLimit *resource.Quantity `json:"downloadLimit,omitempty"`
+	// windows are daily time ranges during which different limits apply
+	// (e.g., no limit at night). The windows must not overlap. The limits
+	// of the window that a synchronization starts in apply for the whole
+	// synchronization, except with the Rclone mover, which switches limits as
+	// the windows start and end.
+	// +optional
+	Windows []TransferWindow `json:"windows,omitempty"`
+}
+
+// TransferWindow is a daily time range with its own transfer limits
+type TransferWindow struct {
+	// start is the time the window starts at, as HH:MM in UTC.
+	//+kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// end is the time the window ends at, as HH:MM in UTC. A window that ends
+	// before it starts spans midnight.
+	//+kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+	// uploadLimit is the maximum rate at which the mover sends data during the
+	// window. If not set, uploads are not limited during the window.
+	// +optional
+	UploadLimit *resource.Quantity `json:"uploadLimit,omitempty"`
+	// downloadLimit is the maximum rate at which the mover receives data
+	// during the window. If not set, downloads are not limited during the
+	// window.
+	// +optional
+	DownloadLimit *resource.Quantity `json:"downloadLimit,omitempty"`
 }
 
 type MoverVolume struct {
@@ -231,3 +342,136 @@ type MoverVolumeSource struct {
 	// nolint:lll
 	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty" protobuf:"bytes,10,opt,name=persistentVolumeClaim"`
 }
+
+// FailureClass categorizes why a synchronization attempt failed.
+// +kubebuilder:validation:Enum=Transient;Auth;Repository;Unknown
+type FailureClass string
+
+const (
+	// FailureClassTransient is a failure that is expected to go away on its
+	// own (e.g., a network timeout or an unavailable server)
+	FailureClassTransient FailureClass = "Transient"
+	// FailureClassAuth is a failure to authenticate to, or a lack of
+	// permission on, the remote end (e.g., wrong credentials)
+	FailureClassAuth FailureClass = "Auth"
+	// FailureClassRepository is a problem with the backup repository itself
+	// (e.g., a missing or corrupted repository)
+	FailureClassRepository FailureClass = "Repository"
+	// FailureClassUnknown is any other failure
+	FailureClassUnknown FailureClass = "Unknown"
+)
+
+// RetryPolicy controls how a failed synchronization is retried. Without a
+// retryPolicy, a failed synchronization is retried every minute until it
+// succeeds.
+type RetryPolicy struct {
+	// maxAttempts is the maximum number of attempts of a synchronization,
+	// including the first one. Once they have all failed, the
+	// RetriesExhausted condition is set and the synchronization is abandoned
+	// until the next scheduled or manual trigger. If not set, the number of
+	// attempts is unlimited.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+	// initialBackoff is the time to wait before the first retry. It is
+	// doubled after each failed retry. Defaults to 1m.
+	//+optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+	// maxBackoff is the maximum time to wait between attempts. Defaults to
+	// 1h.
+	//+optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+	// retryOn is the list of failure classes that are retried. A failure of
+	// any other class immediately exhausts the retries. If not set, all
+	// failures are retried.
+	//+optional
+	RetryOn []FailureClass `json:"retryOn,omitempty"`
+}
+
+// RetryStatus tracks the failed attempts of the current synchronization
+type RetryStatus struct {
+	// attempts is the number of failed attempts of the current
+	// synchronization.
+	Attempts int32 `json:"attempts"`
+	// lastFailureTime is the time of the most recent failed attempt.
+	//+optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// lastFailureClass is the class of the most recent failure.
+	//+optional
+	LastFailureClass FailureClass `json:"lastFailureClass,omitempty"`
+	// nextRetryTime is the time the next attempt will start.
+	//+optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+	// retriesExhaustedTime is the time the synchronization was abandoned
+	// because its retries were exhausted.
+	//+optional
+	RetriesExhaustedTime *metav1.Time `json:"retriesExhaustedTime,omitempty"`
+}
+
+// SyncProgress is the progress of a running synchronization, as reported by
+// the Restic and Kopia movers. Totals that the mover hasn't determined yet are
+// omitted.
+type SyncProgress struct {
+	// bytesDone is the number of bytes processed so far.
+	//+optional
+	BytesDone int64 `json:"bytesDone,omitempty"`
+	// bytesTotal is the total number of bytes to process.
+	//+optional
+	BytesTotal int64 `json:"bytesTotal,omitempty"`
+	// filesDone is the number of files processed so far.
+	//+optional
+	FilesDone int64 `json:"filesDone,omitempty"`
+	// filesTotal is the total number of files to process.
+	//+optional
+	FilesTotal int64 `json:"filesTotal,omitempty"`
+	// bytesPerSecond is the recent throughput of the mover.
+	//+optional
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty"`
+	// estimatedCompletionTime is when the mover is expected to finish at the
+	// current throughput.
+	//+optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+	// lastUpdateTime is when the progress was last read from the mover.
+	//+optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// DefaultSyncHistoryLimit is the number of synchronizations kept in the
+// syncHistory when syncHistoryLimit isn't set
+const DefaultSyncHistoryLimit = 10
+
+// SyncHistoryEntry describes a past synchronization, or a failed attempt of
+// one
+type SyncHistoryEntry struct {
+	// startTime is when the synchronization started.
+	//+optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// endTime is when the synchronization completed or the attempt failed.
+	//+optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// result is Successful if the synchronization completed, or Failed if the
+	// attempt failed.
+	Result MoverResult `json:"result"`
+	// mover is the name of the data mover, e.g. restic.
+	//+optional
+	Mover string `json:"mover,omitempty"`
+	// attempts is the number of failed attempts of the synchronization, up to
+	// and including this one for a failed attempt.
+	//+optional
+	Attempts int32 `json:"attempts,omitempty"`
+	// bytesTransferred is the amount of data processed by the mover, if it
+	// reports it.
+	//+optional
+	BytesTransferred *int64 `json:"bytesTransferred,omitempty"`
+	// snapshotID is the ID of the snapshot created or restored by the mover,
+	// if it reports it.
+	//+optional
+	SnapshotID string `json:"snapshotID,omitempty"`
+	// image is the image (e.g. VolumeSnapshot) produced by a
+	// ReplicationDestination.
+	//+optional
+	Image *corev1.TypedLocalObjectReference `json:"image,omitempty"`
+	// error is a short description of the failure.
+	//+optional
+	Error string `json:"error,omitempty"`
+}
@@ -29,12 +29,21 @@ const (
 	EvRSnapNotBound                        = "VolumeSnapshotNotBound" // Warning
 	EvRPVCCreated                          = "PersistentVolumeClaimCreated"
 	EvRPVCNotBound                         = "PersistentVolumeClaimNotBound" // Warning
+	EvRPVCExpanded                         = "PersistentVolumeClaimExpanded"
+	EvRPVCExpansionNotAllowed              = "PersistentVolumeClaimExpansionNotAllowed" // Warning
 	EvRSvcAddress                          = "ServiceAddressAssigned"
 	EvRSvcNoAddress                        = "NoServiceAddressAssigned" // Warning
 	EvRSrcPVCWaitingForCopyTrigger         = "SrcPVCWaitingForCopyTrigger"
 	EvRSrcPVCTimeoutWaitingForCopyTrigger  = "SrcPVCTimeoutWaitingForCopyTrigger" // Warning
 	EvRSrcPVCCopyTriggerReceived           = "SrcPVCCopyTriggerReceived"
 	EvRSrcPVCCopyUsingCopyTriggerCompleted = "SrcPVCCopyUsingCopyTriggerCompleted"
+	EvRVerifyFailed                        = "VerifyFailed" // Warning
+	EvRHookSucceeded                       = "HookSucceeded"
+	EvRHookFailed                          = "HookFailed"                    // Warning
+	EvRNotificationFailed                  = "NotificationFailed"            // Warning
+	EvRRepositoryCheckFailed               = "RepositoryCheckFailed"         // Warning
+	EvRSecondaryCopyFailed                 = "SecondaryRepositoryCopyFailed" // Warning
+	EvRSyncToFailed                        = "SyncToFailed"                  // Warning
 )
 
 // ReplicationSource/ReplicationDestination Event "action" strings: Things the controller "does"
@@ -43,8 +52,11 @@ const (
 	EvACreateMover                   = "CreateMover"
 	EvADeleteMover                   = "DeleteMover"
 	EvACreatePVC                     = "CreatePersistentVolumeClaim"
+	EvAExpandPVC                     = "ExpandPersistentVolumeClaim"
 	EvACreateSnap                    = "CreateVolumeSnapshot"
 	EvACreateSrcCopyUsingCopyTrigger = "CreateSrcCopyUsingCopyTrigger"
+	EvAExecHook                      = "ExecHook"
+	EvASendNotification              = "SendNotification"
 )
 
 // Volume Populator Event "reason" strings
@@ -52,7 +64,16 @@ const (
 	EvRVolPopPVCPopulatorFinished            = "VolSyncPopulatorFinished" // #nosec G101 - gosec thinks this is a cred
 	EvRVolPopPVCPopulatorError               = "VolSyncPopulatorError"
 	EvVolPopPVCReplicationDestMissing        = "VolSyncPopulatorReplicationDestinationMissing"
+	EvVolPopPVCVolSyncRestoreMissing         = "VolSyncPopulatorVolSyncRestoreMissing"
 	EvRVolPopPVCReplicationDestNoLatestImage = "VolSyncPopulatorReplicationDestinationNoLatestImage"
 	EvRVolPopPVCCreationSuccess              = "VolSyncPopulatorPVCCreated"
 	EvRVolPopPVCCreationError                = "VolSyncPopulatorPVCCreationError"
+	EvRVolPopPVCRestoreStarted               = "VolSyncPopulatorRestoreStarted"
+	EvRVolPopPVCRestoreFailed                = "VolSyncPopulatorRestoreFailed"
+)
+
+// RestoreTest Event "reason" strings
+const (
+	EvRRestoreTestPassed = "RestoreTestPassed"
+	EvRRestoreTestFailed = "RestoreTestFailed" // Warning
 )
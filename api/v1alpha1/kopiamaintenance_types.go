@@ -37,6 +37,7 @@ package v1alpha1
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	cron "github.com/robfig/cron/v3"
 	corev1 "k8s.io/api/core/v1"
@@ -210,6 +211,26 @@ type KopiaMaintenanceSpec struct {
 	// to the maintenance job pod. This should only be used by advanced users.
 	// +optional
 	MoverVolumes []MoverVolume `json:"moverVolumes,omitempty"`
+
+	// SnapshotCatalog configures periodically listing the snapshots in the
+	// repository as KopiaSnapshot resources in this namespace.
+	// +optional
+	SnapshotCatalog *KopiaSnapshotCatalogSpec `json:"snapshotCatalog,omitempty"`
+}
+
+// KopiaSnapshotCatalogSpec defines how the snapshots of a repository are
+// catalogued as KopiaSnapshot resources
+type KopiaSnapshotCatalogSpec struct {
+	// Enabled turns on the snapshot catalog for the repository.
+	// When disabled, the KopiaSnapshots created for the repository are removed.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Interval is how often the snapshots in the repository are listed.
+	// Defaults to 1h.
+	// +kubebuilder:default="1h"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
 // KopiaRepositorySpec defines the repository configuration for maintenance
@@ -347,6 +368,19 @@ func (km *KopiaMaintenance) GetRepositorySecret() string {
 	return km.Spec.Repository.Repository
 }
 
+// IsSnapshotCatalogEnabled returns whether the snapshot catalog is enabled
+func (km *KopiaMaintenance) IsSnapshotCatalogEnabled() bool {
+	return km.Spec.SnapshotCatalog != nil && km.Spec.SnapshotCatalog.Enabled
+}
+
+// GetSnapshotCatalogInterval returns how often the snapshot catalog is refreshed
+func (km *KopiaMaintenance) GetSnapshotCatalogInterval() time.Duration {
+	if km.Spec.SnapshotCatalog != nil && km.Spec.SnapshotCatalog.Interval != nil {
+		return km.Spec.SnapshotCatalog.Interval.Duration
+	}
+	return time.Hour // Default interval
+}
+
 // GetActiveDeadlineSeconds returns the job timeout in seconds
 func (km *KopiaMaintenance) GetActiveDeadlineSeconds() int64 {
 	if km.Spec.ActiveDeadlineSeconds != nil {
@@ -393,6 +427,12 @@ func (km *KopiaMaintenance) Validate() error {
 		// Controller should log a warning about this
 	}
 
+	// Validate snapshot catalog interval
+	if km.Spec.SnapshotCatalog != nil && km.Spec.SnapshotCatalog.Interval != nil &&
+		km.Spec.SnapshotCatalog.Interval.Duration < time.Minute {
+		return fmt.Errorf("snapshotCatalog.interval must be at least 1m")
+	}
+
 	// Validate resource requirements if specified
 	if km.Spec.Resources != nil {
 		if err := validateResourceRequirements(km.Spec.Resources); err != nil {
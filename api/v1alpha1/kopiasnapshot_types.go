@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This file may be used, at your option, according to either the GNU AGPL 3.0 or
+the Apache V2 license.
+
+---
+This program is free software: you can redistribute it and/or modify it under
+the terms of the GNU Affero General Public License as published by the Free
+Software Foundation, either version 3 of the License, or (at your option) any
+later version.
+
+This program is distributed in the hope that it will be useful, but WITHOUT ANY
+WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A
+PARTICULAR PURPOSE.  See the GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License along
+with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+---
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:validation:Required
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KopiaSnapshotIdentity is the source identity a Kopia snapshot was taken with
+type KopiaSnapshotIdentity struct {
+	// username is the Kopia username of the snapshot source
+	Username string `json:"username"`
+	// hostname is the Kopia hostname of the snapshot source
+	Hostname string `json:"hostname"`
+	// path is the path that was snapshotted
+	Path string `json:"path"`
+}
+
+// KopiaSnapshotSpec identifies a snapshot in a Kopia repository
+type KopiaSnapshotSpec struct {
+	// repository is the name of the repository Secret containing the snapshot
+	Repository string `json:"repository"`
+	// snapshotID is the Kopia ID of the snapshot
+	SnapshotID string `json:"snapshotID"`
+	// identity is the source identity of the snapshot
+	Identity KopiaSnapshotIdentity `json:"identity"`
+}
+
+// KopiaSnapshotStatus holds the details of the snapshot as last listed from
+// the repository
+type KopiaSnapshotStatus struct {
+	// startTime is the time the snapshot was started
+	//+optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// endTime is the time the snapshot completed
+	//+optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+	// description is the description of the snapshot
+	//+optional
+	Description string `json:"description,omitempty"`
+	// totalSizeBytes is the total size of the files in the snapshot
+	//+optional
+	TotalSizeBytes int64 `json:"totalSizeBytes,omitempty"`
+	// fileCount is the number of files in the snapshot
+	//+optional
+	FileCount int64 `json:"fileCount,omitempty"`
+	// dirCount is the number of directories in the snapshot
+	//+optional
+	DirCount int64 `json:"dirCount,omitempty"`
+	// retentionReasons lists the retention rules (e.g. "latest-1", "daily-3")
+	// that currently keep the snapshot
+	//+optional
+	RetentionReasons []string `json:"retentionReasons,omitempty"`
+	// pins lists the pins that protect the snapshot from expiration
+	//+optional
+	Pins []string `json:"pins,omitempty"`
+}
+
+// KopiaSnapshot is a read-only VolSync resource describing a single snapshot
+// in a Kopia repository. KopiaSnapshots are created and removed by VolSync
+// when the snapshot catalog of a KopiaMaintenance is enabled, and can be
+// referenced by a ReplicationDestination to restore that exact snapshot.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Snapshot ID",type="string",JSONPath=`.spec.snapshotID`
+// +kubebuilder:printcolumn:name="Username",type="string",JSONPath=`.spec.identity.username`
+// +kubebuilder:printcolumn:name="Hostname",type="string",JSONPath=`.spec.identity.hostname`
+// +kubebuilder:printcolumn:name="End Time",type="string",format="date-time",JSONPath=`.status.endTime`
+// +kubebuilder:printcolumn:name="Size",type="integer",JSONPath=`.status.totalSizeBytes`
+// +kubebuilder:printcolumn:name="Files",type="integer",JSONPath=`.status.fileCount`
+// +kubebuilder:printcolumn:name="Retention",type="string",JSONPath=`.status.retentionReasons`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+type KopiaSnapshot struct {
+	metav1.TypeMeta `json:",inline"`
+	//+optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// spec identifies the snapshot in the repository.
+	Spec KopiaSnapshotSpec `json:"spec,omitempty"`
+	// status contains the details of the snapshot as listed from the
+	// repository.
+	//+optional
+	Status *KopiaSnapshotStatus `json:"status,omitempty"`
+}
+
+// KopiaSnapshotList contains a list of KopiaSnapshot
+// +kubebuilder:object:root=true
+type KopiaSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KopiaSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KopiaSnapshot{}, &KopiaSnapshotList{})
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This file may be used, at your option, according to either the GNU AGPL 3.0 or
+the Apache V2 license.
+
+---
+This program is free software: you can redistribute it and/or modify it under
+the terms of the GNU Affero General Public License as published by the Free
+Software Foundation, either version 3 of the License, or (at your option) any
+later version.
+
+This program is distributed in the hope that it will be useful, but WITHOUT ANY
+WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A
+PARTICULAR PURPOSE.  See the GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License along
+with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+---
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:validation:Required
+// +kubebuilder:validation:Required
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NotificationChannelType is the format of the notifications sent to a
+// NotificationChannel.
+// +kubebuilder:validation:Enum=Webhook;Slack;Alertmanager
+type NotificationChannelType string
+
+const (
+	// NotificationChannelWebhook POSTs a JSON document, optionally built from
+	// a template, to the URL
+	NotificationChannelWebhook NotificationChannelType = "Webhook"
+	// NotificationChannelSlack POSTs a message to a Slack incoming webhook
+	NotificationChannelSlack NotificationChannelType = "Slack"
+	// NotificationChannelAlertmanager POSTs alerts to the Alertmanager v2 API
+	NotificationChannelAlertmanager NotificationChannelType = "Alertmanager"
+)
+
+// NotificationEventType is a kind of synchronization event that can be sent to
+// a NotificationChannel.
+// +kubebuilder:validation:Enum=Failure;Recovery;Success
+type NotificationEventType string
+
+const (
+	// NotificationEventFailure is sent when a synchronization fails. Retried
+	// attempts of the same synchronization don't send it again.
+	NotificationEventFailure NotificationEventType = "Failure"
+	// NotificationEventRecovery is sent when a synchronization succeeds after
+	// a failure
+	NotificationEventRecovery NotificationEventType = "Recovery"
+	// NotificationEventSuccess is sent each time a synchronization succeeds,
+	// including recoveries
+	NotificationEventSuccess NotificationEventType = "Success"
+)
+
+// SecretKeyReference selects a key of a Secret in a given namespace
+type SecretKeyReference struct {
+	// namespace of the Secret.
+	Namespace string `json:"namespace"`
+	// name of the Secret.
+	Name string `json:"name"`
+	// key of the Secret's data to use.
+	Key string `json:"key"`
+}
+
+// NotificationChannelSpec defines where and how notifications are sent
+// nolint:lll
+// +kubebuilder:validation:XValidation:rule="has(self.url) != has(self.urlFrom)",message="exactly one of url and urlFrom must be set"
+type NotificationChannelSpec struct {
+	// type is the format of the notifications: Webhook, Slack or
+	// Alertmanager.
+	Type NotificationChannelType `json:"type"`
+	// url is the endpoint the notifications are POSTed to. For the
+	// Alertmanager type, this is the alerts endpoint of the v2 API (e.g.,
+	// http://alertmanager.monitoring:9093/api/v2/alerts).
+	//+kubebuilder:validation:Pattern=`^https?://`
+	//+optional
+	URL string `json:"url,omitempty"`
+	// urlFrom reads the url from a Secret, for endpoints that embed a token
+	// (e.g., Slack incoming webhooks). The Secret must be in the namespace
+	// VolSync runs in.
+	//+optional
+	URLFrom *SecretKeyReference `json:"urlFrom,omitempty"`
+	// headers are added to each request (e.g., Authorization).
+	//+optional
+	Headers map[string]string `json:"headers,omitempty"`
+	// bodyTemplate is a Go text/template that renders the JSON body of Webhook
+	// notifications. If not set, the event is sent as a JSON document. Only
+	// used with the Webhook type.
+	//+optional
+	BodyTemplate string `json:"bodyTemplate,omitempty"`
+	// timeout is how long to wait for the endpoint to respond. Defaults to
+	// 10s.
+	//+optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// NotificationReference sends the synchronization events of a
+// ReplicationSource or ReplicationDestination to a NotificationChannel
+type NotificationReference struct {
+	// channel is the name of the NotificationChannel.
+	//+kubebuilder:validation:MinLength=1
+	Channel string `json:"channel"`
+	// on is the list of events to send. Defaults to Failure and Recovery.
+	//+optional
+	On []NotificationEventType `json:"on,omitempty"`
+}
+
+// NotificationChannel is a cluster-wide destination for the synchronization
+// events of ReplicationSources and ReplicationDestinations, such as an HTTP
+// webhook, a Slack channel or an Alertmanager.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+type NotificationChannel struct {
+	metav1.TypeMeta `json:",inline"`
+	//+optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// spec is the desired state of the NotificationChannel.
+	Spec NotificationChannelSpec `json:"spec,omitempty"`
+}
+
+// NotificationChannelList contains a list of NotificationChannel
+// +kubebuilder:object:root=true
+type NotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationChannel `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NotificationChannel{}, &NotificationChannelList{})
+}
@@ -96,6 +96,59 @@ type ReplicationDestinationVolumeOptions struct {
 	// The default is false.
 	//+optional
 	CleanupTempPVC bool `json:"cleanupTempPVC,omitempty"`
+	// snapshotRetention keeps VolumeSnapshots of previous synchronizations
+	// instead of deleting each one when a new latestImage is taken. It can
+	// only be used with a copyMethod of Snapshot.
+	//+optional
+	SnapshotRetention *SnapshotRetentionPolicy `json:"snapshotRetention,omitempty"`
+	// capacityPolicy defines whether the destination volume keeps the
+	// capacity from the spec (Fixed, the default) or is expanded as the source
+	// volume grows (FollowSource). Expansion requires a StorageClass that
+	// allows volume expansion.
+	//+optional
+	CapacityPolicy CapacityPolicyType `json:"capacityPolicy,omitempty"`
+}
+
+// CapacityPolicyType defines how the capacity of the destination volume
+// changes
+// +kubebuilder:validation:Enum=Fixed;FollowSource
+type CapacityPolicyType string
+
+const (
+	// CapacityPolicyFixed keeps the capacity of the destination volume
+	CapacityPolicyFixed CapacityPolicyType = "Fixed"
+	// CapacityPolicyFollowSource expands the destination volume, and the
+	// mover's cache volume, when the source volume grows
+	CapacityPolicyFollowSource CapacityPolicyType = "FollowSource"
+)
+
+// SnapshotRetentionPolicy defines which VolumeSnapshots of the destination
+// volume are kept. A snapshot is kept if any of the rules selects it.
+type SnapshotRetentionPolicy struct {
+	// last is the number of most recent snapshots to keep, including the
+	// latestImage. Defaults to 1.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	Last *int32 `json:"last,omitempty"`
+	// hourly is the number of hours, with at least one snapshot, for which
+	// the most recent snapshot of the hour is kept.
+	//+kubebuilder:validation:Minimum=0
+	//+optional
+	Hourly *int32 `json:"hourly,omitempty"`
+	// daily is the number of days, with at least one snapshot, for which the
+	// most recent snapshot of the day is kept.
+	//+kubebuilder:validation:Minimum=0
+	//+optional
+	Daily *int32 `json:"daily,omitempty"`
+}
+
+// RetainedImage is a point-in-time image of the destination volume kept by a
+// snapshotRetention policy
+type RetainedImage struct {
+	// image is the retained VolumeSnapshot
+	Image corev1.TypedLocalObjectReference `json:"image"`
+	// syncTime is the time of the synchronization that produced the image.
+	SyncTime metav1.Time `json:"syncTime"`
 }
 
 type ReplicationDestinationRsyncSpec struct {
@@ -203,6 +256,18 @@ type ReplicationDestinationSpec struct {
 	// provider.
 	//+optional
 	External *ReplicationDestinationExternalSpec `json:"external,omitempty"`
+	// retryPolicy controls how failed synchronizations are retried.
+	//+optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// notifications sends the synchronization events to NotificationChannels.
+	//+optional
+	Notifications []NotificationReference `json:"notifications,omitempty"`
+	// syncHistoryLimit is the number of past synchronizations kept in the
+	// .status.syncHistory. Defaults to 10. Setting it to 0 disables the history.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	//+optional
+	SyncHistoryLimit *int32 `json:"syncHistoryLimit,omitempty"`
 	// paused can be used to temporarily stop replication. Defaults to "false".
 	//+optional
 	Paused bool `json:"paused,omitempty"`
@@ -230,6 +295,11 @@ type ReplicationDestinationResticCA CustomCASpec
 // ReplicationDestinationResticSpec defines the field for restic in replicationDestination.
 type ReplicationDestinationResticSpec struct {
 	ReplicationDestinationVolumeOptions `json:",inline"`
+	// Will be used for the dynamic destination PVC created by VolSync.
+	// Block volumes are restored from backups of block volumes.
+	// Defaults to "Filesystem"
+	//+optional
+	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
 	// Repository is the secret name containing repository info
 	Repository string `json:"repository,omitempty"`
 	// customCA is a custom CA that will be used to verify the remote
@@ -258,11 +328,24 @@ type ReplicationDestinationResticSpec struct {
 	// +kubebuilder:validation:Format="date-time"
 	//+optional
 	RestoreAsOf *string `json:"restoreAsOf,omitempty"`
+	// snapshotID is the ID of a restic snapshot to restore. It selects that
+	// exact snapshot and cannot be combined with restoreAsOf or previous.
+	//+optional
+	SnapshotID string `json:"snapshotID,omitempty"`
 	// enableFileDeletion will pass the --delete flag to the restic restore command.
 	// This will remove files and directories in the pvc that do not exist in the snapshot being restored.
 	// Defaults to false.
 	//+optional
 	EnableFileDeletion bool `json:"enableFileDeletion,omitempty"`
+	// restoreIncludes are patterns of the files and directories to restore,
+	// passed to `restic restore --include`. Patterns that start with a "/" are
+	// anchored at the root of the backed up volume. If empty, the whole
+	// snapshot is restored. When enableFileDeletion is set, only files that
+	// match the patterns are deleted.
+	// Example: ["/app/config", "*.sql"]
+	// +kubebuilder:validation:MaxItems=100
+	//+optional
+	RestoreIncludes []string `json:"restoreIncludes,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
@@ -304,6 +387,11 @@ type KopiaSourceIdentity struct {
 // For cross-namespace restores or custom identity, use SourceIdentity or provide both Username AND Hostname.
 type ReplicationDestinationKopiaSpec struct {
 	ReplicationDestinationVolumeOptions `json:",inline"`
+	// Will be used for the dynamic destination PVC created by VolSync.
+	// Block volumes are restored from backups of block volumes.
+	// Defaults to "Filesystem"
+	//+optional
+	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
 	// Repository is the secret name containing repository info.
 	// When using SourceIdentity, this can be auto-discovered from the ReplicationSource.
 	Repository string `json:"repository,omitempty"`
@@ -346,6 +434,17 @@ type ReplicationDestinationKopiaSpec struct {
 	// Previous specifies the number of snapshots to skip before selecting one to restore from
 	//+optional
 	Previous *int32 `json:"previous,omitempty"`
+	// snapshotName is the name of a KopiaSnapshot in the same namespace to
+	// restore. It selects that exact snapshot and cannot be combined with
+	// restoreAsOf, shallow or previous. If repository is empty, the repository
+	// of the KopiaSnapshot is used.
+	//+optional
+	SnapshotName string `json:"snapshotName,omitempty"`
+	// snapshotID is the Kopia ID of a snapshot to restore. It selects that
+	// exact snapshot and cannot be combined with snapshotName, restoreAsOf,
+	// shallow or previous.
+	//+optional
+	SnapshotID string `json:"snapshotID,omitempty"`
 	// PolicyConfig defines configuration for Kopia policy files
 	//+optional
 	PolicyConfig *KopiaPolicySpec `json:"policyConfig,omitempty"`
@@ -382,6 +481,22 @@ type ReplicationDestinationKopiaSpec struct {
 	// +kubebuilder:validation:MaxItems=20
 	// +optional
 	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+	// includePaths restricts the restore to the listed files or directories of
+	// the snapshot. Paths are relative to the root of the snapshot (a leading
+	// "/" is ignored) and are restored to the same location in the destination
+	// volume. If empty, the entire snapshot is restored. When enableFileDeletion
+	// is set, only the included paths are cleaned before the restore.
+	// Example: ["app/config", "db/dump.sql"]
+	// +kubebuilder:validation:MaxItems=100
+	//+optional
+	IncludePaths []string `json:"includePaths,omitempty"`
+	// excludePaths lists files or directories of the snapshot that should not
+	// be restored. Paths are relative to the root of the snapshot (a leading
+	// "/" is ignored). When used with includePaths, excluded paths are removed
+	// from within the included subtrees.
+	// +kubebuilder:validation:MaxItems=100
+	//+optional
+	ExcludePaths []string `json:"excludePaths,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
@@ -415,6 +530,11 @@ type ReplicationDestinationKopiaStatus struct {
 	// LastConfiguredContentCacheSizeLimitMB is the content cache limit that was last applied.
 	// +optional
 	LastConfiguredContentCacheSizeLimitMB *int32 `json:"lastConfiguredContentCacheSizeLimitMB,omitempty"`
+	// restoredPaths lists the snapshot paths that were restored by the most
+	// recent successful restore when includePaths or excludePaths are used.
+	// It is empty when the whole snapshot was restored.
+	// +optional
+	RestoredPaths []string `json:"restoredPaths,omitempty"`
 }
 
 // ReplicationDestinationStatus defines the observed state of ReplicationDestination
@@ -440,6 +560,10 @@ type ReplicationDestinationStatus struct {
 	// image.
 	//+optional
 	LatestImage *corev1.TypedLocalObjectReference `json:"latestImage,omitempty"`
+	// retainedImages lists the images kept by the snapshotRetention policy,
+	// most recent first. The first one is the latestImage.
+	//+optional
+	RetainedImages []RetainedImage `json:"retainedImages,omitempty"`
 	// Logs/Summary from latest mover job
 	//+optional
 	LatestMoverStatus *MoverStatus `json:"latestMoverStatus,omitempty"`
@@ -458,6 +582,18 @@ type ReplicationDestinationStatus struct {
 	// conditions represent the latest available observations of the
 	// destination's state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// retry tracks the failed attempts of the current synchronization.
+	//+optional
+	Retry *RetryStatus `json:"retry,omitempty"`
+	// progress is the progress of the running synchronization. Only the
+	// Restic and Kopia movers report it; it is never set for the Rclone,
+	// Rsync, Rsync-TLS and Syncthing movers. It is cleared when the
+	// synchronization completes.
+	//+optional
+	Progress *SyncProgress `json:"progress,omitempty"`
+	// syncHistory lists the most recent synchronizations, oldest first.
+	//+optional
+	SyncHistory []SyncHistoryEntry `json:"syncHistory,omitempty"`
 }
 
 // A ReplicationDestination is a VolSync resource that you can use to define the destination of a VolSync replication
@@ -91,6 +91,11 @@ type ReplicationSourceVolumeOptions struct {
 	// copyMethod is Snapshot. If not set, the default VSC is used.
 	//+optional
 	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+	// volumeGroupSnapshotClassName can be used to specify the
+	// VolumeGroupSnapshotClass to be used if copyMethod is GroupSnapshot. If not
+	// set, the default VolumeGroupSnapshotClass is used.
+	//+optional
+	VolumeGroupSnapshotClassName *string `json:"volumeGroupSnapshotClassName,omitempty"`
 }
 
 type ReplicationSourceRsyncSpec struct {
@@ -134,6 +139,10 @@ type ReplicationSourceRsyncSpec struct {
 	// pod being unschedulable or crashing due to limited resources.
 	// +optional
 	MoverResources *corev1.ResourceRequirements `json:"moverResources,omitempty"`
+	// transferPolicy limits the network bandwidth used by the data mover. Only
+	// the uploadLimit is supported, and it isn't applied to block volumes.
+	// +optional
+	TransferPolicy *TransferPolicy `json:"transferPolicy,omitempty"`
 }
 
 // ReplicationSourceRcloneSpec defines the field for rclone in replicationSource.
@@ -209,10 +218,83 @@ type ReplicationSourceResticSpec struct {
 	// then ran a backup.
 	// Unlock will not be run again unless spec.restic.unlock is set to a different value.
 	Unlock string `json:"unlock,omitempty"`
+	// check periodically verifies the integrity of the repository by running
+	// `restic check` after a backup.
+	//+optional
+	Check *ReplicationSourceResticCheckSpec `json:"check,omitempty"`
+	// includes restricts the backup to the listed files or directories of the
+	// volume. Paths are relative to the root of the volume (a leading "/" is
+	// ignored). If empty, the whole volume is backed up.
+	// Example: ["app/data", "config.yaml"]
+	// +kubebuilder:validation:MaxItems=100
+	//+optional
+	Includes []string `json:"includes,omitempty"`
+	// excludes are patterns of files and directories to leave out of the
+	// backup, passed to `restic backup --exclude`. Patterns that start with a
+	// "/" are anchored at the root of the volume.
+	// Example: ["/app/cache", "*.tmp"]
+	// +kubebuilder:validation:MaxItems=100
+	//+optional
+	Excludes []string `json:"excludes,omitempty"`
+	// caseInsensitiveExcludes are like excludes, but ignore the case of the
+	// file names (`restic backup --iexclude`).
+	// +kubebuilder:validation:MaxItems=100
+	//+optional
+	CaseInsensitiveExcludes []string `json:"caseInsensitiveExcludes,omitempty"`
+	// excludeIfPresent leaves out directories that contain a file with one of
+	// these names (`restic backup --exclude-if-present`).
+	// Example: [".nobackup", "CACHEDIR.TAG:Signature: 8a477f597d28d172789f06886806bc55"]
+	// +kubebuilder:validation:MaxItems=100
+	//+optional
+	ExcludeIfPresent []string `json:"excludeIfPresent,omitempty"`
+	// secondaryRepositories are additional repositories that the snapshots
+	// are copied to with `restic copy` after each backup.
+	// +kubebuilder:validation:MaxItems=5
+	//+optional
+	SecondaryRepositories []ResticSecondaryRepository `json:"secondaryRepositories,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
 
+// ResticSecondaryRepository is a repository that the snapshots of the primary
+// repository are copied to.
+type ResticSecondaryRepository struct {
+	// repository is the name of the Secret containing the configuration of the
+	// secondary repository, in the same format as the primary repository's.
+	// The repository is initialized with the chunker parameters of the primary
+	// repository if it doesn't exist.
+	//+kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+	// retain is the retention policy of the secondary repository. If not
+	// specified, only the last snapshot is kept, as for the primary repository.
+	//+optional
+	Retain *ResticRetainPolicy `json:"retain,omitempty"`
+}
+
+// ReplicationSourceResticCheckSpec configures the repository integrity checks
+// of the Restic mover.
+type ReplicationSourceResticCheckSpec struct {
+	// intervalDays defines how often to check the repository. Defaults to 7.
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	IntervalDays *int32 `json:"intervalDays,omitempty"`
+	// readDataSubset is passed to `restic check --read-data-subset` to also
+	// verify the contents of a subset of the pack files, either as a
+	// percentage ("10%") or as a fraction ("1/5"). If not specified, only the
+	// structure of the repository is checked.
+	//+kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?%|[0-9]+/[0-9]+)$`
+	//+optional
+	ReadDataSubset *string `json:"readDataSubset,omitempty"`
+}
+
+// ResticCheckResult is the result of a repository integrity check
+type ResticCheckResult string
+
+const (
+	ResticCheckPassed ResticCheckResult = "Passed"
+	ResticCheckFailed ResticCheckResult = "Failed"
+)
+
 // ReplicationSourceResticStatus defines the field for ReplicationSourceStatus in ReplicationSourceStatus
 type ReplicationSourceResticStatus struct {
 	// lastPruned in the object holding the time of last pruned
@@ -222,6 +304,34 @@ type ReplicationSourceResticStatus struct {
 	// restic repository.
 	//+optional
 	LastUnlocked string `json:"lastUnlocked,omitempty"`
+	// lastChecked is the time of the last repository integrity check
+	//+optional
+	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
+	// lastCheckResult is the result of the last repository integrity check
+	//+kubebuilder:validation:Enum=Passed;Failed
+	//+optional
+	LastCheckResult ResticCheckResult `json:"lastCheckResult,omitempty"`
+	// secondaryRepositories contains the status of the copies to each of the
+	// secondary repositories.
+	//+optional
+	SecondaryRepositories []ResticSecondaryRepositoryStatus `json:"secondaryRepositories,omitempty"`
+}
+
+// ResticSecondaryRepositoryStatus is the status of the copies to a secondary
+// repository
+type ResticSecondaryRepositoryStatus struct {
+	// repository is the name of the Secret of the secondary repository
+	Repository string `json:"repository"`
+	// lastCopied is the time of the last successful copy to the repository
+	//+optional
+	LastCopied *metav1.Time `json:"lastCopied,omitempty"`
+	// lastFailure is the time of the last failed copy to the repository
+	//+optional
+	LastFailure *metav1.Time `json:"lastFailure,omitempty"`
+	// consecutiveFailures is the number of copies to the repository that
+	// failed since the last successful copy
+	//+optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
 }
 
 // KopiaRetainPolicy defines the retention policy for Kopia backups
@@ -338,10 +448,53 @@ type ReplicationSourceKopiaSpec struct {
 	// +kubebuilder:validation:MaxItems=20
 	// +optional
 	AdditionalArgs []string `json:"additionalArgs,omitempty"`
+	// Verify enables reading back part of each new snapshot after the backup
+	// to check that the uploaded data can be restored.
+	// +optional
+	Verify *KopiaVerifySpec `json:"verify,omitempty"`
+	// SyncTo lists additional repositories that the repository is mirrored to
+	// with `kopia repository sync-to` after each backup.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	SyncTo []KopiaSyncTarget `json:"syncTo,omitempty"`
 
 	MoverConfig `json:",inline"`
 }
 
+// KopiaSyncTarget is a repository that the repository of a ReplicationSource
+// is mirrored to.
+type KopiaSyncTarget struct {
+	// Repository is the name of the Secret containing the backend
+	// configuration of the target, using the same keys as the repository
+	// Secret. KOPIA_PASSWORD is not needed since the target is an exact copy
+	// of the repository.
+	// +kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+}
+
+// KopiaVerifySpec configures the verification of snapshots after backup.
+// When neither everyNSyncs nor schedule is set, every snapshot is verified.
+// Only one of everyNSyncs and schedule may be set.
+type KopiaVerifySpec struct {
+	// FilesPercent is the percentage of files in the snapshot whose contents
+	// are downloaded and checked. Metadata of all files is always verified.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	// +optional
+	FilesPercent *int32 `json:"filesPercent,omitempty"`
+	// EveryNSyncs verifies the snapshot of every Nth successful sync.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	EveryNSyncs *int32 `json:"everyNSyncs,omitempty"`
+	// Schedule is a cronspec for when to verify. The first sync after each
+	// scheduled time verifies its snapshot.
+	// nolint:lll
+	//+kubebuilder:validation:Pattern=`^(@(annually|yearly|monthly|weekly|daily|hourly))|((((\d+,)*\d+|(\d+(\/|-)\d+)|\*(\/\d+)?)\s?){5})$`
+	// +optional
+	Schedule *string `json:"schedule,omitempty"`
+}
+
 // ReplicationSourceKopiaStatus defines the field for ReplicationSourceStatus in ReplicationSourceStatus
 type ReplicationSourceKopiaStatus struct {
 	// lastMaintenance in the object holding the time of last maintenance
@@ -363,6 +516,48 @@ type ReplicationSourceKopiaStatus struct {
 	// LastConfiguredContentCacheSizeLimitMB is the content cache limit that was last applied.
 	// +optional
 	LastConfiguredContentCacheSizeLimitMB *int32 `json:"lastConfiguredContentCacheSizeLimitMB,omitempty"`
+	// Verify holds the result of the last snapshot verification.
+	// +optional
+	Verify *KopiaVerifyStatus `json:"verify,omitempty"`
+	// SyncTo holds the status of the mirroring to each of the syncTo targets.
+	// +optional
+	SyncTo []KopiaSyncTargetStatus `json:"syncTo,omitempty"`
+}
+
+// KopiaSyncTargetStatus is the status of the mirroring to a syncTo target
+type KopiaSyncTargetStatus struct {
+	// Repository is the name of the Secret of the target.
+	Repository string `json:"repository"`
+	// LastSynced is the time of the last successful sync to the target.
+	// +optional
+	LastSynced *metav1.Time `json:"lastSynced,omitempty"`
+	// LastFailure is the time of the last failed sync to the target.
+	// +optional
+	LastFailure *metav1.Time `json:"lastFailure,omitempty"`
+	// ConsecutiveFailures is the number of syncs to the target that failed
+	// since the last successful one.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+}
+
+// KopiaVerifyStatus is the result of the last snapshot verification
+type KopiaVerifyStatus struct {
+	// LastVerified is the time of the last verification.
+	// +optional
+	LastVerified *metav1.Time `json:"lastVerified,omitempty"`
+	// SnapshotID is the id of the snapshot that was last verified.
+	// +optional
+	SnapshotID string `json:"snapshotID,omitempty"`
+	// VerifiedObjects is the number of objects checked by the last verification.
+	// +optional
+	VerifiedObjects int64 `json:"verifiedObjects,omitempty"`
+	// Errors is the number of errors found by the last verification.
+	// +optional
+	Errors int64 `json:"errors,omitempty"`
+	// SyncsSinceLastVerify counts the successful syncs whose snapshot was
+	// not verified since the last verification.
+	// +optional
+	SyncsSinceLastVerify int32 `json:"syncsSinceLastVerify,omitempty"`
 }
 
 // define the Syncthing field
@@ -385,10 +580,67 @@ type ReplicationSourceSyncthingSpec struct {
 	MoverConfig `json:",inline"`
 }
 
+// HookFailurePolicy determines what happens when a hook fails
+// +kubebuilder:validation:Enum=Fail;Continue
+type HookFailurePolicy string
+
+const (
+	// HookFailurePolicyFail fails the synchronization, which is retried
+	HookFailurePolicyFail HookFailurePolicy = "Fail"
+	// HookFailurePolicyContinue records the failure and carries on with the
+	// synchronization
+	HookFailurePolicyContinue HookFailurePolicy = "Continue"
+)
+
+// ReplicationSourceHook is a command exec'd in the application pods
+type ReplicationSourceHook struct {
+	// command is the command to exec in the container. It is not run in a
+	// shell.
+	//+kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+	// timeout is how long to wait for the command to complete in each pod.
+	// Defaults to 30s.
+	//+optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// onFailure determines whether the synchronization fails (Fail) or
+	// continues (Continue) when the command fails or times out. Defaults to
+	// Fail.
+	//+optional
+	OnFailure HookFailurePolicy `json:"onFailure,omitempty"`
+}
+
+// ReplicationSourceHooksSpec defines commands that are exec'd in the
+// application pods around the point-in-time copy of the source volume.
+type ReplicationSourceHooksSpec struct {
+	// podSelector selects the application pods, in the namespace of the
+	// ReplicationSource, the commands are exec'd in.
+	PodSelector metav1.LabelSelector `json:"podSelector"`
+	// containerName is the name of the container the commands are exec'd in.
+	//+kubebuilder:validation:MinLength=1
+	ContainerName string `json:"containerName"`
+	// before is run before the clone or snapshot of the source volume is
+	// taken, e.g. to quiesce the application. With the Direct copyMethod it is
+	// run before the data mover starts.
+	//+optional
+	Before *ReplicationSourceHook `json:"before,omitempty"`
+	// after is run once the clone or snapshot of the source volume is ready,
+	// e.g. to unquiesce the application. With the Direct copyMethod it is run
+	// once the synchronization completes.
+	//+optional
+	After *ReplicationSourceHook `json:"after,omitempty"`
+}
+
 // ReplicationSourceSpec defines the desired state of ReplicationSource
 type ReplicationSourceSpec struct {
 	// sourcePVC is the name of the PersistentVolumeClaim (PVC) to replicate.
 	SourcePVC string `json:"sourcePVC,omitempty"`
+	// sourcePVCSelector selects the PVCs, in the namespace of the
+	// ReplicationSource, to replicate together. The PVCs are copied at the same
+	// instant using a VolumeGroupSnapshot, so copyMethod must be GroupSnapshot.
+	// Each PVC is replicated in a subdirectory named after it. Only one of
+	// sourcePVC and sourcePVCSelector may be set.
+	//+optional
+	SourcePVCSelector *metav1.LabelSelector `json:"sourcePVCSelector,omitempty"`
 	// trigger determines when the latest state of the volume will be captured
 	// (and potentially replicated to the destination).
 	//+optional
@@ -415,6 +667,28 @@ type ReplicationSourceSpec struct {
 	// provider.
 	//+optional
 	External *ReplicationSourceExternalSpec `json:"external,omitempty"`
+	// hooks defines commands exec'd in the application pods before and after
+	// the point-in-time copy of the source volume is taken. They are supported
+	// by all movers and copy methods.
+	//+optional
+	Hooks *ReplicationSourceHooksSpec `json:"hooks,omitempty"`
+	// retryPolicy controls how failed synchronizations are retried.
+	//+optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+	// notifications sends the synchronization events to NotificationChannels.
+	//+optional
+	Notifications []NotificationReference `json:"notifications,omitempty"`
+	// syncHistoryLimit is the number of past synchronizations kept in the
+	// .status.syncHistory. Defaults to 10. Setting it to 0 disables the history.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	//+optional
+	SyncHistoryLimit *int32 `json:"syncHistoryLimit,omitempty"`
+	// maxStaleness is how old the last successful synchronization may be
+	// before the ReplicationSource is reported as Stale, whatever its trigger.
+	// If not set, staleness isn't reported.
+	//+optional
+	MaxStaleness *metav1.Duration `json:"maxStaleness,omitempty"`
 	// paused can be used to temporarily stop replication. Defaults to "false".
 	//+optional
 	Paused bool `json:"paused,omitempty"`
@@ -446,6 +720,29 @@ type ReplicationSourceSyncthingStatus struct {
 	Address string `json:"address,omitempty"`
 }
 
+// HookResult is the result of the latest run of a hook
+type HookResult struct {
+	// time is when the hook was last run
+	//+optional
+	Time *metav1.Time `json:"time,omitempty"`
+	// succeeded is whether the command succeeded in all selected pods
+	Succeeded bool `json:"succeeded"`
+	// message describes why the hook failed
+	//+optional
+	Message string `json:"message,omitempty"`
+}
+
+// ReplicationSourceHooksStatus holds the results of the hooks of the latest
+// synchronization
+type ReplicationSourceHooksStatus struct {
+	// before is the result of the latest run of the before hook
+	//+optional
+	Before *HookResult `json:"before,omitempty"`
+	// after is the result of the latest run of the after hook
+	//+optional
+	After *HookResult `json:"after,omitempty"`
+}
+
 // ReplicationSourceStatus defines the observed state of ReplicationSource
 type ReplicationSourceStatus struct {
 	// lastSyncTime is the time of the most recent successful synchronization.
@@ -488,6 +785,21 @@ type ReplicationSourceStatus struct {
 	// kopia contains status information for Kopia-based replication.
 	//+optional
 	Kopia *ReplicationSourceKopiaStatus `json:"kopia,omitempty"`
+	// hooks contains the results of the hooks of the latest synchronization.
+	//+optional
+	Hooks *ReplicationSourceHooksStatus `json:"hooks,omitempty"`
+	// retry tracks the failed attempts of the current synchronization.
+	//+optional
+	Retry *RetryStatus `json:"retry,omitempty"`
+	// progress is the progress of the running synchronization. Only the
+	// Restic and Kopia movers report it; it is never set for the Rclone,
+	// Rsync, Rsync-TLS and Syncthing movers. It is cleared when the
+	// synchronization completes.
+	//+optional
+	Progress *SyncProgress `json:"progress,omitempty"`
+	// syncHistory lists the most recent synchronizations, oldest first.
+	//+optional
+	SyncHistory []SyncHistoryEntry `json:"syncHistory,omitempty"`
 }
 
 // A ReplicationSource is a VolSync resource that you can use to define the source PVC and replication mover type,
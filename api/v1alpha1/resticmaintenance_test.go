@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"k8s.io/utils/ptr"
+)
+
+func TestResticMaintenance_GetSchedule(t *testing.T) {
+	rm := &ResticMaintenance{}
+	if got := rm.GetSchedule(); got != "0 3 * * 0" {
+		t.Errorf("ResticMaintenance.GetSchedule() = %v, want the default schedule", got)
+	}
+	rm.Spec.Trigger = &ResticMaintenanceTriggerSpec{Schedule: ptr.To("@daily")}
+	if got := rm.GetSchedule(); got != "@daily" {
+		t.Errorf("ResticMaintenance.GetSchedule() = %v, want @daily", got)
+	}
+}
+
+func TestResticMaintenance_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    ResticMaintenanceSpec
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			spec: ResticMaintenanceSpec{Repository: "restic-secret"},
+		},
+		{
+			name: "descriptor schedule",
+			spec: ResticMaintenanceSpec{
+				Repository: "restic-secret",
+				Trigger:    &ResticMaintenanceTriggerSpec{Schedule: ptr.To("@weekly")},
+			},
+		},
+		{
+			name:    "missing repository",
+			spec:    ResticMaintenanceSpec{},
+			wantErr: true,
+		},
+		{
+			name: "invalid schedule",
+			spec: ResticMaintenanceSpec{
+				Repository: "restic-secret",
+				Trigger:    &ResticMaintenanceTriggerSpec{Schedule: ptr.To("every day")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "schedule and manual trigger",
+			spec: ResticMaintenanceSpec{
+				Repository: "restic-secret",
+				Trigger: &ResticMaintenanceTriggerSpec{
+					Schedule: ptr.To("0 3 * * *"),
+					Manual:   "now",
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rm := &ResticMaintenance{Spec: tt.spec}
+			if err := rm.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ResticMaintenance.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This file may be used, at your option, according to either the GNU AGPL 3.0 or
+the Apache V2 license.
+
+---
+This program is free software: you can redistribute it and/or modify it under
+the terms of the GNU Affero General Public License as published by the Free
+Software Foundation, either version 3 of the License, or (at your option) any
+later version.
+
+This program is distributed in the hope that it will be useful, but WITHOUT ANY
+WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A
+PARTICULAR PURPOSE.  See the GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License along
+with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+---
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	cron "github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResticMaintenanceTriggerSpec defines when maintenance will be performed.
+type ResticMaintenanceTriggerSpec struct {
+	// schedule is a cronspec (https://en.wikipedia.org/wiki/Cron#Overview) that
+	// can be used to schedule maintenance to occur at regular, time-based
+	// intervals.
+	// nolint:lll
+	//+kubebuilder:validation:Pattern=`^(@(annually|yearly|monthly|weekly|daily|hourly))|((((\d+,)*\d+|(\d+(\/|-)\d+)|\*(\/\d+)?)\s?){5})$`
+	//+optional
+	Schedule *string `json:"schedule,omitempty"`
+	// manual is a string value that schedules a manual trigger.
+	// Once a maintenance completes then status.lastManualSync is set to the same string value.
+	// A consumer of a manual trigger should set spec.trigger.manual to a known value
+	// and then wait for lastManualSync to be updated by the operator to the same value,
+	// which means that the manual trigger will then pause and wait for further
+	// updates to the trigger.
+	//+optional
+	Manual string `json:"manual,omitempty"`
+}
+
+// ResticMaintenanceCheckSpec configures the `restic check` run after pruning.
+type ResticMaintenanceCheckSpec struct {
+	// ReadDataSubset is passed to `restic check --read-data-subset` to also
+	// verify the contents of a subset of the pack files, either as a
+	// percentage ("10%") or as a fraction ("1/5"). If not specified, only the
+	// structure of the repository is checked.
+	//+kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?%|[0-9]+/[0-9]+)$`
+	//+optional
+	ReadDataSubset *string `json:"readDataSubset,omitempty"`
+}
+
+// ResticMaintenanceSpec defines the desired state of ResticMaintenance
+type ResticMaintenanceSpec struct {
+	// Repository is the secret name containing the repository configuration,
+	// in the same format as used by ReplicationSources. The secret must exist
+	// in the same namespace as the ResticMaintenance resource.
+	// ReplicationSources in this namespace that use the same secret no longer
+	// prune the repository themselves.
+	//+kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+
+	// CustomCA is optional custom CA configuration for repository access.
+	//+optional
+	CustomCA *ReplicationSourceResticCA `json:"customCA,omitempty"`
+
+	// Trigger determines when maintenance will be performed.
+	// If not specified, defaults to a schedule of "0 3 * * 0" (weekly on
+	// Sunday at 3am).
+	//+optional
+	Trigger *ResticMaintenanceTriggerSpec `json:"trigger,omitempty"`
+
+	// Suspend can be used to temporarily stop maintenance. When true,
+	// the CronJob will not create new Jobs, but existing Jobs will be allowed
+	// to complete.
+	//+optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// Retain is the retention policy applied to the repository with
+	// `restic forget --prune`. If not specified, snapshots are not forgotten
+	// and only the data that is no longer referenced is pruned.
+	//+optional
+	Retain *ResticRetainPolicy `json:"retain,omitempty"`
+
+	// Check runs `restic check` on the repository after pruning.
+	// If not specified, the repository is not checked.
+	//+optional
+	Check *ResticMaintenanceCheckSpec `json:"check,omitempty"`
+
+	// SuccessfulJobsHistoryLimit specifies how many successful maintenance Jobs
+	// should be kept. Defaults to 3.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:default=3
+	//+optional
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+
+	// FailedJobsHistoryLimit specifies how many failed maintenance Jobs
+	// should be kept. Defaults to 1.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:default=1
+	//+optional
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+
+	// Resources represents compute resources required by the maintenance container.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/
+	//+optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ActiveDeadlineSeconds specifies the duration in seconds relative to the startTime
+	// that the job may be active before the system tries to terminate it.
+	// If not specified, defaults to 10800 (3 hours).
+	//+kubebuilder:validation:Minimum=600
+	//+kubebuilder:default=10800
+	//+optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// ServiceAccountName allows specifying a custom ServiceAccount for maintenance jobs.
+	// If not specified, the namespace's default ServiceAccount will be used.
+	//+optional
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+
+	// PodSecurityContext defines the security context for maintenance pods.
+	// If not specified, defaults to runAsUser: 1000, fsGroup: 1000, runAsNonRoot: true.
+	//+optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// MoverPodLabels that should be added to maintenance pods.
+	// These will be in addition to any labels that VolSync may add.
+	//+optional
+	MoverPodLabels map[string]string `json:"moverPodLabels,omitempty"`
+
+	// NodeSelector for maintenance pods.
+	//+optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations for maintenance pods.
+	//+optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity for maintenance pods.
+	//+optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// CacheCapacity can be used to set the size of the restic metadata cache volume
+	//+optional
+	CacheCapacity *resource.Quantity `json:"cacheCapacity,omitempty"`
+
+	// CacheStorageClassName can be used to set the StorageClass of the restic
+	// metadata cache volume. If neither this nor cacheAccessModes are set, the
+	// cache is an EmptyDir.
+	//+optional
+	CacheStorageClassName *string `json:"cacheStorageClassName,omitempty"`
+
+	// CacheAccessModes can be used to set the accessModes of restic metadata cache volume
+	//+optional
+	CacheAccessModes []corev1.PersistentVolumeAccessMode `json:"cacheAccessModes,omitempty"`
+}
+
+// ResticMaintenanceStatus defines the observed state of ResticMaintenance
+type ResticMaintenanceStatus struct {
+	// ObservedGeneration is the most recent generation observed by the controller.
+	//+optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ActiveCronJob is the name of the CronJob currently managed by this maintenance configuration.
+	//+optional
+	ActiveCronJob string `json:"activeCronJob,omitempty"`
+
+	// LastMaintenanceTime is the last time maintenance was successfully performed.
+	//+optional
+	LastMaintenanceTime *metav1.Time `json:"lastMaintenanceTime,omitempty"`
+
+	// NextScheduledMaintenance is the next scheduled maintenance time.
+	//+optional
+	NextScheduledMaintenance *metav1.Time `json:"nextScheduledMaintenance,omitempty"`
+
+	// LastManualSync is set to the last spec.trigger.manual when the manual maintenance is done.
+	//+optional
+	LastManualSync string `json:"lastManualSync,omitempty"`
+
+	// Conditions represent the latest available observations of the
+	// maintenance configuration's state.
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ResticMaintenance is a VolSync resource that prunes, and optionally checks,
+// a restic repository on its own schedule. It replaces the prune that
+// ReplicationSources would otherwise run after their backups.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Repository",type="string",JSONPath=`.spec.repository`
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=`.spec.trigger.schedule`
+// +kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=`.spec.suspend`
+// +kubebuilder:printcolumn:name="Last Maintenance",type="string",format="date-time",JSONPath=`.status.lastMaintenanceTime`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+type ResticMaintenance struct {
+	metav1.TypeMeta `json:",inline"`
+	//+optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// spec is the desired state of the ResticMaintenance.
+	Spec ResticMaintenanceSpec `json:"spec,omitempty"`
+	// status is the observed state of the ResticMaintenance as determined by
+	// the controller.
+	//+optional
+	Status *ResticMaintenanceStatus `json:"status,omitempty"`
+}
+
+// ResticMaintenanceList contains a list of ResticMaintenance
+// +kubebuilder:object:root=true
+type ResticMaintenanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResticMaintenance `json:"items"`
+}
+
+// GetSchedule returns the maintenance schedule
+func (rm *ResticMaintenance) GetSchedule() string {
+	if rm.Spec.Trigger != nil && rm.Spec.Trigger.Schedule != nil {
+		return *rm.Spec.Trigger.Schedule
+	}
+	return "0 3 * * 0" // Default schedule
+}
+
+// GetManualTrigger returns the manual trigger value if set
+func (rm *ResticMaintenance) GetManualTrigger() string {
+	if rm.Spec.Trigger != nil {
+		return rm.Spec.Trigger.Manual
+	}
+	return ""
+}
+
+// IsSuspended returns true if maintenance is suspended
+func (rm *ResticMaintenance) IsSuspended() bool {
+	return rm.Spec.Suspend != nil && *rm.Spec.Suspend
+}
+
+// GetActiveDeadlineSeconds returns the job timeout in seconds
+func (rm *ResticMaintenance) GetActiveDeadlineSeconds() int64 {
+	if rm.Spec.ActiveDeadlineSeconds != nil {
+		return *rm.Spec.ActiveDeadlineSeconds
+	}
+	// Default to 3 hours (10800 seconds)
+	return 10800
+}
+
+// Validate validates the ResticMaintenance configuration
+func (rm *ResticMaintenance) Validate() error {
+	if rm.Spec.Repository == "" {
+		return fmt.Errorf("repository field is required")
+	}
+
+	if rm.Spec.Trigger != nil {
+		if rm.Spec.Trigger.Schedule != nil && rm.Spec.Trigger.Manual != "" {
+			return fmt.Errorf("cannot specify both schedule and manual triggers")
+		}
+		if rm.Spec.Trigger.Schedule != nil {
+			parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+			if _, err := parser.Parse(*rm.Spec.Trigger.Schedule); err != nil {
+				return fmt.Errorf("invalid cron schedule format in trigger: %w", err)
+			}
+		}
+	}
+
+	if rm.Spec.Resources != nil {
+		if err := validateResourceRequirements(rm.Spec.Resources); err != nil {
+			return fmt.Errorf("invalid resource requirements: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	SchemeBuilder.Register(&ResticMaintenance{}, &ResticMaintenanceList{})
+}
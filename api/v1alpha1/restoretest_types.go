@@ -0,0 +1,213 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This file may be used, at your option, according to either the GNU AGPL 3.0 or
+the Apache V2 license.
+
+---
+This program is free software: you can redistribute it and/or modify it under
+the terms of the GNU Affero General Public License as published by the Free
+Software Foundation, either version 3 of the License, or (at your option) any
+later version.
+
+This program is distributed in the hope that it will be useful, but WITHOUT ANY
+WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A
+PARTICULAR PURPOSE.  See the GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License along
+with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+---
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:validation:Required
+// +kubebuilder:validation:Required
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RestoreTestTriggerSpec defines when a RestoreTest is run
+type RestoreTestTriggerSpec struct {
+	// schedule is a cronspec (https://en.wikipedia.org/wiki/Cron#Overview) that
+	// can be used to run the test at regular, time-based intervals.
+	// nolint:lll
+	//+kubebuilder:validation:Pattern=`^(@(annually|yearly|monthly|weekly|daily|hourly))|((((\d+,)*\d+|(\d+(\/|-)\d+)|\*(\/\d+)?)\s?){5})$`
+	//+optional
+	Schedule *string `json:"schedule,omitempty"`
+	// manual is a string value that schedules a manual test run.
+	// Once the test completes then status.lastManualTest is set to the same
+	// string value.
+	//+optional
+	Manual string `json:"manual,omitempty"`
+}
+
+// RestoreTestCheckerSpec defines the container that checks the restored data
+type RestoreTestCheckerSpec struct {
+	// image is the container image of the checker
+	//+kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+	// command is the entrypoint of the checker container
+	//+optional
+	Command []string `json:"command,omitempty"`
+	// args are the arguments of the checker container
+	//+optional
+	Args []string `json:"args,omitempty"`
+	// env is a list of environment variables to set in the checker container
+	//+optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// mountPath is where the restored volume is mounted in the checker
+	// container. Defaults to /data.
+	//+optional
+	MountPath string `json:"mountPath,omitempty"`
+	// resources represents compute resources required by the checker container.
+	//+optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// podSecurityContext allows specifying the PodSecurityContext of the
+	// checker pod
+	//+optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// serviceAccountName is the name of the service account the checker pod
+	// runs as
+	//+optional
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+}
+
+// RestoreTestSpec defines the desired state of a RestoreTest
+type RestoreTestSpec struct {
+	// sourceName is the name of the ReplicationSource, in the same namespace,
+	// whose backups are tested. It must use the restic or kopia mover.
+	//+kubebuilder:validation:MinLength=1
+	SourceName string `json:"sourceName"`
+	// trigger determines when the test is run
+	//+optional
+	Trigger *RestoreTestTriggerSpec `json:"trigger,omitempty"`
+	// capacity is the size of the temporary volume the backup is restored
+	// into. Defaults to the size of the source PVC.
+	//+optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// storageClassName is the StorageClass of the temporary volume. Defaults
+	// to the StorageClass of the source PVC.
+	//+optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// accessModes are the access modes of the temporary volume. Defaults to
+	// the access modes of the source PVC.
+	//+optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// checker is the container run against the restored volume. The test
+	// passes if it exits successfully.
+	Checker RestoreTestCheckerSpec `json:"checker"`
+	// paused can be used to temporarily stop running tests.
+	//+optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// RestoreTestResultType is the outcome of a restore test
+type RestoreTestResultType string
+
+const (
+	RestoreTestResultPassed RestoreTestResultType = "Passed"
+	RestoreTestResultFailed RestoreTestResultType = "Failed"
+)
+
+// RestoreTestResult records the outcome of a test run
+type RestoreTestResult struct {
+	// result is whether the backup could be restored and passed the checker
+	//+kubebuilder:validation:Enum=Passed;Failed
+	Result RestoreTestResultType `json:"result"`
+	// snapshotID is the ID of the snapshot that was restored
+	//+optional
+	SnapshotID string `json:"snapshotID,omitempty"`
+	// startTime is when the test run started
+	//+optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// completionTime is when the test run finished
+	//+optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// duration is how long the restore and check took
+	//+optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// message describes why the test failed
+	//+optional
+	Message string `json:"message,omitempty"`
+}
+
+// RestoreTestStatus defines the observed state of a RestoreTest
+type RestoreTestStatus struct {
+	// lastTestTime is the time of the most recently completed test.
+	//+optional
+	LastTestTime *metav1.Time `json:"lastTestTime,omitempty"`
+	// lastTestStartTime is the time the running test started.
+	//+optional
+	LastTestStartTime *metav1.Time `json:"lastTestStartTime,omitempty"`
+	// lastTestDuration is the amount of time it took to run the most recent
+	// test.
+	//+optional
+	LastTestDuration *metav1.Duration `json:"lastTestDuration,omitempty"`
+	// nextTestTime is the time when the next test is scheduled to start (for
+	// schedule-based tests).
+	//+optional
+	NextTestTime *metav1.Time `json:"nextTestTime,omitempty"`
+	// lastManualTest is set to the last spec.trigger.manual when the manual
+	// test is done.
+	//+optional
+	LastManualTest string `json:"lastManualTest,omitempty"`
+	// lastResult is the outcome of the most recently completed test.
+	//+optional
+	LastResult *RestoreTestResult `json:"lastResult,omitempty"`
+	// conditions represent the latest available observations of the test's
+	// state.
+	//+optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// RestoreTest periodically proves that the backups of a ReplicationSource can
+// be restored. Each run restores the latest backup into a temporary PVC
+// through a temporary ReplicationDestination, runs a checker container
+// against the restored data and records the outcome.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=`.spec.sourceName`
+// +kubebuilder:printcolumn:name="Last test",type="string",format="date-time",JSONPath=`.status.lastTestTime`
+// +kubebuilder:printcolumn:name="Result",type="string",JSONPath=`.status.lastResult.result`
+// +kubebuilder:printcolumn:name="Snapshot",type="string",JSONPath=`.status.lastResult.snapshotID`
+// +kubebuilder:printcolumn:name="Duration",type="string",JSONPath=`.status.lastTestDuration`
+// +kubebuilder:printcolumn:name="Next test",type="string",format="date-time",JSONPath=`.status.nextTestTime`
+type RestoreTest struct {
+	metav1.TypeMeta `json:",inline"`
+	//+optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// spec is the desired state of the RestoreTest.
+	Spec RestoreTestSpec `json:"spec,omitempty"`
+	// status is the observed state of the RestoreTest as determined by the
+	// controller.
+	//+optional
+	Status *RestoreTestStatus `json:"status,omitempty"`
+}
+
+// RestoreTestList contains a list of RestoreTest
+// +kubebuilder:object:root=true
+type RestoreTestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RestoreTest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RestoreTest{}, &RestoreTestList{})
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This file may be used, at your option, according to either the GNU AGPL 3.0 or
+the Apache V2 license.
+
+---
+This program is free software: you can redistribute it and/or modify it under
+the terms of the GNU Affero General Public License as published by the Free
+Software Foundation, either version 3 of the License, or (at your option) any
+later version.
+
+This program is distributed in the hope that it will be useful, but WITHOUT ANY
+WARRANTY; without even the implied warranty of MERCHANTABILITY or FITNESS FOR A
+PARTICULAR PURPOSE.  See the GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License along
+with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+---
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// +kubebuilder:validation:Required
+// +kubebuilder:validation:Required
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VolSyncRestoreKopiaSpec selects the backups of a Kopia repository to restore
+// nolint:lll
+// +kubebuilder:validation:XValidation:rule="has(self.sourceIdentity) || (has(self.username) && has(self.hostname))",message="the identity must be set with sourceIdentity or username and hostname"
+// +kubebuilder:validation:XValidation:rule="!(has(self.restoreAsOf) && has(self.snapshotID))",message="only one of restoreAsOf and snapshotID may be set"
+type VolSyncRestoreKopiaSpec struct {
+	// repository is the secret name containing repository info
+	//+kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+	// customCA is a custom CA that will be used to verify the remote
+	//+optional
+	CustomCA ReplicationDestinationKopiaCA `json:"customCA,omitempty"`
+	// sourceIdentity identifies the ReplicationSource whose snapshots are
+	// restored.
+	//+optional
+	SourceIdentity *KopiaSourceIdentity `json:"sourceIdentity,omitempty"`
+	// username is the username of the snapshots to restore, as an
+	// alternative to sourceIdentity.
+	//+optional
+	Username *string `json:"username,omitempty"`
+	// hostname is the hostname of the snapshots to restore, as an
+	// alternative to sourceIdentity.
+	//+optional
+	Hostname *string `json:"hostname,omitempty"`
+	// restoreAsOf refers to the backup that is most recent as of that time.
+	// The latest snapshot is restored if neither restoreAsOf nor snapshotID
+	// is set.
+	// +kubebuilder:validation:Format="date-time"
+	//+optional
+	RestoreAsOf *string `json:"restoreAsOf,omitempty"`
+	// snapshotID is the ID of the snapshot to restore.
+	//+optional
+	SnapshotID string `json:"snapshotID,omitempty"`
+
+	MoverConfig `json:",inline"`
+}
+
+// VolSyncRestoreResticSpec selects the backups of a Restic repository to
+// restore
+// nolint:lll
+// +kubebuilder:validation:XValidation:rule="!(has(self.restoreAsOf) && has(self.snapshotID))",message="only one of restoreAsOf and snapshotID may be set"
+type VolSyncRestoreResticSpec struct {
+	// repository is the secret name containing repository info
+	//+kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+	// customCA is a custom CA that will be used to verify the remote
+	//+optional
+	CustomCA ReplicationDestinationResticCA `json:"customCA,omitempty"`
+	// restoreAsOf refers to the backup that is most recent as of that time.
+	// The latest backup is restored if neither restoreAsOf nor snapshotID is
+	// set.
+	// +kubebuilder:validation:Format="date-time"
+	//+optional
+	RestoreAsOf *string `json:"restoreAsOf,omitempty"`
+	// snapshotID is the ID of the snapshot to restore.
+	//+optional
+	SnapshotID string `json:"snapshotID,omitempty"`
+
+	MoverConfig `json:",inline"`
+}
+
+// VolSyncRestoreRcloneSpec selects the Rclone remote path to restore
+type VolSyncRestoreRcloneSpec struct {
+	// RcloneConfigSection is the section in rclone_config file to use for the
+	// current job.
+	//+kubebuilder:validation:MinLength=1
+	RcloneConfigSection string `json:"rcloneConfigSection"`
+	// RcloneDestPath is the remote path to sync from.
+	//+kubebuilder:validation:MinLength=1
+	RcloneDestPath string `json:"rcloneDestPath"`
+	// RcloneConfig is the rclone secret name
+	//+kubebuilder:validation:MinLength=1
+	RcloneConfig string `json:"rcloneConfig"`
+	// customCA is a custom CA that will be used to verify the remote
+	//+optional
+	CustomCA CustomCASpec `json:"customCA,omitempty"`
+
+	MoverConfig `json:",inline"`
+}
+
+// VolSyncRestoreSpec defines the repository that PVCs referencing the
+// VolSyncRestore are populated from. Exactly one mover must be set.
+// nolint:lll
+// +kubebuilder:validation:XValidation:rule="[has(self.kopia), has(self.restic), has(self.rclone)].filter(x, x).size() == 1",message="exactly one of kopia, restic and rclone must be set"
+type VolSyncRestoreSpec struct {
+	// kopia restores a snapshot of a Kopia repository.
+	//+optional
+	Kopia *VolSyncRestoreKopiaSpec `json:"kopia,omitempty"`
+	// restic restores a backup of a Restic repository.
+	//+optional
+	Restic *VolSyncRestoreResticSpec `json:"restic,omitempty"`
+	// rclone restores the contents of an Rclone remote path.
+	//+optional
+	Rclone *VolSyncRestoreRcloneSpec `json:"rclone,omitempty"`
+}
+
+// VolSyncRestore is a volume populator data source that restores a PVC
+// directly from a backup repository. PVCs that reference a VolSyncRestore in
+// their dataSourceRef are populated by running the mover against a temporary
+// PVC, without a pre-existing ReplicationDestination.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+type VolSyncRestore struct {
+	metav1.TypeMeta `json:",inline"`
+	//+optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// spec is the repository to restore from.
+	Spec VolSyncRestoreSpec `json:"spec,omitempty"`
+}
+
+// VolSyncRestoreList contains a list of VolSyncRestore
+// +kubebuilder:object:root=true
+type VolSyncRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolSyncRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VolSyncRestore{}, &VolSyncRestoreList{})
+}
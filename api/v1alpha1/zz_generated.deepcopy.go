@@ -38,8 +38,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -58,6 +58,25 @@ func (in *CustomCASpec) DeepCopy() *CustomCASpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookResult) DeepCopyInto(out *HookResult) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookResult.
+func (in *HookResult) DeepCopy() *HookResult {
+	if in == nil {
+		return nil
+	}
+	out := new(HookResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KopiaActions) DeepCopyInto(out *KopiaActions) {
 	*out = *in
@@ -186,7 +205,7 @@ func (in *KopiaMaintenanceSpec) DeepCopyInto(out *KopiaMaintenanceSpec) {
 	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ActiveDeadlineSeconds != nil {
@@ -201,12 +220,12 @@ func (in *KopiaMaintenanceSpec) DeepCopyInto(out *KopiaMaintenanceSpec) {
 	}
 	if in.PodSecurityContext != nil {
 		in, out := &in.PodSecurityContext, &out.PodSecurityContext
-		*out = new(v1.PodSecurityContext)
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ContainerSecurityContext != nil {
 		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
-		*out = new(v1.SecurityContext)
+		*out = new(corev1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.MoverPodLabels != nil {
@@ -225,14 +244,14 @@ func (in *KopiaMaintenanceSpec) DeepCopyInto(out *KopiaMaintenanceSpec) {
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.CacheCapacity != nil {
@@ -247,7 +266,7 @@ func (in *KopiaMaintenanceSpec) DeepCopyInto(out *KopiaMaintenanceSpec) {
 	}
 	if in.CacheAccessModes != nil {
 		in, out := &in.CacheAccessModes, &out.CacheAccessModes
-		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
 	if in.MetadataCacheSizeLimitMB != nil {
@@ -272,6 +291,11 @@ func (in *KopiaMaintenanceSpec) DeepCopyInto(out *KopiaMaintenanceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SnapshotCatalog != nil {
+		in, out := &in.SnapshotCatalog, &out.SnapshotCatalog
+		*out = new(KopiaSnapshotCatalogSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaMaintenanceSpec.
@@ -301,7 +325,7 @@ func (in *KopiaMaintenanceStatus) DeepCopyInto(out *KopiaMaintenanceStatus) {
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -423,6 +447,153 @@ func (in *KopiaRetainPolicy) DeepCopy() *KopiaRetainPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaSnapshot) DeepCopyInto(out *KopiaSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(KopiaSnapshotStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaSnapshot.
+func (in *KopiaSnapshot) DeepCopy() *KopiaSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KopiaSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaSnapshotCatalogSpec) DeepCopyInto(out *KopiaSnapshotCatalogSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaSnapshotCatalogSpec.
+func (in *KopiaSnapshotCatalogSpec) DeepCopy() *KopiaSnapshotCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaSnapshotCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaSnapshotIdentity) DeepCopyInto(out *KopiaSnapshotIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaSnapshotIdentity.
+func (in *KopiaSnapshotIdentity) DeepCopy() *KopiaSnapshotIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaSnapshotIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaSnapshotList) DeepCopyInto(out *KopiaSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KopiaSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaSnapshotList.
+func (in *KopiaSnapshotList) DeepCopy() *KopiaSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KopiaSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaSnapshotSpec) DeepCopyInto(out *KopiaSnapshotSpec) {
+	*out = *in
+	out.Identity = in.Identity
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaSnapshotSpec.
+func (in *KopiaSnapshotSpec) DeepCopy() *KopiaSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaSnapshotStatus) DeepCopyInto(out *KopiaSnapshotStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RetentionReasons != nil {
+		in, out := &in.RetentionReasons, &out.RetentionReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pins != nil {
+		in, out := &in.Pins, &out.Pins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaSnapshotStatus.
+func (in *KopiaSnapshotStatus) DeepCopy() *KopiaSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KopiaSourceIdentity) DeepCopyInto(out *KopiaSourceIdentity) {
 	*out = *in
@@ -443,12 +614,99 @@ func (in *KopiaSourceIdentity) DeepCopy() *KopiaSourceIdentity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaSyncTarget) DeepCopyInto(out *KopiaSyncTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaSyncTarget.
+func (in *KopiaSyncTarget) DeepCopy() *KopiaSyncTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaSyncTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaSyncTargetStatus) DeepCopyInto(out *KopiaSyncTargetStatus) {
+	*out = *in
+	if in.LastSynced != nil {
+		in, out := &in.LastSynced, &out.LastSynced
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaSyncTargetStatus.
+func (in *KopiaSyncTargetStatus) DeepCopy() *KopiaSyncTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaSyncTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaVerifySpec) DeepCopyInto(out *KopiaVerifySpec) {
+	*out = *in
+	if in.FilesPercent != nil {
+		in, out := &in.FilesPercent, &out.FilesPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EveryNSyncs != nil {
+		in, out := &in.EveryNSyncs, &out.EveryNSyncs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaVerifySpec.
+func (in *KopiaVerifySpec) DeepCopy() *KopiaVerifySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaVerifySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KopiaVerifyStatus) DeepCopyInto(out *KopiaVerifyStatus) {
+	*out = *in
+	if in.LastVerified != nil {
+		in, out := &in.LastVerified, &out.LastVerified
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KopiaVerifyStatus.
+func (in *KopiaVerifyStatus) DeepCopy() *KopiaVerifyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KopiaVerifyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MoverConfig) DeepCopyInto(out *MoverConfig) {
 	*out = *in
 	if in.MoverSecurityContext != nil {
 		in, out := &in.MoverSecurityContext, &out.MoverSecurityContext
-		*out = new(v1.PodSecurityContext)
+		*out = new(corev1.PodSecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.MoverServiceAccount != nil {
@@ -465,12 +723,12 @@ func (in *MoverConfig) DeepCopyInto(out *MoverConfig) {
 	}
 	if in.MoverResources != nil {
 		in, out := &in.MoverResources, &out.MoverResources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.MoverAffinity != nil {
 		in, out := &in.MoverAffinity, &out.MoverAffinity
-		*out = new(v1.Affinity)
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.MoverVolumes != nil {
@@ -480,6 +738,11 @@ func (in *MoverConfig) DeepCopyInto(out *MoverConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TransferPolicy != nil {
+		in, out := &in.TransferPolicy, &out.TransferPolicy
+		*out = new(TransferPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MoverConfig.
@@ -495,6 +758,31 @@ func (in *MoverConfig) DeepCopy() *MoverConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MoverStatus) DeepCopyInto(out *MoverStatus) {
 	*out = *in
+	if in.BytesProcessed != nil {
+		in, out := &in.BytesProcessed, &out.BytesProcessed
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FilesProcessed != nil {
+		in, out := &in.FilesProcessed, &out.FilesProcessed
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceCapacity != nil {
+		in, out := &in.SourceCapacity, &out.SourceCapacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MoverStatus.
@@ -528,17 +816,17 @@ func (in *MoverVolumeSource) DeepCopyInto(out *MoverVolumeSource) {
 	*out = *in
 	if in.Secret != nil {
 		in, out := &in.Secret, &out.Secret
-		*out = new(v1.SecretVolumeSource)
+		*out = new(corev1.SecretVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.NFS != nil {
 		in, out := &in.NFS, &out.NFS
-		*out = new(v1.NFSVolumeSource)
+		*out = new(corev1.NFSVolumeSource)
 		**out = **in
 	}
 	if in.PersistentVolumeClaim != nil {
 		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
-		*out = new(v1.PersistentVolumeClaimVolumeSource)
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
 		**out = **in
 	}
 }
@@ -554,30 +842,57 @@ func (in *MoverVolumeSource) DeepCopy() *MoverVolumeSource {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationDestination) DeepCopyInto(out *ReplicationDestination) {
+func (in *NotificationChannel) DeepCopyInto(out *NotificationChannel) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(ReplicationDestinationStatus)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannel.
+func (in *NotificationChannel) DeepCopy() *NotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NotificationChannel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestination.
-func (in *ReplicationDestination) DeepCopy() *ReplicationDestination {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationChannelList) DeepCopyInto(out *NotificationChannelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NotificationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelList.
+func (in *NotificationChannelList) DeepCopy() *NotificationChannelList {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationDestination)
+	out := new(NotificationChannelList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ReplicationDestination) DeepCopyObject() runtime.Object {
+func (in *NotificationChannelList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -585,51 +900,139 @@ func (in *ReplicationDestination) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationDestinationExternalSpec) DeepCopyInto(out *ReplicationDestinationExternalSpec) {
+func (in *NotificationChannelSpec) DeepCopyInto(out *NotificationChannelSpec) {
 	*out = *in
-	if in.Parameters != nil {
-		in, out := &in.Parameters, &out.Parameters
+	if in.URLFrom != nil {
+		in, out := &in.URLFrom, &out.URLFrom
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestinationExternalSpec.
-func (in *ReplicationDestinationExternalSpec) DeepCopy() *ReplicationDestinationExternalSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelSpec.
+func (in *NotificationChannelSpec) DeepCopy() *NotificationChannelSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationDestinationExternalSpec)
+	out := new(NotificationChannelSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationDestinationKopiaCA) DeepCopyInto(out *ReplicationDestinationKopiaCA) {
+func (in *NotificationReference) DeepCopyInto(out *NotificationReference) {
 	*out = *in
+	if in.On != nil {
+		in, out := &in.On, &out.On
+		*out = make([]NotificationEventType, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestinationKopiaCA.
-func (in *ReplicationDestinationKopiaCA) DeepCopy() *ReplicationDestinationKopiaCA {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationReference.
+func (in *NotificationReference) DeepCopy() *NotificationReference {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationDestinationKopiaCA)
+	out := new(NotificationReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationDestinationKopiaSpec) DeepCopyInto(out *ReplicationDestinationKopiaSpec) {
+func (in *ReplicationDestination) DeepCopyInto(out *ReplicationDestination) {
 	*out = *in
-	in.ReplicationDestinationVolumeOptions.DeepCopyInto(&out.ReplicationDestinationVolumeOptions)
-	out.CustomCA = in.CustomCA
-	if in.CacheCapacity != nil {
-		in, out := &in.CacheCapacity, &out.CacheCapacity
-		x := (*in).DeepCopy()
-		*out = &x
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ReplicationDestinationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestination.
+func (in *ReplicationDestination) DeepCopy() *ReplicationDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplicationDestination) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationDestinationExternalSpec) DeepCopyInto(out *ReplicationDestinationExternalSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestinationExternalSpec.
+func (in *ReplicationDestinationExternalSpec) DeepCopy() *ReplicationDestinationExternalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationDestinationExternalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationDestinationKopiaCA) DeepCopyInto(out *ReplicationDestinationKopiaCA) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestinationKopiaCA.
+func (in *ReplicationDestinationKopiaCA) DeepCopy() *ReplicationDestinationKopiaCA {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationDestinationKopiaCA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationDestinationKopiaSpec) DeepCopyInto(out *ReplicationDestinationKopiaSpec) {
+	*out = *in
+	in.ReplicationDestinationVolumeOptions.DeepCopyInto(&out.ReplicationDestinationVolumeOptions)
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(corev1.PersistentVolumeMode)
+		**out = **in
+	}
+	out.CustomCA = in.CustomCA
+	if in.CacheCapacity != nil {
+		in, out := &in.CacheCapacity, &out.CacheCapacity
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 	if in.CacheStorageClassName != nil {
 		in, out := &in.CacheStorageClassName, &out.CacheStorageClassName
@@ -638,7 +1041,7 @@ func (in *ReplicationDestinationKopiaSpec) DeepCopyInto(out *ReplicationDestinat
 	}
 	if in.CacheAccessModes != nil {
 		in, out := &in.CacheAccessModes, &out.CacheAccessModes
-		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
 	if in.MetadataCacheSizeLimitMB != nil {
@@ -696,6 +1099,16 @@ func (in *ReplicationDestinationKopiaSpec) DeepCopyInto(out *ReplicationDestinat
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IncludePaths != nil {
+		in, out := &in.IncludePaths, &out.IncludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePaths != nil {
+		in, out := &in.ExcludePaths, &out.ExcludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -729,6 +1142,11 @@ func (in *ReplicationDestinationKopiaStatus) DeepCopyInto(out *ReplicationDestin
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RestoredPaths != nil {
+		in, out := &in.RestoredPaths, &out.RestoredPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestinationKopiaStatus.
@@ -825,6 +1243,11 @@ func (in *ReplicationDestinationResticCA) DeepCopy() *ReplicationDestinationRest
 func (in *ReplicationDestinationResticSpec) DeepCopyInto(out *ReplicationDestinationResticSpec) {
 	*out = *in
 	in.ReplicationDestinationVolumeOptions.DeepCopyInto(&out.ReplicationDestinationVolumeOptions)
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(corev1.PersistentVolumeMode)
+		**out = **in
+	}
 	out.CustomCA = in.CustomCA
 	if in.CacheCapacity != nil {
 		in, out := &in.CacheCapacity, &out.CacheCapacity
@@ -838,7 +1261,7 @@ func (in *ReplicationDestinationResticSpec) DeepCopyInto(out *ReplicationDestina
 	}
 	if in.CacheAccessModes != nil {
 		in, out := &in.CacheAccessModes, &out.CacheAccessModes
-		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
 	if in.Previous != nil {
@@ -851,6 +1274,11 @@ func (in *ReplicationDestinationResticSpec) DeepCopyInto(out *ReplicationDestina
 		*out = new(string)
 		**out = **in
 	}
+	if in.RestoreIncludes != nil {
+		in, out := &in.RestoreIncludes, &out.RestoreIncludes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -870,7 +1298,7 @@ func (in *ReplicationDestinationRsyncSpec) DeepCopyInto(out *ReplicationDestinat
 	in.ReplicationDestinationVolumeOptions.DeepCopyInto(&out.ReplicationDestinationVolumeOptions)
 	if in.VolumeMode != nil {
 		in, out := &in.VolumeMode, &out.VolumeMode
-		*out = new(v1.PersistentVolumeMode)
+		*out = new(corev1.PersistentVolumeMode)
 		**out = **in
 	}
 	if in.SSHKeys != nil {
@@ -880,7 +1308,7 @@ func (in *ReplicationDestinationRsyncSpec) DeepCopyInto(out *ReplicationDestinat
 	}
 	if in.ServiceType != nil {
 		in, out := &in.ServiceType, &out.ServiceType
-		*out = new(v1.ServiceType)
+		*out = new(corev1.ServiceType)
 		**out = **in
 	}
 	if in.ServiceAnnotations != nil {
@@ -928,7 +1356,7 @@ func (in *ReplicationDestinationRsyncSpec) DeepCopyInto(out *ReplicationDestinat
 	}
 	if in.MoverResources != nil {
 		in, out := &in.MoverResources, &out.MoverResources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -979,7 +1407,7 @@ func (in *ReplicationDestinationRsyncTLSSpec) DeepCopyInto(out *ReplicationDesti
 	in.ReplicationDestinationVolumeOptions.DeepCopyInto(&out.ReplicationDestinationVolumeOptions)
 	if in.VolumeMode != nil {
 		in, out := &in.VolumeMode, &out.VolumeMode
-		*out = new(v1.PersistentVolumeMode)
+		*out = new(corev1.PersistentVolumeMode)
 		**out = **in
 	}
 	if in.KeySecret != nil {
@@ -989,7 +1417,7 @@ func (in *ReplicationDestinationRsyncTLSSpec) DeepCopyInto(out *ReplicationDesti
 	}
 	if in.ServiceType != nil {
 		in, out := &in.ServiceType, &out.ServiceType
-		*out = new(v1.ServiceType)
+		*out = new(corev1.ServiceType)
 		**out = **in
 	}
 	if in.ServiceAnnotations != nil {
@@ -1084,6 +1512,23 @@ func (in *ReplicationDestinationSpec) DeepCopyInto(out *ReplicationDestinationSp
 		*out = new(ReplicationDestinationExternalSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SyncHistoryLimit != nil {
+		in, out := &in.SyncHistoryLimit, &out.SyncHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestinationSpec.
@@ -1109,7 +1554,7 @@ func (in *ReplicationDestinationStatus) DeepCopyInto(out *ReplicationDestination
 	}
 	if in.LastSyncDuration != nil {
 		in, out := &in.LastSyncDuration, &out.LastSyncDuration
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.NextSyncTime != nil {
@@ -1118,13 +1563,20 @@ func (in *ReplicationDestinationStatus) DeepCopyInto(out *ReplicationDestination
 	}
 	if in.LatestImage != nil {
 		in, out := &in.LatestImage, &out.LatestImage
-		*out = new(v1.TypedLocalObjectReference)
+		*out = new(corev1.TypedLocalObjectReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RetainedImages != nil {
+		in, out := &in.RetainedImages, &out.RetainedImages
+		*out = make([]RetainedImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.LatestMoverStatus != nil {
 		in, out := &in.LatestMoverStatus, &out.LatestMoverStatus
 		*out = new(MoverStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Rsync != nil {
 		in, out := &in.Rsync, &out.Rsync
@@ -1150,7 +1602,24 @@ func (in *ReplicationDestinationStatus) DeepCopyInto(out *ReplicationDestination
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(SyncProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncHistory != nil {
+		in, out := &in.SyncHistory, &out.SyncHistory
+		*out = make([]SyncHistoryEntry, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1202,7 +1671,7 @@ func (in *ReplicationDestinationVolumeOptions) DeepCopyInto(out *ReplicationDest
 	}
 	if in.AccessModes != nil {
 		in, out := &in.AccessModes, &out.AccessModes
-		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
 	if in.VolumeSnapshotClassName != nil {
@@ -1215,6 +1684,11 @@ func (in *ReplicationDestinationVolumeOptions) DeepCopyInto(out *ReplicationDest
 		*out = new(string)
 		**out = **in
 	}
+	if in.SnapshotRetention != nil {
+		in, out := &in.SnapshotRetention, &out.SnapshotRetention
+		*out = new(SnapshotRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationDestinationVolumeOptions.
@@ -1280,6 +1754,82 @@ func (in *ReplicationSourceExternalSpec) DeepCopy() *ReplicationSourceExternalSp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSourceHook) DeepCopyInto(out *ReplicationSourceHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceHook.
+func (in *ReplicationSourceHook) DeepCopy() *ReplicationSourceHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSourceHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSourceHooksSpec) DeepCopyInto(out *ReplicationSourceHooksSpec) {
+	*out = *in
+	in.PodSelector.DeepCopyInto(&out.PodSelector)
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = new(ReplicationSourceHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = new(ReplicationSourceHook)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceHooksSpec.
+func (in *ReplicationSourceHooksSpec) DeepCopy() *ReplicationSourceHooksSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSourceHooksSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSourceHooksStatus) DeepCopyInto(out *ReplicationSourceHooksStatus) {
+	*out = *in
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = new(HookResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = new(HookResult)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceHooksStatus.
+func (in *ReplicationSourceHooksStatus) DeepCopy() *ReplicationSourceHooksStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSourceHooksStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicationSourceKopiaCA) DeepCopyInto(out *ReplicationSourceKopiaCA) {
 	*out = *in
@@ -1322,7 +1872,7 @@ func (in *ReplicationSourceKopiaSpec) DeepCopyInto(out *ReplicationSourceKopiaSp
 	}
 	if in.CacheAccessModes != nil {
 		in, out := &in.CacheAccessModes, &out.CacheAccessModes
-		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
 	if in.MetadataCacheSizeLimitMB != nil {
@@ -1365,6 +1915,16 @@ func (in *ReplicationSourceKopiaSpec) DeepCopyInto(out *ReplicationSourceKopiaSp
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(KopiaVerifySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncTo != nil {
+		in, out := &in.SyncTo, &out.SyncTo
+		*out = make([]KopiaSyncTarget, len(*in))
+		copy(*out, *in)
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -1399,6 +1959,18 @@ func (in *ReplicationSourceKopiaStatus) DeepCopyInto(out *ReplicationSourceKopia
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		*out = new(KopiaVerifyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncTo != nil {
+		in, out := &in.SyncTo, &out.SyncTo
+		*out = make([]KopiaSyncTargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceKopiaStatus.
@@ -1491,6 +2063,31 @@ func (in *ReplicationSourceResticCA) DeepCopy() *ReplicationSourceResticCA {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSourceResticCheckSpec) DeepCopyInto(out *ReplicationSourceResticCheckSpec) {
+	*out = *in
+	if in.IntervalDays != nil {
+		in, out := &in.IntervalDays, &out.IntervalDays
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReadDataSubset != nil {
+		in, out := &in.ReadDataSubset, &out.ReadDataSubset
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceResticCheckSpec.
+func (in *ReplicationSourceResticCheckSpec) DeepCopy() *ReplicationSourceResticCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSourceResticCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicationSourceResticSpec) DeepCopyInto(out *ReplicationSourceResticSpec) {
 	*out = *in
@@ -1518,9 +2115,41 @@ func (in *ReplicationSourceResticSpec) DeepCopyInto(out *ReplicationSourceRestic
 	}
 	if in.CacheAccessModes != nil {
 		in, out := &in.CacheAccessModes, &out.CacheAccessModes
-		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
+	if in.Check != nil {
+		in, out := &in.Check, &out.Check
+		*out = new(ReplicationSourceResticCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Excludes != nil {
+		in, out := &in.Excludes, &out.Excludes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CaseInsensitiveExcludes != nil {
+		in, out := &in.CaseInsensitiveExcludes, &out.CaseInsensitiveExcludes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeIfPresent != nil {
+		in, out := &in.ExcludeIfPresent, &out.ExcludeIfPresent
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecondaryRepositories != nil {
+		in, out := &in.SecondaryRepositories, &out.SecondaryRepositories
+		*out = make([]ResticSecondaryRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
 }
 
@@ -1541,6 +2170,17 @@ func (in *ReplicationSourceResticStatus) DeepCopyInto(out *ReplicationSourceRest
 		in, out := &in.LastPruned, &out.LastPruned
 		*out = (*in).DeepCopy()
 	}
+	if in.LastChecked != nil {
+		in, out := &in.LastChecked, &out.LastChecked
+		*out = (*in).DeepCopy()
+	}
+	if in.SecondaryRepositories != nil {
+		in, out := &in.SecondaryRepositories, &out.SecondaryRepositories
+		*out = make([]ResticSecondaryRepositoryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceResticStatus.
@@ -1564,7 +2204,7 @@ func (in *ReplicationSourceRsyncSpec) DeepCopyInto(out *ReplicationSourceRsyncSp
 	}
 	if in.ServiceType != nil {
 		in, out := &in.ServiceType, &out.ServiceType
-		*out = new(v1.ServiceType)
+		*out = new(corev1.ServiceType)
 		**out = **in
 	}
 	if in.Address != nil {
@@ -1601,7 +2241,12 @@ func (in *ReplicationSourceRsyncSpec) DeepCopyInto(out *ReplicationSourceRsyncSp
 	}
 	if in.MoverResources != nil {
 		in, out := &in.MoverResources, &out.MoverResources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransferPolicy != nil {
+		in, out := &in.TransferPolicy, &out.TransferPolicy
+		*out = new(TransferPolicy)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -1701,6 +2346,11 @@ func (in *ReplicationSourceRsyncTLSStatus) DeepCopy() *ReplicationSourceRsyncTLS
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicationSourceSpec) DeepCopyInto(out *ReplicationSourceSpec) {
 	*out = *in
+	if in.SourcePVCSelector != nil {
+		in, out := &in.SourcePVCSelector, &out.SourcePVCSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Trigger != nil {
 		in, out := &in.Trigger, &out.Trigger
 		*out = new(ReplicationSourceTriggerSpec)
@@ -1741,6 +2391,33 @@ func (in *ReplicationSourceSpec) DeepCopyInto(out *ReplicationSourceSpec) {
 		*out = new(ReplicationSourceExternalSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(ReplicationSourceHooksSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SyncHistoryLimit != nil {
+		in, out := &in.SyncHistoryLimit, &out.SyncHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxStaleness != nil {
+		in, out := &in.MaxStaleness, &out.MaxStaleness
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceSpec.
@@ -1766,7 +2443,7 @@ func (in *ReplicationSourceStatus) DeepCopyInto(out *ReplicationSourceStatus) {
 	}
 	if in.LastSyncDuration != nil {
 		in, out := &in.LastSyncDuration, &out.LastSyncDuration
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	if in.NextSyncTime != nil {
@@ -1776,7 +2453,7 @@ func (in *ReplicationSourceStatus) DeepCopyInto(out *ReplicationSourceStatus) {
 	if in.LatestMoverStatus != nil {
 		in, out := &in.LatestMoverStatus, &out.LatestMoverStatus
 		*out = new(MoverStatus)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Rsync != nil {
 		in, out := &in.Rsync, &out.Rsync
@@ -1797,7 +2474,7 @@ func (in *ReplicationSourceStatus) DeepCopyInto(out *ReplicationSourceStatus) {
 	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1817,6 +2494,28 @@ func (in *ReplicationSourceStatus) DeepCopyInto(out *ReplicationSourceStatus) {
 		*out = new(ReplicationSourceKopiaStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(ReplicationSourceHooksStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(SyncProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncHistory != nil {
+		in, out := &in.SyncHistory, &out.SyncHistory
+		*out = make([]SyncHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceStatus.
@@ -1839,7 +2538,7 @@ func (in *ReplicationSourceSyncthingSpec) DeepCopyInto(out *ReplicationSourceSyn
 	}
 	if in.ServiceType != nil {
 		in, out := &in.ServiceType, &out.ServiceType
-		*out = new(v1.ServiceType)
+		*out = new(corev1.ServiceType)
 		**out = **in
 	}
 	if in.ConfigCapacity != nil {
@@ -1854,7 +2553,7 @@ func (in *ReplicationSourceSyncthingSpec) DeepCopyInto(out *ReplicationSourceSyn
 	}
 	if in.ConfigAccessModes != nil {
 		in, out := &in.ConfigAccessModes, &out.ConfigAccessModes
-		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
 	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
@@ -1925,7 +2624,7 @@ func (in *ReplicationSourceVolumeOptions) DeepCopyInto(out *ReplicationSourceVol
 	}
 	if in.AccessModes != nil {
 		in, out := &in.AccessModes, &out.AccessModes
-		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
 		copy(*out, *in)
 	}
 	if in.VolumeSnapshotClassName != nil {
@@ -1933,6 +2632,11 @@ func (in *ReplicationSourceVolumeOptions) DeepCopyInto(out *ReplicationSourceVol
 		*out = new(string)
 		**out = **in
 	}
+	if in.VolumeGroupSnapshotClassName != nil {
+		in, out := &in.VolumeGroupSnapshotClassName, &out.VolumeGroupSnapshotClassName
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSourceVolumeOptions.
@@ -1946,15 +2650,259 @@ func (in *ReplicationSourceVolumeOptions) DeepCopy() *ReplicationSourceVolumeOpt
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResticRetainPolicy) DeepCopyInto(out *ResticRetainPolicy) {
+func (in *ResticMaintenance) DeepCopyInto(out *ResticMaintenance) {
 	*out = *in
-	if in.Hourly != nil {
-		in, out := &in.Hourly, &out.Hourly
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Daily != nil {
-		in, out := &in.Daily, &out.Daily
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ResticMaintenanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticMaintenance.
+func (in *ResticMaintenance) DeepCopy() *ResticMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResticMaintenance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticMaintenanceCheckSpec) DeepCopyInto(out *ResticMaintenanceCheckSpec) {
+	*out = *in
+	if in.ReadDataSubset != nil {
+		in, out := &in.ReadDataSubset, &out.ReadDataSubset
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticMaintenanceCheckSpec.
+func (in *ResticMaintenanceCheckSpec) DeepCopy() *ResticMaintenanceCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticMaintenanceCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticMaintenanceList) DeepCopyInto(out *ResticMaintenanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResticMaintenance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticMaintenanceList.
+func (in *ResticMaintenanceList) DeepCopy() *ResticMaintenanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticMaintenanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResticMaintenanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticMaintenanceSpec) DeepCopyInto(out *ResticMaintenanceSpec) {
+	*out = *in
+	if in.CustomCA != nil {
+		in, out := &in.CustomCA, &out.CustomCA
+		*out = new(ReplicationSourceResticCA)
+		**out = **in
+	}
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(ResticMaintenanceTriggerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Retain != nil {
+		in, out := &in.Retain, &out.Retain
+		*out = new(ResticRetainPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Check != nil {
+		in, out := &in.Check, &out.Check
+		*out = new(ResticMaintenanceCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ServiceAccountName != nil {
+		in, out := &in.ServiceAccountName, &out.ServiceAccountName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MoverPodLabels != nil {
+		in, out := &in.MoverPodLabels, &out.MoverPodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CacheCapacity != nil {
+		in, out := &in.CacheCapacity, &out.CacheCapacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CacheStorageClassName != nil {
+		in, out := &in.CacheStorageClassName, &out.CacheStorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.CacheAccessModes != nil {
+		in, out := &in.CacheAccessModes, &out.CacheAccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticMaintenanceSpec.
+func (in *ResticMaintenanceSpec) DeepCopy() *ResticMaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticMaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticMaintenanceStatus) DeepCopyInto(out *ResticMaintenanceStatus) {
+	*out = *in
+	if in.LastMaintenanceTime != nil {
+		in, out := &in.LastMaintenanceTime, &out.LastMaintenanceTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduledMaintenance != nil {
+		in, out := &in.NextScheduledMaintenance, &out.NextScheduledMaintenance
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticMaintenanceStatus.
+func (in *ResticMaintenanceStatus) DeepCopy() *ResticMaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticMaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticMaintenanceTriggerSpec) DeepCopyInto(out *ResticMaintenanceTriggerSpec) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticMaintenanceTriggerSpec.
+func (in *ResticMaintenanceTriggerSpec) DeepCopy() *ResticMaintenanceTriggerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticMaintenanceTriggerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResticRetainPolicy) DeepCopyInto(out *ResticRetainPolicy) {
+	*out = *in
+	if in.Hourly != nil {
+		in, out := &in.Hourly, &out.Hourly
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Daily != nil {
+		in, out := &in.Daily, &out.Daily
 		*out = new(int32)
 		**out = **in
 	}
@@ -1996,31 +2944,713 @@ func (in *ResticRetainPolicy) DeepCopy() *ResticRetainPolicy {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SyncthingPeer) DeepCopyInto(out *SyncthingPeer) {
+func (in *ResticSecondaryRepository) DeepCopyInto(out *ResticSecondaryRepository) {
 	*out = *in
+	if in.Retain != nil {
+		in, out := &in.Retain, &out.Retain
+		*out = new(ResticRetainPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncthingPeer.
-func (in *SyncthingPeer) DeepCopy() *SyncthingPeer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticSecondaryRepository.
+func (in *ResticSecondaryRepository) DeepCopy() *ResticSecondaryRepository {
 	if in == nil {
 		return nil
 	}
-	out := new(SyncthingPeer)
+	out := new(ResticSecondaryRepository)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SyncthingPeerStatus) DeepCopyInto(out *SyncthingPeerStatus) {
+func (in *ResticSecondaryRepositoryStatus) DeepCopyInto(out *ResticSecondaryRepositoryStatus) {
+	*out = *in
+	if in.LastCopied != nil {
+		in, out := &in.LastCopied, &out.LastCopied
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResticSecondaryRepositoryStatus.
+func (in *ResticSecondaryRepositoryStatus) DeepCopy() *ResticSecondaryRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResticSecondaryRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTest) DeepCopyInto(out *RestoreTest) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(RestoreTestStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncthingPeerStatus.
-func (in *SyncthingPeerStatus) DeepCopy() *SyncthingPeerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTest.
+func (in *RestoreTest) DeepCopy() *RestoreTest {
 	if in == nil {
 		return nil
 	}
-	out := new(SyncthingPeerStatus)
+	out := new(RestoreTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreTest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTestCheckerSpec) DeepCopyInto(out *RestoreTestCheckerSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountName != nil {
+		in, out := &in.ServiceAccountName, &out.ServiceAccountName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTestCheckerSpec.
+func (in *RestoreTestCheckerSpec) DeepCopy() *RestoreTestCheckerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTestCheckerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTestList) DeepCopyInto(out *RestoreTestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RestoreTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTestList.
+func (in *RestoreTestList) DeepCopy() *RestoreTestList {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreTestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTestResult) DeepCopyInto(out *RestoreTestResult) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTestResult.
+func (in *RestoreTestResult) DeepCopy() *RestoreTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTestSpec) DeepCopyInto(out *RestoreTestSpec) {
+	*out = *in
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(RestoreTestTriggerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	in.Checker.DeepCopyInto(&out.Checker)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTestSpec.
+func (in *RestoreTestSpec) DeepCopy() *RestoreTestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTestStatus) DeepCopyInto(out *RestoreTestStatus) {
+	*out = *in
+	if in.LastTestTime != nil {
+		in, out := &in.LastTestTime, &out.LastTestTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTestStartTime != nil {
+		in, out := &in.LastTestStartTime, &out.LastTestStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTestDuration != nil {
+		in, out := &in.LastTestDuration, &out.LastTestDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.NextTestTime != nil {
+		in, out := &in.NextTestTime, &out.NextTestTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastResult != nil {
+		in, out := &in.LastResult, &out.LastResult
+		*out = new(RestoreTestResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTestStatus.
+func (in *RestoreTestStatus) DeepCopy() *RestoreTestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreTestTriggerSpec) DeepCopyInto(out *RestoreTestTriggerSpec) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTestTriggerSpec.
+func (in *RestoreTestTriggerSpec) DeepCopy() *RestoreTestTriggerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreTestTriggerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainedImage) DeepCopyInto(out *RetainedImage) {
+	*out = *in
+	in.Image.DeepCopyInto(&out.Image)
+	in.SyncTime.DeepCopyInto(&out.SyncTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainedImage.
+func (in *RetainedImage) DeepCopy() *RetainedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]FailureClass, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryStatus) DeepCopyInto(out *RetryStatus) {
+	*out = *in
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RetriesExhaustedTime != nil {
+		in, out := &in.RetriesExhaustedTime, &out.RetriesExhaustedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryStatus.
+func (in *RetryStatus) DeepCopy() *RetryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRetentionPolicy) DeepCopyInto(out *SnapshotRetentionPolicy) {
+	*out = *in
+	if in.Last != nil {
+		in, out := &in.Last, &out.Last
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Hourly != nil {
+		in, out := &in.Hourly, &out.Hourly
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Daily != nil {
+		in, out := &in.Daily, &out.Daily
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRetentionPolicy.
+func (in *SnapshotRetentionPolicy) DeepCopy() *SnapshotRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncHistoryEntry) DeepCopyInto(out *SyncHistoryEntry) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BytesTransferred != nil {
+		in, out := &in.BytesTransferred, &out.BytesTransferred
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(corev1.TypedLocalObjectReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncHistoryEntry.
+func (in *SyncHistoryEntry) DeepCopy() *SyncHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncProgress) DeepCopyInto(out *SyncProgress) {
+	*out = *in
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncProgress.
+func (in *SyncProgress) DeepCopy() *SyncProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncthingPeer) DeepCopyInto(out *SyncthingPeer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncthingPeer.
+func (in *SyncthingPeer) DeepCopy() *SyncthingPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncthingPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncthingPeerStatus) DeepCopyInto(out *SyncthingPeerStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncthingPeerStatus.
+func (in *SyncthingPeerStatus) DeepCopy() *SyncthingPeerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncthingPeerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransferPolicy) DeepCopyInto(out *TransferPolicy) {
+	*out = *in
+	if in.UploadLimit != nil {
+		in, out := &in.UploadLimit, &out.UploadLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DownloadLimit != nil {
+		in, out := &in.DownloadLimit, &out.DownloadLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]TransferWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferPolicy.
+func (in *TransferPolicy) DeepCopy() *TransferPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TransferPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransferWindow) DeepCopyInto(out *TransferWindow) {
+	*out = *in
+	if in.UploadLimit != nil {
+		in, out := &in.UploadLimit, &out.UploadLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DownloadLimit != nil {
+		in, out := &in.DownloadLimit, &out.DownloadLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransferWindow.
+func (in *TransferWindow) DeepCopy() *TransferWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(TransferWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncRestore) DeepCopyInto(out *VolSyncRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncRestore.
+func (in *VolSyncRestore) DeepCopy() *VolSyncRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolSyncRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncRestoreKopiaSpec) DeepCopyInto(out *VolSyncRestoreKopiaSpec) {
+	*out = *in
+	out.CustomCA = in.CustomCA
+	if in.SourceIdentity != nil {
+		in, out := &in.SourceIdentity, &out.SourceIdentity
+		*out = new(KopiaSourceIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(string)
+		**out = **in
+	}
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(string)
+		**out = **in
+	}
+	if in.RestoreAsOf != nil {
+		in, out := &in.RestoreAsOf, &out.RestoreAsOf
+		*out = new(string)
+		**out = **in
+	}
+	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncRestoreKopiaSpec.
+func (in *VolSyncRestoreKopiaSpec) DeepCopy() *VolSyncRestoreKopiaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncRestoreKopiaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncRestoreList) DeepCopyInto(out *VolSyncRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolSyncRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncRestoreList.
+func (in *VolSyncRestoreList) DeepCopy() *VolSyncRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolSyncRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncRestoreRcloneSpec) DeepCopyInto(out *VolSyncRestoreRcloneSpec) {
+	*out = *in
+	out.CustomCA = in.CustomCA
+	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncRestoreRcloneSpec.
+func (in *VolSyncRestoreRcloneSpec) DeepCopy() *VolSyncRestoreRcloneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncRestoreRcloneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncRestoreResticSpec) DeepCopyInto(out *VolSyncRestoreResticSpec) {
+	*out = *in
+	out.CustomCA = in.CustomCA
+	if in.RestoreAsOf != nil {
+		in, out := &in.RestoreAsOf, &out.RestoreAsOf
+		*out = new(string)
+		**out = **in
+	}
+	in.MoverConfig.DeepCopyInto(&out.MoverConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncRestoreResticSpec.
+func (in *VolSyncRestoreResticSpec) DeepCopy() *VolSyncRestoreResticSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncRestoreResticSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolSyncRestoreSpec) DeepCopyInto(out *VolSyncRestoreSpec) {
+	*out = *in
+	if in.Kopia != nil {
+		in, out := &in.Kopia, &out.Kopia
+		*out = new(VolSyncRestoreKopiaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restic != nil {
+		in, out := &in.Restic, &out.Restic
+		*out = new(VolSyncRestoreResticSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rclone != nil {
+		in, out := &in.Rclone, &out.Rclone
+		*out = new(VolSyncRestoreRcloneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolSyncRestoreSpec.
+func (in *VolSyncRestoreSpec) DeepCopy() *VolSyncRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolSyncRestoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
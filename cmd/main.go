@@ -35,6 +35,7 @@ import (
 
 	_ "embed"
 
+	vgsv1beta2 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta2"
 	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	volumepopulatorv1beta1 "github.com/kubernetes-csi/volume-data-source-validator/client/apis/volumepopulator/v1beta1"
 	ocpsecurityv1 "github.com/openshift/api/security/v1"
@@ -52,12 +53,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
 	"github.com/backube/volsync/internal/controller"
 	"github.com/backube/volsync/internal/controller/mover"
 	"github.com/backube/volsync/internal/controller/platform"
 	"github.com/backube/volsync/internal/controller/utils"
+	webhookv1alpha1 "github.com/backube/volsync/internal/webhook/v1alpha1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -72,11 +75,15 @@ var (
 	// See each mover_<movertype>_register.go where they add themselves to
 	// enabledMovers
 	enabledMovers = []func() error{}
+	// Controllers that are only needed by some movers, also added by their
+	// mover_<movertype>_register.go
+	moverControllers = []func(mgr manager.Manager) error{}
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(snapv1.AddToScheme(scheme))
+	utilruntime.Must(vgsv1beta2.AddToScheme(scheme))
 	utilruntime.Must(volsyncv1alpha1.AddToScheme(scheme))
 	utilruntime.Must(ocpsecurityv1.AddToScheme(scheme))
 	utilruntime.Must(volumepopulatorv1beta1.AddToScheme(scheme))
@@ -119,10 +126,28 @@ func configureChecks(mgr manager.Manager) error {
 	return nil
 }
 
+// setupWebhooks registers the admission webhooks with the manager.
+func setupWebhooks(mgr manager.Manager) error {
+	if err := webhookv1alpha1.SetupReplicationSourceWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create webhook for ReplicationSource: %w", err)
+	}
+	if err := webhookv1alpha1.SetupReplicationDestinationWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create webhook for ReplicationDestination: %w", err)
+	}
+	if err := webhookv1alpha1.SetupKopiaMaintenanceWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create webhook for KopiaMaintenance: %w", err)
+	}
+	if err := webhookv1alpha1.SetupResticMaintenanceWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create webhook for ResticMaintenance: %w", err)
+	}
+	return nil
+}
+
 // addCommandFlags Configures flags to be bound to the VolSync command.
 func addCommandFlags(probeAddr *string, metricsAddr *string, enableLeaderElection *bool, secureMetrics *bool,
 	metricsRequireRBAC *bool, metricsCertPath *string, metricsCertName *string, metricsCertKey *string,
-	enableHTTP2 *bool) {
+	enableHTTP2 *bool, enableWebhooks *bool, webhookCertPath *string, webhookCertName *string,
+	webhookCertKey *string) {
 	flag.StringVar(metricsAddr, "metrics-bind-address", ":0", "The address the metric endpoint binds to."+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.BoolVar(secureMetrics, "metrics-secure", true,
@@ -140,9 +165,12 @@ func addCommandFlags(probeAddr *string, metricsAddr *string, enableLeaderElectio
 	flag.StringVar(&utils.MoverImagePullSecrets, "mover-image-pull-secrets", "",
 		"comma-separated list of pull secrets volsync should copy from its namespace and use for mover jobs")
 	flag.BoolVar(enableHTTP2, "enable-http2", false, "If set, HTTP/2 will be enabled for the metrics and webhook servers")
-	//flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
-	//flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
-	//flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	flag.BoolVar(enableWebhooks, "enable-webhooks", false,
+		"If set, the validating and defaulting admission webhooks will be served. "+
+			"A webhook certificate must be provided (see --webhook-cert-path).")
+	flag.StringVar(webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
+	flag.StringVar(webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
+	flag.StringVar(webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
 	flag.StringVar(metricsCertPath, "metrics-cert-path", "", "The directory that contains the metrics server certificate.")
 	flag.StringVar(metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
 	flag.StringVar(metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
@@ -212,11 +240,13 @@ func main() {
 	var enableLeaderElection bool
 	var enableHTTP2 bool
 	var metricsCertPath, metricsCertName, metricsCertKey string
-	//var webhookCertPath, webhookCertName, webhookCertKey string
+	var enableWebhooks bool
+	var webhookCertPath, webhookCertName, webhookCertKey string
 	var tlsOpts []func(*tls.Config)
 
 	addCommandFlags(&probeAddr, &metricsAddr, &enableLeaderElection, &secureMetrics,
-		&metricsRequireRBAC, &metricsCertPath, &metricsCertName, &metricsCertKey, &enableHTTP2)
+		&metricsRequireRBAC, &metricsCertPath, &metricsCertName, &metricsCertKey, &enableHTTP2,
+		&enableWebhooks, &webhookCertPath, &webhookCertName, &webhookCertKey)
 	printInfo()
 
 	leaseDuration := 137 * time.Second
@@ -240,34 +270,33 @@ func main() {
 
 	// Create watchers for metrics and webhooks certificates
 	var metricsCertWatcher *certwatcher.CertWatcher
-	//var webhookCertWatcher *certwatcher.CertWatcher
-
-	// // Initial webhook TLS options
-	// webhookTLSOpts := tlsOpts
-
-	// if len(webhookCertPath) > 0 {
-	// 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
-	// 			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
-
-	// 		var err error
-	// 		webhookCertWatcher, err = certwatcher.New(
-	// 			filepath.Join(webhookCertPath, webhookCertName),
-	// 			filepath.Join(webhookCertPath, webhookCertKey),
-	// 		)
-	// 		if err != nil {
-	// 			setupLog.Error(err, "Failed to initialize webhook certificate watcher")
-	// 			os.Exit(1)
-	// 		}
-
-	// 		webhookTLSOpts = append(webhookTLSOpts, func(config *tls.Config) {
-	// 			config.GetCertificate = webhookCertWatcher.GetCertificate
-	// 		})
-	// 	}
-	// }
-
-	// webhookServer := webhook.NewServer(webhook.Options{
-	// 	TLSOpts: webhookTLSOpts,
-	// })
+	var webhookCertWatcher *certwatcher.CertWatcher
+
+	// Initial webhook TLS options
+	webhookTLSOpts := tlsOpts
+
+	if enableWebhooks && len(webhookCertPath) > 0 {
+		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
+			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
+
+		var err error
+		webhookCertWatcher, err = certwatcher.New(
+			filepath.Join(webhookCertPath, webhookCertName),
+			filepath.Join(webhookCertPath, webhookCertKey),
+		)
+		if err != nil {
+			setupLog.Error(err, "Failed to initialize webhook certificate watcher")
+			os.Exit(1)
+		}
+
+		webhookTLSOpts = append(webhookTLSOpts, func(config *tls.Config) {
+			config.GetCertificate = webhookCertWatcher.GetCertificate
+		})
+	}
+
+	webhookServer := webhook.NewServer(webhook.Options{
+		TLSOpts: webhookTLSOpts,
+	})
 
 	// Metrics endpoint is enabled in 'config/default/kustomization.yaml'. The Metrics options configure the server.
 	// More info:
@@ -324,6 +353,7 @@ func main() {
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
+		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "b95b3104.backube",
@@ -346,6 +376,7 @@ func main() {
 	ensureCRs(cfg)
 
 	initPodLogsClient(cfg)
+	utils.InitPodExecClient(cfg)
 
 	// Index fields that are required for the ReplicationSource controller
 	if err := controller.IndexFieldsForReplicationSource(context.Background(), mgr.GetFieldIndexer()); err != nil {
@@ -371,6 +402,22 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "KopiaMaintenance")
 		os.Exit(1)
 	}
+	for _, setupController := range moverControllers {
+		if err = setupController(mgr); err != nil {
+			setupLog.Error(err, "unable to create mover controller")
+			os.Exit(1)
+		}
+	}
+	// Register the KopiaSnapshot catalog controller
+	if err = (&controller.KopiaSnapshotCatalogReconciler{
+		Client:        mgr.GetClient(),
+		Log:           ctrl.Log.WithName("controller").WithName("KopiaSnapshotCatalog"),
+		Scheme:        mgr.GetScheme(),
+		EventRecorder: mgr.GetEventRecorderFor("volsync-maintenance-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KopiaSnapshotCatalog")
+		os.Exit(1)
+	}
 	if err = (&controller.ReplicationDestinationReconciler{
 		Client:        mgr.GetClient(),
 		Log:           ctrl.Log.WithName("controller").WithName("ReplicationDestination"),
@@ -380,6 +427,15 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "ReplicationDestination")
 		os.Exit(1)
 	}
+	if err = (&controller.RestoreTestReconciler{
+		Client:        mgr.GetClient(),
+		Log:           ctrl.Log.WithName("controller").WithName("RestoreTest"),
+		Scheme:        mgr.GetScheme(),
+		EventRecorder: mgr.GetEventRecorderFor("volsync-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RestoreTest")
+		os.Exit(1)
+	}
 
 	// Index fields that are required for the VolumePopulator controller
 	if err := controller.IndexFieldsForVolumePopulator(context.Background(), mgr.GetFieldIndexer()); err != nil {
@@ -395,6 +451,12 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "VolumePopulator")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		if err = setupWebhooks(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhooks")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
@@ -405,13 +467,13 @@ func main() {
 		}
 	}
 
-	// if webhookCertWatcher != nil {
-	// 	setupLog.Info("Adding webhook certificate watcher to manager")
-	// 	if err := mgr.Add(webhookCertWatcher); err != nil {
-	// 		setupLog.Error(err, "unable to add webhook certificate watcher to manager")
-	// 		os.Exit(1)
-	// 	}
-	// }
+	if webhookCertWatcher != nil {
+		setupLog.Info("Adding webhook certificate watcher to manager")
+		if err := mgr.Add(webhookCertWatcher); err != nil {
+			setupLog.Error(err, "unable to add webhook certificate watcher to manager")
+			os.Exit(1)
+		}
+	}
 
 	if err := configureChecks(mgr); err != nil {
 		setupLog.Error(err, "unable to setup checks")
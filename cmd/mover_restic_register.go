@@ -20,9 +20,30 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package main
 
 import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/backube/volsync/internal/controller"
 	"github.com/backube/volsync/internal/controller/mover/restic"
 )
 
 func init() {
 	enabledMovers = append(enabledMovers, restic.Register)
+	moverControllers = append(moverControllers, setupResticMaintenanceController)
+}
+
+// setupResticMaintenanceController registers the controller for
+// ResticMaintenance, whose Jobs run the restic mover image
+func setupResticMaintenanceController(mgr manager.Manager) error {
+	if err := (&controller.ResticMaintenanceReconciler{
+		Client:        mgr.GetClient(),
+		Log:           ctrl.Log.WithName("controller").WithName("ResticMaintenance"),
+		Scheme:        mgr.GetScheme(),
+		EventRecorder: mgr.GetEventRecorderFor("volsync-maintenance-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create controller for ResticMaintenance: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,478 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+const (
+	// kopiaSnapshotCatalogLabel is set on catalog Jobs and KopiaSnapshots to
+	// the name of the KopiaMaintenance they belong to
+	kopiaSnapshotCatalogLabel = "volsync.backube/kopia-snapshot-catalog"
+	// kopiaCatalogResultAnnotation records on a finished catalog Job whether
+	// its output has been processed
+	kopiaCatalogResultAnnotation = "volsync.backube/catalog-result"
+	kopiaCatalogResultSucceeded  = "Succeeded"
+	kopiaCatalogResultFailed     = "Failed"
+
+	// Prefixes of the lines printed by the mover in catalog mode
+	kopiaCatalogSnapshotPrefix = "KOPIA_CATALOG_SNAPSHOT: "
+	kopiaCatalogCountPrefix    = "KOPIA_CATALOG_COUNT: "
+
+	// Lines the mover may print after the catalog, which are read along with
+	// it from the end of the logs
+	kopiaCatalogLogTrailingLines = 100
+
+	kopiaCatalogJobCheckInterval = 10 * time.Second
+	kopiaCatalogRetryInterval    = 5 * time.Minute
+)
+
+// kopiaCatalogResult is the catalog specific part of the mover result
+type kopiaCatalogResult struct {
+	CatalogCount *int64 `json:"catalogCount,omitempty"`
+}
+
+// kopiaCatalogSnapshot is a snapshot manifest as printed by
+// "kopia snapshot list --json"
+type kopiaCatalogSnapshot struct {
+	ID     string `json:"id"`
+	Source struct {
+		Host     string `json:"host"`
+		UserName string `json:"userName"`
+		Path     string `json:"path"`
+	} `json:"source"`
+	Description string    `json:"description"`
+	StartTime   time.Time `json:"startTime"`
+	EndTime     time.Time `json:"endTime"`
+	Stats       struct {
+		TotalSize int64 `json:"totalSize"`
+		FileCount int64 `json:"fileCount"`
+		DirCount  int64 `json:"dirCount"`
+	} `json:"stats"`
+	RetentionReason []string `json:"retentionReason"`
+	Pins            []string `json:"pins"`
+}
+
+// KopiaSnapshotCatalogReconciler periodically lists the snapshots in the
+// repository of each KopiaMaintenance with the snapshot catalog enabled and
+// keeps a KopiaSnapshot for each of them in the KopiaMaintenance's namespace.
+type KopiaSnapshotCatalogReconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	Log            logr.Logger
+	EventRecorder  record.EventRecorder
+	containerImage string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KopiaSnapshotCatalogReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.containerImage == "" {
+		r.containerImage = utils.GetDefaultKopiaImage()
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("kopiasnapshotcatalog").
+		For(&volsyncv1alpha1.KopiaMaintenance{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=volsync.backube,resources=kopiasnapshots,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=volsync.backube,resources=kopiasnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=volsync.backube,resources=kopiamaintenances,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get;list;watch
+
+// Reconcile refreshes the snapshot catalog of a KopiaMaintenance when it is due
+func (r *KopiaSnapshotCatalogReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("kopiamaintenance", req.NamespacedName)
+
+	km := &volsyncv1alpha1.KopiaMaintenance{}
+	if err := r.Get(ctx, req.NamespacedName, km); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Catalog Jobs and KopiaSnapshots are owned by the KopiaMaintenance, so
+	// they are garbage collected along with it
+	if !km.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if !km.IsSnapshotCatalogEnabled() {
+		return ctrl.Result{}, r.cleanupSnapshotCatalog(ctx, km)
+	}
+
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: catalogJobName(km), Namespace: km.Namespace}, job)
+	if apierrors.IsNotFound(err) {
+		if err := r.createCatalogJob(ctx, km); err != nil {
+			logger.Error(err, "Failed to create snapshot catalog job")
+			r.EventRecorder.Event(km, corev1.EventTypeWarning, "SnapshotCatalogFailed",
+				fmt.Sprintf("Failed to create snapshot catalog job: %v", err))
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		return ctrl.Result{RequeueAfter: kopiaCatalogJobCheckInterval}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	finishTime, succeeded := catalogJobState(job)
+	if finishTime == nil {
+		logger.V(1).Info("Snapshot catalog job still running", "job", job.Name)
+		return ctrl.Result{RequeueAfter: kopiaCatalogJobCheckInterval}, nil
+	}
+
+	result, processed := job.Annotations[kopiaCatalogResultAnnotation]
+	if !processed {
+		result = kopiaCatalogResultFailed
+		if succeeded {
+			count, err := r.refreshSnapshotCatalog(ctx, logger, km, job)
+			if err != nil {
+				logger.Error(err, "Failed to refresh snapshot catalog")
+				r.EventRecorder.Event(km, corev1.EventTypeWarning, "SnapshotCatalogFailed",
+					fmt.Sprintf("Failed to refresh snapshot catalog: %v", err))
+			} else {
+				result = kopiaCatalogResultSucceeded
+				logger.V(1).Info("Refreshed snapshot catalog", "snapshots", count)
+			}
+		} else {
+			r.EventRecorder.Event(km, corev1.EventTypeWarning, "SnapshotCatalogFailed",
+				fmt.Sprintf("Snapshot catalog job %s failed", job.Name))
+		}
+
+		patch := client.MergeFrom(job.DeepCopy())
+		metav1.SetMetaDataAnnotation(&job.ObjectMeta, kopiaCatalogResultAnnotation, result)
+		if err := r.Patch(ctx, job, patch); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Keep the finished job around until the next refresh is due so its
+	// completion time can be used for scheduling
+	nextRefresh := finishTime.Add(km.GetSnapshotCatalogInterval())
+	if result != kopiaCatalogResultSucceeded {
+		nextRefresh = finishTime.Add(kopiaCatalogRetryInterval)
+	}
+	if wait := time.Until(nextRefresh); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	logger.V(1).Info("Snapshot catalog refresh is due, removing previous job", "job", job.Name)
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: kopiaCatalogJobCheckInterval}, nil
+}
+
+// catalogJobName returns the name of the catalog Job of a KopiaMaintenance
+func catalogJobName(km *volsyncv1alpha1.KopiaMaintenance) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", km.Namespace, km.Name)))
+	return fmt.Sprintf("kopia-catalog-%x", hash[:8])
+}
+
+// kopiaSnapshotName returns the name of the KopiaSnapshot for a snapshot
+// catalogued by a KopiaMaintenance
+func kopiaSnapshotName(km *volsyncv1alpha1.KopiaMaintenance, snapshotID string) string {
+	return fmt.Sprintf("%s-%s", km.Name, strings.ToLower(snapshotID))
+}
+
+// catalogJobState returns the time a Job finished (nil if it is still
+// running) and whether it succeeded
+func catalogJobState(job *batchv1.Job) (*metav1.Time, bool) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			if job.Status.CompletionTime != nil {
+				return job.Status.CompletionTime, true
+			}
+			return &c.LastTransitionTime, true
+		case batchv1.JobFailed:
+			return &c.LastTransitionTime, false
+		}
+	}
+	return nil, false
+}
+
+// createCatalogJob starts a Job that lists all snapshots in the repository.
+// The pod is configured the same way as the maintenance jobs of the
+// KopiaMaintenance (cache, custom CA, security context, ...).
+func (r *KopiaSnapshotCatalogReconciler) createCatalogJob(ctx context.Context,
+	km *volsyncv1alpha1.KopiaMaintenance) error {
+	templateBuilder := &KopiaMaintenanceReconciler{
+		Client:         r.Client,
+		Scheme:         r.Scheme,
+		Log:            r.Log,
+		EventRecorder:  r.EventRecorder,
+		containerImage: r.containerImage,
+	}
+	cronJob, err := templateBuilder.buildMaintenanceCronJob(ctx, km, catalogJobName(km))
+	if err != nil {
+		return err
+	}
+
+	podTemplate := cronJob.Spec.JobTemplate.Spec.Template.DeepCopy()
+	podTemplate.Labels = map[string]string{
+		kopiaSnapshotCatalogLabel: km.Name,
+	}
+	for k, v := range km.Spec.MoverPodLabels {
+		podTemplate.Labels[k] = v
+	}
+	container := &podTemplate.Spec.Containers[0]
+	container.Name = "kopia-catalog"
+	for i := range container.Env {
+		if container.Env[i].Name == "DIRECTION" {
+			container.Env[i].Value = "catalog"
+		}
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      catalogJobName(km),
+			Namespace: km.Namespace,
+			Labels: map[string]string{
+				kopiaSnapshotCatalogLabel:      km.Name,
+				"app.kubernetes.io/name":       "volsync",
+				"app.kubernetes.io/component":  "kopia-snapshot-catalog",
+				"app.kubernetes.io/managed-by": "volsync",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(int32(2)),
+			ActiveDeadlineSeconds: ptr.To(km.GetActiveDeadlineSeconds()),
+			Template:              *podTemplate,
+		},
+	}
+	if err := ctrl.SetControllerReference(km, job, r.Scheme); err != nil {
+		return err
+	}
+
+	if err := r.Create(ctx, job); err != nil {
+		return err
+	}
+	r.Log.V(1).Info("Created snapshot catalog job", "job", job.Name, "namespace", job.Namespace)
+	return nil
+}
+
+// refreshSnapshotCatalog reads the snapshots listed by the catalog job and
+// updates the KopiaSnapshots to match them. The snapshots don't fit in the
+// mover result, which only has their number, so they are read from the end of
+// the logs of the job.
+func (r *KopiaSnapshotCatalogReconciler) refreshSnapshotCatalog(ctx context.Context, logger logr.Logger,
+	km *volsyncv1alpha1.KopiaMaintenance, job *batchv1.Job) (int, error) {
+	pod, result, err := utils.GetResultForSuccessfulJob(ctx, logger, job.Name, job.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	details := kopiaCatalogResult{}
+	if err := result.DecodeDetails(&details); err != nil {
+		return 0, err
+	}
+	if details.CatalogCount == nil {
+		return 0, fmt.Errorf("catalog job %s didn't report the number of snapshots", job.Name)
+	}
+	logs, err := utils.GetPodLogs(ctx, logger, pod, *details.CatalogCount+kopiaCatalogLogTrailingLines,
+		catalogLogFilter)
+	if err != nil {
+		return 0, err
+	}
+	snapshots, err := parseSnapshotCatalog(logs)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(snapshots), r.syncKopiaSnapshots(ctx, km, snapshots)
+}
+
+// syncKopiaSnapshots creates, updates and deletes the KopiaSnapshots of a
+// KopiaMaintenance to match the snapshots in the repository
+func (r *KopiaSnapshotCatalogReconciler) syncKopiaSnapshots(ctx context.Context,
+	km *volsyncv1alpha1.KopiaMaintenance, snapshots []kopiaCatalogSnapshot) error {
+	existing := &volsyncv1alpha1.KopiaSnapshotList{}
+	if err := r.List(ctx, existing, client.InNamespace(km.Namespace),
+		client.MatchingLabels{kopiaSnapshotCatalogLabel: km.Name}); err != nil {
+		return err
+	}
+	existingByName := map[string]*volsyncv1alpha1.KopiaSnapshot{}
+	for i := range existing.Items {
+		existingByName[existing.Items[i].Name] = &existing.Items[i]
+	}
+
+	for i := range snapshots {
+		name := kopiaSnapshotName(km, snapshots[i].ID)
+		ks, found := existingByName[name]
+		delete(existingByName, name)
+		if !found {
+			ks = &volsyncv1alpha1.KopiaSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: km.Namespace,
+					Labels: map[string]string{
+						kopiaSnapshotCatalogLabel: km.Name,
+					},
+				},
+				Spec: kopiaSnapshotSpec(km, &snapshots[i]),
+			}
+			if err := ctrl.SetControllerReference(km, ks, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(ctx, ks); err != nil {
+				return err
+			}
+		}
+
+		status := kopiaSnapshotStatus(&snapshots[i])
+		if ks.Status == nil || !equality.Semantic.DeepEqual(*ks.Status, *status) {
+			ks.Status = status
+			if err := r.Status().Update(ctx, ks); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Anything left is no longer in the repository
+	for _, ks := range existingByName {
+		if err := r.Delete(ctx, ks); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cleanupSnapshotCatalog removes the catalog Job and KopiaSnapshots of a
+// KopiaMaintenance that no longer has the snapshot catalog enabled
+func (r *KopiaSnapshotCatalogReconciler) cleanupSnapshotCatalog(ctx context.Context,
+	km *volsyncv1alpha1.KopiaMaintenance) error {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      catalogJobName(km),
+			Namespace: km.Namespace,
+		},
+	}
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return r.DeleteAllOf(ctx, &volsyncv1alpha1.KopiaSnapshot{}, client.InNamespace(km.Namespace),
+		client.MatchingLabels{kopiaSnapshotCatalogLabel: km.Name})
+}
+
+// catalogLogFilter keeps only the lines of the catalog job output that carry
+// results
+func catalogLogFilter(line string) *string {
+	if strings.HasPrefix(line, kopiaCatalogSnapshotPrefix) || strings.HasPrefix(line, kopiaCatalogCountPrefix) {
+		return &line
+	}
+	return nil
+}
+
+// parseSnapshotCatalog parses the snapshots printed by the mover in catalog
+// mode. The mover prints the number of snapshots last, which is used to
+// detect incomplete output so that KopiaSnapshots aren't deleted by mistake.
+func parseSnapshotCatalog(logs string) ([]kopiaCatalogSnapshot, error) {
+	snapshots := []kopiaCatalogSnapshot{}
+	expected := -1
+	for _, line := range strings.Split(logs, "\n") {
+		if data, found := strings.CutPrefix(line, kopiaCatalogSnapshotPrefix); found {
+			snapshot := kopiaCatalogSnapshot{}
+			if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+				return nil, fmt.Errorf("unable to parse snapshot from catalog output: %w", err)
+			}
+			if snapshot.ID == "" {
+				return nil, fmt.Errorf("snapshot without an id in catalog output")
+			}
+			snapshots = append(snapshots, snapshot)
+		} else if count, found := strings.CutPrefix(line, kopiaCatalogCountPrefix); found {
+			n, err := strconv.Atoi(strings.TrimSpace(count))
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse snapshot count from catalog output: %w", err)
+			}
+			expected = n
+		}
+	}
+
+	if expected < 0 {
+		return nil, fmt.Errorf("catalog output is incomplete: snapshot count not found")
+	}
+	if expected != len(snapshots) {
+		return nil, fmt.Errorf("catalog output is incomplete: expected %d snapshots, found %d",
+			expected, len(snapshots))
+	}
+	return snapshots, nil
+}
+
+func kopiaSnapshotSpec(km *volsyncv1alpha1.KopiaMaintenance,
+	snapshot *kopiaCatalogSnapshot) volsyncv1alpha1.KopiaSnapshotSpec {
+	return volsyncv1alpha1.KopiaSnapshotSpec{
+		Repository: km.GetRepositorySecret(),
+		SnapshotID: snapshot.ID,
+		Identity: volsyncv1alpha1.KopiaSnapshotIdentity{
+			Username: snapshot.Source.UserName,
+			Hostname: snapshot.Source.Host,
+			Path:     snapshot.Source.Path,
+		},
+	}
+}
+
+func kopiaSnapshotStatus(snapshot *kopiaCatalogSnapshot) *volsyncv1alpha1.KopiaSnapshotStatus {
+	status := &volsyncv1alpha1.KopiaSnapshotStatus{
+		Description:      snapshot.Description,
+		TotalSizeBytes:   snapshot.Stats.TotalSize,
+		FileCount:        snapshot.Stats.FileCount,
+		DirCount:         snapshot.Stats.DirCount,
+		RetentionReasons: snapshot.RetentionReason,
+		Pins:             snapshot.Pins,
+	}
+	if !snapshot.StartTime.IsZero() {
+		status.StartTime = &metav1.Time{Time: snapshot.StartTime.UTC().Truncate(time.Second)}
+	}
+	if !snapshot.EndTime.IsZero() {
+		status.EndTime = &metav1.Time{Time: snapshot.EndTime.UTC().Truncate(time.Second)}
+	}
+	return status
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testCatalogLogs = `KOPIA_CATALOG_SNAPSHOT: {"id":"k1a2b","source":{"host":"ns-pvc","userName":"app","path":"/data"},` +
+	`"description":"","startTime":"2026-03-01T02:00:00.123Z","endTime":"2026-03-01T02:05:00Z",` +
+	`"stats":{"totalSize":4096,"fileCount":3,"dirCount":1},"retentionReason":["latest-1","daily-1"],"pins":["keep"]}
+KOPIA_CATALOG_SNAPSHOT: {"id":"k3c4d","source":{"host":"ns-pvc","userName":"app","path":"/data"},` +
+	`"startTime":"2026-02-28T02:00:00Z","endTime":"2026-02-28T02:01:00Z","stats":{"totalSize":2048}}
+KOPIA_CATALOG_COUNT: 2`
+
+func TestParseSnapshotCatalog(t *testing.T) {
+	snapshots, err := parseSnapshotCatalog(testCatalogLogs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(snapshots))
+	}
+	s := snapshots[0]
+	if s.ID != "k1a2b" || s.Source.UserName != "app" || s.Source.Host != "ns-pvc" || s.Source.Path != "/data" {
+		t.Errorf("unexpected snapshot identity: %+v", s)
+	}
+	if s.Stats.TotalSize != 4096 || s.Stats.FileCount != 3 || s.Stats.DirCount != 1 {
+		t.Errorf("unexpected snapshot stats: %+v", s.Stats)
+	}
+	if len(s.RetentionReason) != 2 || len(s.Pins) != 1 {
+		t.Errorf("unexpected retention reasons or pins: %v %v", s.RetentionReason, s.Pins)
+	}
+
+	// An empty repository is valid
+	snapshots, err = parseSnapshotCatalog("KOPIA_CATALOG_COUNT: 0")
+	if err != nil || len(snapshots) != 0 {
+		t.Errorf("expected empty catalog, got %v, %v", snapshots, err)
+	}
+
+	// Incomplete output must not be used, it would delete KopiaSnapshots
+	incomplete := []string{
+		"",
+		strings.Replace(testCatalogLogs, "KOPIA_CATALOG_COUNT: 2", "KOPIA_CATALOG_COUNT: 3", 1),
+		strings.Split(testCatalogLogs, "\n")[0],
+		"KOPIA_CATALOG_SNAPSHOT: {\"id\":\nKOPIA_CATALOG_COUNT: 1",
+	}
+	for _, logs := range incomplete {
+		if _, err := parseSnapshotCatalog(logs); err == nil {
+			t.Errorf("expected an error for logs %q", logs)
+		}
+	}
+}
+
+func TestCatalogLogFilter(t *testing.T) {
+	if catalogLogFilter("KOPIA_CATALOG_COUNT: 2") == nil {
+		t.Error("expected count line to be kept")
+	}
+	if catalogLogFilter(`KOPIA_CATALOG_SNAPSHOT: {"id":"k1"}`) == nil {
+		t.Error("expected snapshot line to be kept")
+	}
+	if catalogLogFilter("[2026-03-01 02:00:00] [INFO] Connected to repository") != nil {
+		t.Error("expected other lines to be dropped")
+	}
+}
+
+func TestCatalogJobState(t *testing.T) {
+	completed := metav1.NewTime(time.Now().Add(-time.Minute))
+	tests := []struct {
+		name          string
+		conditions    []batchv1.JobCondition
+		wantFinished  bool
+		wantSucceeded bool
+	}{
+		{
+			name: "running",
+		},
+		{
+			name: "complete",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: completed},
+			},
+			wantFinished:  true,
+			wantSucceeded: true,
+		},
+		{
+			name: "failed",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: completed},
+			},
+			wantFinished: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &batchv1.Job{Status: batchv1.JobStatus{Conditions: tt.conditions}}
+			finished, succeeded := catalogJobState(job)
+			if (finished != nil) != tt.wantFinished || succeeded != tt.wantSucceeded {
+				t.Errorf("got finished=%v succeeded=%v", finished, succeeded)
+			}
+		})
+	}
+}
+
+func TestKopiaSnapshotCatalogReconciler_syncKopiaSnapshots(t *testing.T) {
+	ctx := context.Background()
+	s := scheme.Scheme
+	_ = volsyncv1alpha1.AddToScheme(s)
+
+	km := &volsyncv1alpha1.KopiaMaintenance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "km",
+			Namespace: "test-ns",
+			UID:       "km-uid",
+		},
+		Spec: volsyncv1alpha1.KopiaMaintenanceSpec{
+			Repository: volsyncv1alpha1.KopiaRepositorySpec{
+				Repository: "kopia-secret",
+			},
+		},
+	}
+	stale := &volsyncv1alpha1.KopiaSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "km-kdeleted",
+			Namespace: "test-ns",
+			Labels:    map[string]string{kopiaSnapshotCatalogLabel: "km"},
+		},
+		Spec: volsyncv1alpha1.KopiaSnapshotSpec{Repository: "kopia-secret", SnapshotID: "kdeleted"},
+	}
+	unrelated := &volsyncv1alpha1.KopiaSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-k1",
+			Namespace: "test-ns",
+			Labels:    map[string]string{kopiaSnapshotCatalogLabel: "other"},
+		},
+		Spec: volsyncv1alpha1.KopiaSnapshotSpec{Repository: "other-secret", SnapshotID: "k1"},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(km, stale, unrelated).
+		WithStatusSubresource(&volsyncv1alpha1.KopiaSnapshot{}).
+		Build()
+	r := &KopiaSnapshotCatalogReconciler{
+		Client:        fakeClient,
+		Scheme:        s,
+		Log:           logr.Discard(),
+		EventRecorder: &record.FakeRecorder{},
+	}
+
+	snapshots, err := parseSnapshotCatalog(testCatalogLogs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.syncKopiaSnapshots(ctx, km, snapshots); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Syncing again without changes must be a no-op
+	if err := r.syncKopiaSnapshots(ctx, km, snapshots); err != nil {
+		t.Fatalf("unexpected error on resync: %v", err)
+	}
+
+	list := &volsyncv1alpha1.KopiaSnapshotList{}
+	if err := fakeClient.List(ctx, list, client.InNamespace("test-ns"),
+		client.MatchingLabels{kopiaSnapshotCatalogLabel: "km"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Items) != 2 {
+		t.Fatalf("expected 2 KopiaSnapshots, got %d", len(list.Items))
+	}
+
+	ks := &volsyncv1alpha1.KopiaSnapshot{}
+	if err := fakeClient.Get(ctx, types.NamespacedName{Name: "km-k1a2b", Namespace: "test-ns"}, ks); err != nil {
+		t.Fatalf("expected KopiaSnapshot km-k1a2b: %v", err)
+	}
+	if ks.Spec.Repository != "kopia-secret" || ks.Spec.SnapshotID != "k1a2b" ||
+		ks.Spec.Identity.Username != "app" || ks.Spec.Identity.Hostname != "ns-pvc" {
+		t.Errorf("unexpected spec: %+v", ks.Spec)
+	}
+	if ks.Status == nil || ks.Status.TotalSizeBytes != 4096 || ks.Status.FileCount != 3 ||
+		len(ks.Status.RetentionReasons) != 2 || len(ks.Status.Pins) != 1 {
+		t.Errorf("unexpected status: %+v", ks.Status)
+	}
+	if ks.Status != nil && (ks.Status.StartTime == nil ||
+		!ks.Status.StartTime.Equal(&metav1.Time{Time: time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)})) {
+		t.Errorf("unexpected start time: %v", ks.Status.StartTime)
+	}
+	if len(ks.OwnerReferences) != 1 || ks.OwnerReferences[0].UID != km.UID {
+		t.Errorf("expected KopiaSnapshot to be owned by the KopiaMaintenance: %v", ks.OwnerReferences)
+	}
+
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(stale), &volsyncv1alpha1.KopiaSnapshot{}); err == nil {
+		t.Error("expected stale KopiaSnapshot to be deleted")
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(unrelated), &volsyncv1alpha1.KopiaSnapshot{}); err != nil {
+		t.Errorf("expected KopiaSnapshot of another KopiaMaintenance to be kept: %v", err)
+	}
+}
+
+func TestKopiaSnapshotCatalogReconciler_disabledCatalog(t *testing.T) {
+	ctx := context.Background()
+	s := scheme.Scheme
+	_ = volsyncv1alpha1.AddToScheme(s)
+
+	km := &volsyncv1alpha1.KopiaMaintenance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "km",
+			Namespace: "test-ns",
+		},
+		Spec: volsyncv1alpha1.KopiaMaintenanceSpec{
+			Repository: volsyncv1alpha1.KopiaRepositorySpec{
+				Repository: "kopia-secret",
+			},
+		},
+	}
+	catalogJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      catalogJobName(km),
+			Namespace: "test-ns",
+		},
+	}
+	ks := &volsyncv1alpha1.KopiaSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "km-k1",
+			Namespace: "test-ns",
+			Labels:    map[string]string{kopiaSnapshotCatalogLabel: "km"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(km, catalogJob, ks).
+		Build()
+	r := &KopiaSnapshotCatalogReconciler{
+		Client:        fakeClient,
+		Scheme:        s,
+		Log:           logr.Discard(),
+		EventRecorder: &record.FakeRecorder{},
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(km)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expected no requeue, got %v", result.RequeueAfter)
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(catalogJob), &batchv1.Job{}); err == nil {
+		t.Error("expected catalog job to be deleted")
+	}
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(ks), &volsyncv1alpha1.KopiaSnapshot{}); err == nil {
+		t.Error("expected KopiaSnapshot to be deleted")
+	}
+}
@@ -18,10 +18,15 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package controller
 
 import (
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
 )
 
 const (
@@ -30,9 +35,17 @@ const (
 
 // volsyncMetrics holds references to fully qualified instances of the metrics
 type volsyncMetrics struct {
-	MissedIntervals prometheus.Counter
-	OutOfSync       prometheus.Gauge
-	SyncDurations   prometheus.Observer
+	MissedIntervals       prometheus.Counter
+	OutOfSync             prometheus.Gauge
+	SyncDurations         prometheus.Observer
+	SyncDurationHistogram prometheus.Observer
+	LastSuccessfulSync    prometheus.Gauge
+	Stale                 prometheus.Gauge
+	TransferredBytes      prometheus.Counter
+	TransferredFiles      prometheus.Counter
+	SyncFailures          *prometheus.CounterVec
+	// key identifies the object the metrics belong to
+	key string
 }
 
 var (
@@ -69,17 +82,143 @@ var (
 		},
 		metricLabels,
 	)
+	syncDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:      "sync_duration_histogram_seconds",
+			Namespace: metricsNamespace,
+			Help:      "Duration of the synchronizations in seconds",
+			// Classic buckets from 1s to ~3 days for scrapers that don't
+			// support native histograms
+			Buckets:                         prometheus.ExponentialBuckets(1, 4, 10),
+			NativeHistogramBucketFactor:     1.1,
+			NativeHistogramMaxBucketNumber:  100,
+			NativeHistogramMinResetDuration: 24 * time.Hour,
+		},
+		metricLabels,
+	)
+	lastSuccessfulSync = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      "last_successful_sync_timestamp_seconds",
+			Namespace: metricsNamespace,
+			Help:      "Time of the last successful synchronization, in seconds since the epoch",
+		},
+		metricLabels,
+	)
+	stale = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      "sync_stale",
+			Namespace: metricsNamespace,
+			Help:      "Set to 1 if the last successful synchronization is older than the maxStaleness",
+		},
+		metricLabels,
+	)
+	transferredBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "transferred_bytes_total",
+			Namespace: metricsNamespace,
+			Help:      "The amount of data processed by the mover, as reported by the mover",
+		},
+		metricLabels,
+	)
+	transferredFiles = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "transferred_files_total",
+			Namespace: metricsNamespace,
+			Help:      "The number of files processed by the mover, as reported by the mover",
+		},
+		metricLabels,
+	)
+	syncFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "sync_failures_total",
+			Namespace: metricsNamespace,
+			Help:      "The number of failed synchronization attempts, by class of failure",
+		},
+		append(metricLabels, "reason"),
+	)
+
+	// transferTotals holds the last traffic total reported by each
+	// continuously synchronizing mover, by metrics key
+	transferTotals sync.Map
 )
 
 func newVolSyncMetrics(labels prometheus.Labels) volsyncMetrics {
 	return volsyncMetrics{
-		MissedIntervals: missedIntervals.With(labels),
-		OutOfSync:       outOfSync.With(labels),
-		SyncDurations:   syncDurations.With(labels),
+		MissedIntervals:       missedIntervals.With(labels),
+		OutOfSync:             outOfSync.With(labels),
+		SyncDurations:         syncDurations.With(labels),
+		SyncDurationHistogram: syncDurationHistogram.With(labels),
+		LastSuccessfulSync:    lastSuccessfulSync.With(labels),
+		Stale:                 stale.With(labels),
+		TransferredBytes:      transferredBytes.With(labels),
+		TransferredFiles:      transferredFiles.With(labels),
+		SyncFailures:          syncFailures.MustCurryWith(labels),
+		key:                   labels["obj_namespace"] + "/" + labels["obj_name"] + "/" + labels["role"],
+	}
+}
+
+// observeSyncDuration records the duration of a completed synchronization
+func (m volsyncMetrics) observeSyncDuration(duration time.Duration) {
+	m.SyncDurations.Observe(duration.Seconds())
+	m.SyncDurationHistogram.Observe(duration.Seconds())
+}
+
+// setLastSuccessfulSync records the time of the last successful
+// synchronization. It is set from the status of the object, so that it
+// survives restarts of the operator.
+func (m volsyncMetrics) setLastSuccessfulSync(last *metav1.Time) {
+	if !last.IsZero() {
+		m.LastSuccessfulSync.Set(float64(last.Unix()))
+	}
+}
+
+// setStale records whether the object is stale
+func (m volsyncMetrics) setStale(isStale bool) {
+	if isStale {
+		m.Stale.Set(1)
+	} else {
+		m.Stale.Set(0)
+	}
+}
+
+// observeSyncResult records the outcome of a synchronization attempt. The
+// amount of data transferred is taken from the status of the mover that
+// completed the synchronization.
+func (m volsyncMetrics) observeSyncResult(result mover.Result, moverStatus *volsyncv1alpha1.MoverStatus) {
+	if result.Failure != nil {
+		m.SyncFailures.WithLabelValues(string(result.Failure.Class)).Inc()
+	}
+	if result.TransferredBytesTotal != nil {
+		m.observeTransferTotal(*result.TransferredBytesTotal)
+	}
+	if !result.Completed || moverStatus == nil {
+		return
+	}
+	if moverStatus.BytesProcessed != nil && *moverStatus.BytesProcessed > 0 {
+		m.TransferredBytes.Add(float64(*moverStatus.BytesProcessed))
+	}
+	if moverStatus.FilesProcessed != nil && *moverStatus.FilesProcessed > 0 {
+		m.TransferredFiles.Add(float64(*moverStatus.FilesProcessed))
+	}
+}
+
+// observeTransferTotal adds the increase of the traffic total of a
+// continuously synchronizing mover to the transferred bytes. The total is
+// reset when the mover restarts, in which case all of it is new traffic.
+func (m volsyncMetrics) observeTransferTotal(total int64) {
+	last, found := transferTotals.Swap(m.key, total)
+	switch {
+	case !found:
+		// Nothing to compare to after the operator restarts
+	case total >= last.(int64):
+		m.TransferredBytes.Add(float64(total - last.(int64)))
+	default:
+		m.TransferredBytes.Add(float64(total))
 	}
 }
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(missedIntervals, outOfSync, syncDurations)
+	metrics.Registry.MustRegister(missedIntervals, outOfSync, syncDurations, syncDurationHistogram,
+		lastSuccessfulSync, stale, transferredBytes, transferredFiles, syncFailures)
 }
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+)
+
+func TestObserveSyncResult(t *testing.T) {
+	m := newVolSyncMetrics(prometheus.Labels{
+		"obj_name":      "metrics-test",
+		"obj_namespace": "test-ns",
+		"role":          "source",
+		"method":        "restic",
+	})
+
+	m.observeSyncResult(mover.Failed(mover.AttemptFailure{Class: volsyncv1alpha1.FailureClassAuth}), nil)
+	if got := testutil.ToFloat64(m.SyncFailures.WithLabelValues("Auth")); got != 1 {
+		t.Errorf("expected 1 Auth failure, got %v", got)
+	}
+
+	// Only completed synchronizations count the data they transferred
+	status := &volsyncv1alpha1.MoverStatus{BytesProcessed: ptr.To(int64(2048)), FilesProcessed: ptr.To(int64(3))}
+	m.observeSyncResult(mover.InProgress(), status)
+	m.observeSyncResult(mover.Complete(), status)
+	if got := testutil.ToFloat64(m.TransferredBytes); got != 2048 {
+		t.Errorf("expected 2048 bytes, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.TransferredFiles); got != 3 {
+		t.Errorf("expected 3 files, got %v", got)
+	}
+}
+
+func TestSetLastSuccessfulSync(t *testing.T) {
+	m := newVolSyncMetrics(prometheus.Labels{
+		"obj_name":      "metrics-test",
+		"obj_namespace": "test-ns",
+		"role":          "destination",
+		"method":        "restic",
+	})
+	m.setLastSuccessfulSync(nil)
+	if got := testutil.ToFloat64(m.LastSuccessfulSync); got != 0 {
+		t.Errorf("expected no last successful sync, got %v", got)
+	}
+	m.setLastSuccessfulSync(&metav1.Time{Time: time.Unix(1700000000, 0)})
+	if got := testutil.ToFloat64(m.LastSuccessfulSync); got != 1700000000 {
+		t.Errorf("expected the time of the last successful sync, got %v", got)
+	}
+}
+
+func TestObserveTransferTotal(t *testing.T) {
+	m := newVolSyncMetrics(prometheus.Labels{
+		"obj_name":      "metrics-test",
+		"obj_namespace": "test-ns",
+		"role":          "source",
+		"method":        "syncthing",
+	})
+	running := func(total int64) mover.Result {
+		result := mover.RetryAfter(0)
+		result.TransferredBytesTotal = ptr.To(total)
+		return result
+	}
+
+	// The first total is only a baseline, then its increases are counted,
+	// including after the mover restarts
+	for _, total := range []int64{1000, 1500, 1500, 200} {
+		m.observeSyncResult(running(total), nil)
+	}
+	if got := testutil.ToFloat64(m.TransferredBytes); got != 700 {
+		t.Errorf("expected 700 bytes, got %v", got)
+	}
+}
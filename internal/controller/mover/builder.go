@@ -18,6 +18,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package mover
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -30,6 +31,13 @@ import (
 var (
 	ErrNoMoverFound        = fmt.Errorf("a replication method must be specified")
 	ErrMultipleMoversFound = fmt.Errorf("only one replication method can be supplied")
+	// ErrGroupSnapshotNotSupported is returned by the Builders of movers that
+	// can't back up the PVCs of a VolumeGroupSnapshot
+	ErrGroupSnapshotNotSupported = fmt.Errorf(
+		"the GroupSnapshot copyMethod is only supported by the restic and kopia movers")
+	// ErrGroupSnapshotDestination is returned by the Builders of all movers
+	// for ReplicationDestinations using the GroupSnapshot copyMethod
+	ErrGroupSnapshotDestination = fmt.Errorf("the GroupSnapshot copyMethod is only supported by ReplicationSources")
 )
 
 // Catalog is the list of the available Builders for the controller to use when
@@ -75,12 +83,24 @@ func GetEnabledMoverList() []string {
 	return enabledMoverNames
 }
 
+// isGroupSnapshotError returns true for the errors of the Builders that reject
+// the GroupSnapshot copyMethod. Other errors are ignored, as if the Builder
+// didn't handle the CR.
+func isGroupSnapshotError(err error) bool {
+	return errors.Is(err, ErrGroupSnapshotNotSupported) || errors.Is(err, ErrGroupSnapshotDestination)
+}
+
 func GetDestinationMoverFromCatalog(client client.Client, logger logr.Logger,
 	eventRecorder events.EventRecorder,
 	destination *volsyncv1alpha1.ReplicationDestination, privileged bool) (Mover, error) {
 	var dataMover Mover
+	var groupSnapshotErr error
 	for _, builder := range Catalog {
 		candidate, err := builder.FromDestination(client, logger, eventRecorder, destination, privileged)
+		if isGroupSnapshotError(err) {
+			groupSnapshotErr = err
+			continue
+		}
 		if err == nil && candidate != nil {
 			if dataMover != nil {
 				// Found 2 movers claiming this CR...
@@ -89,6 +109,10 @@ func GetDestinationMoverFromCatalog(client client.Client, logger logr.Logger,
 			dataMover = candidate
 		}
 	}
+	if groupSnapshotErr != nil {
+		// The mover claims the CR but can't use its copyMethod
+		return nil, groupSnapshotErr
+	}
 	if dataMover == nil { // No mover matched
 		return nil, ErrNoMoverFound
 	}
@@ -99,8 +123,13 @@ func GetSourceMoverFromCatalog(client client.Client, logger logr.Logger,
 	eventRecorder events.EventRecorder,
 	source *volsyncv1alpha1.ReplicationSource, privileged bool) (Mover, error) {
 	var dataMover Mover
+	var groupSnapshotErr error
 	for _, builder := range Catalog {
 		candidate, err := builder.FromSource(client, logger, eventRecorder, source, privileged)
+		if isGroupSnapshotError(err) {
+			groupSnapshotErr = err
+			continue
+		}
 		if err == nil && candidate != nil {
 			if dataMover != nil {
 				// Found 2 movers claiming this CR...
@@ -109,6 +138,10 @@ func GetSourceMoverFromCatalog(client client.Client, logger logr.Logger,
 			dataMover = candidate
 		}
 	}
+	if groupSnapshotErr != nil {
+		// The mover claims the CR but can't use its copyMethod
+		return nil, groupSnapshotErr
+	}
 	if dataMover == nil { // No mover matched
 		return nil, ErrNoMoverFound
 	}
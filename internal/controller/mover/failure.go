@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mover
+
+import (
+	"slices"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+// AttemptFailure describes a failed synchronization attempt
+type AttemptFailure struct {
+	// Class is the kind of failure, used to decide whether it is retried
+	Class volsyncv1alpha1.FailureClass
+	// Message describes the failure
+	Message string
+}
+
+// Substrings of the mover logs that identify a class of failure. Auth and
+// repository failures are checked first since their messages often also
+// contain generic network errors.
+var failurePatterns = []struct {
+	class    volsyncv1alpha1.FailureClass
+	patterns []string
+}{
+	{volsyncv1alpha1.FailureClassAuth, []string{
+		"wrong password",
+		"invalid password",
+		"permission denied",
+		"access denied",
+		"accessdenied",
+		"unauthorized",
+		"invalidaccesskeyid",
+		"signaturedoesnotmatch",
+		"authentication failed",
+		"403 forbidden",
+	}},
+	{volsyncv1alpha1.FailureClassRepository, []string{
+		"repository does not exist",
+		"unable to open config file",
+		"repository not initialized",
+		"ciphertext verification failed",
+		"checksum mismatch",
+		"corrupt",
+		"invalid data returned",
+		"pack file cannot be listed",
+	}},
+	{volsyncv1alpha1.FailureClassTransient, []string{
+		"connection refused",
+		"connection reset",
+		"broken pipe",
+		"timeout",
+		"timed out",
+		"no such host",
+		"network is unreachable",
+		"tls handshake",
+		"temporarily unavailable",
+		"service unavailable",
+		"too many requests",
+		"i/o timeout",
+		"unexpected eof",
+		"repository is already locked",
+	}},
+}
+
+// ClassifyFailure determines the class of a failure from the logs of the mover
+func ClassifyFailure(logs string) volsyncv1alpha1.FailureClass {
+	logs = strings.ToLower(logs)
+	for _, fp := range failurePatterns {
+		for _, p := range fp.patterns {
+			if strings.Contains(logs, p) {
+				return fp.class
+			}
+		}
+	}
+	return volsyncv1alpha1.FailureClassUnknown
+}
+
+// JobFailure returns the AttemptFailure of a failed mover Job, classified from
+// the errors reported by the mover and the Job's logs
+func JobFailure(moverStatus *volsyncv1alpha1.MoverStatus) AttemptFailure {
+	failure := AttemptFailure{
+		Class:   volsyncv1alpha1.FailureClassUnknown,
+		Message: "mover job failed",
+	}
+	if moverStatus == nil {
+		return failure
+	}
+	failure.Class = ClassifyFailure(strings.Join(append(slices.Clone(moverStatus.Errors), moverStatus.Logs), "\n"))
+	if len(moverStatus.Errors) > 0 {
+		failure.Message += ": " + moverStatus.Errors[len(moverStatus.Errors)-1]
+	}
+	return failure
+}
+
+// JobBackoffLimit returns the BackoffLimit to set on a mover Job. When the
+// owner has a retryPolicy, failed synchronizations are retried by the state
+// machine, so the Job itself isn't retried.
+func JobBackoffLimit(owner client.Object, defaultLimit int32) *int32 {
+	var retryPolicy *volsyncv1alpha1.RetryPolicy
+	switch o := owner.(type) {
+	case *volsyncv1alpha1.ReplicationSource:
+		retryPolicy = o.Spec.RetryPolicy
+	case *volsyncv1alpha1.ReplicationDestination:
+		retryPolicy = o.Spec.RetryPolicy
+	}
+	if retryPolicy != nil {
+		defaultLimit = 0
+	}
+	return &defaultLimit
+}
+
+// JobFailed returns true once a mover Job has failed as many times as its
+// BackoffLimit allows
+func JobFailed(job *batchv1.Job) bool {
+	return job.Status.Failed > 0 && job.Status.Failed >= *job.Spec.BackoffLimit
+}
@@ -0,0 +1,62 @@
+//go:build !disable_kopia
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package kopia
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Kopia block volumes", func() {
+	var podSpec *corev1.PodSpec
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "kopia-sa"}}
+
+	BeforeEach(func() {
+		podSpec = &corev1.PodSpec{}
+	})
+
+	It("should attach block volumes as a device", func() {
+		mover := &Mover{isSource: true}
+		mover.configureContainer(podSpec, nil, []string{operationBackup}, false, true, sa)
+
+		container := podSpec.Containers[0]
+		Expect(container.VolumeDevices).To(ConsistOf(corev1.VolumeDevice{
+			Name:       dataVolumeName,
+			DevicePath: devicePath,
+		}))
+		for _, mount := range container.VolumeMounts {
+			Expect(mount.Name).NotTo(Equal(dataVolumeName))
+		}
+	})
+
+	It("should mount filesystem volumes", func() {
+		mover := &Mover{isSource: false}
+		mover.configureContainer(podSpec, nil, []string{operationRestore}, false, false, sa)
+
+		container := podSpec.Containers[0]
+		Expect(container.VolumeDevices).To(BeEmpty())
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      dataVolumeName,
+			MountPath: destinationMountPath,
+		}))
+	})
+})
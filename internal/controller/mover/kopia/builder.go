@@ -23,6 +23,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -157,6 +158,10 @@ func (kb *Builder) FromSource(client client.Client, logger logr.Logger,
 		return nil, nil
 	}
 
+	if err := ValidateSyncTargets(source.Spec.Kopia.Repository, source.Spec.Kopia.SyncTo); err != nil {
+		return nil, fmt.Errorf("invalid syncTo: %w", err)
+	}
+
 	// Initialize status fields
 	kb.initializeSourceStatus(source)
 
@@ -240,6 +245,7 @@ func (kb *Builder) createSourceMover(client client.Client, logger logr.Logger,
 		isSource:                 isSource,
 		paused:                   source.Spec.Paused,
 		mainPVCName:              &source.Spec.SourcePVC,
+		sourcePVCSelector:        source.Spec.SourcePVCSelector,
 		customCASpec:             volsyncv1alpha1.CustomCASpec(source.Spec.Kopia.CustomCA),
 		policyConfig:             source.Spec.Kopia.PolicyConfig,
 		privileged:               privileged,
@@ -255,6 +261,9 @@ func (kb *Builder) createSourceMover(client client.Client, logger logr.Logger,
 		latestMoverStatus:        source.Status.LatestMoverStatus,
 		moverConfig:              source.Spec.Kopia.MoverConfig,
 		additionalArgs:           source.Spec.Kopia.AdditionalArgs,
+		verify:                   source.Spec.Kopia.Verify,
+		syncTo:                   source.Spec.Kopia.SyncTo,
+		conditions:               &source.Status.Conditions,
 		builder:                  kb,
 	}
 }
@@ -267,6 +276,9 @@ func (kb *Builder) FromDestination(client client.Client, logger logr.Logger,
 	if destination.Spec.Kopia == nil {
 		return nil, nil
 	}
+	if destination.Spec.Kopia.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		return nil, mover.ErrGroupSnapshotDestination
+	}
 
 	// Validate identity configuration (only checks for invalid partial configurations)
 	if err := kb.validateDestinationIdentity(destination); err != nil {
@@ -276,6 +288,14 @@ func (kb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		return nil, err
 	}
 
+	// Validate partial restore paths
+	if err := ValidateRestorePaths(destination.Spec.Kopia.IncludePaths); err != nil {
+		return nil, fmt.Errorf("invalid includePaths: %w", err)
+	}
+	if err := ValidateRestorePaths(destination.Spec.Kopia.ExcludePaths); err != nil {
+		return nil, fmt.Errorf("invalid excludePaths: %w", err)
+	}
+
 	// Initialize Status if it's nil
 	if destination.Status == nil {
 		destination.Status = &volsyncv1alpha1.ReplicationDestinationStatus{}
@@ -290,6 +310,7 @@ func (kb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		volumehandler.WithRecorder(eventRecorder),
 		volumehandler.WithOwner(destination),
 		volumehandler.FromDestination(&destination.Spec.Kopia.ReplicationDestinationVolumeOptions),
+		volumehandler.VolumeMode(destination.Spec.Kopia.VolumeMode), // Allow setting block mode for dynamic dest PVC
 	)
 	if err != nil {
 		return nil, err
@@ -417,6 +438,24 @@ func (kb *Builder) FromDestination(client client.Client, logger logr.Logger,
 	// 2. Either sourceIdentity is not used OR no repository was discovered
 	// In this case, repositoryName will remain empty string, which is the existing behavior
 
+	// Resolve a snapshot referenced by KopiaSnapshot name to its id. The
+	// KopiaSnapshot's repository is used if the destination doesn't set one.
+	// A snapshot may also be selected by its id directly.
+	var snapshotID string
+	if destination.Spec.Kopia.SnapshotName != "" {
+		snapshot, err := fetchKopiaSnapshot(client, destination.Spec.Kopia.SnapshotName, destination.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		snapshotID = snapshot.Spec.SnapshotID
+		if destination.Spec.Kopia.Repository == "" {
+			repositoryName = snapshot.Spec.Repository
+		}
+	}
+	if destination.Spec.Kopia.SnapshotID != "" {
+		snapshotID = destination.Spec.Kopia.SnapshotID
+	}
+
 	saHandler := utils.NewSAHandler(client, destination, isSource, privileged,
 		destination.Spec.Kopia.MoverServiceAccount)
 
@@ -463,11 +502,14 @@ func (kb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		restoreAsOf:                 destination.Spec.Kopia.RestoreAsOf,
 		shallow:                     destination.Spec.Kopia.Shallow,
 		previous:                    destination.Spec.Kopia.Previous,
+		snapshotID:                  snapshotID,
 		enableFileDeletionOnRestore: enableFileDeletion,
 		destinationStatus:           destination.Status.Kopia,
 		latestMoverStatus:           destination.Status.LatestMoverStatus,
 		moverConfig:                 destination.Spec.Kopia.MoverConfig,
 		additionalArgs:              destination.Spec.Kopia.AdditionalArgs,
+		includePaths:                normalizeRestorePaths(destination.Spec.Kopia.IncludePaths),
+		excludePaths:                normalizeRestorePaths(destination.Spec.Kopia.ExcludePaths),
 		builder:                     kb,
 	}, nil
 }
@@ -634,6 +676,23 @@ func (kb *Builder) fetchReplicationSource(c client.Client, sourceName, sourceNam
 	return source, nil
 }
 
+// fetchKopiaSnapshot fetches the KopiaSnapshot referenced by a
+// ReplicationDestination
+func fetchKopiaSnapshot(c client.Client, name, namespace string) (*volsyncv1alpha1.KopiaSnapshot, error) {
+	snapshot := &volsyncv1alpha1.KopiaSnapshot{}
+	err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, snapshot)
+	if kerrors.IsNotFound(err) {
+		return nil, fmt.Errorf("KopiaSnapshot %s/%s not found", namespace, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Spec.SnapshotID == "" {
+		return nil, fmt.Errorf("KopiaSnapshot %s/%s has no snapshotID", namespace, name)
+	}
+	return snapshot, nil
+}
+
 // logDiscoveryError logs appropriate error messages based on error type
 func (kb *Builder) logDiscoveryError(err error, sourceName, sourceNamespace string, logger logr.Logger) {
 	if kerrors.IsNotFound(err) {
@@ -703,10 +762,17 @@ func (kb *Builder) discoverSourcePVC(c client.Client, sourceName, sourceNamespac
 }
 
 // validateDestinationIdentity validates identity configuration for ReplicationDestination.
+// See ValidateDestinationIdentity.
+func (kb *Builder) validateDestinationIdentity(destination *volsyncv1alpha1.ReplicationDestination) error {
+	return ValidateDestinationIdentity(destination)
+}
+
+// ValidateDestinationIdentity validates identity configuration for ReplicationDestination.
 // This function now only validates mismatched configurations (e.g., username without hostname).
 // Identity is no longer required - it will be automatically determined if not provided.
 // Returns an error only if the configuration is invalid (partial identity provided).
-func (kb *Builder) validateDestinationIdentity(destination *volsyncv1alpha1.ReplicationDestination) error {
+// It does not require a client so it can also be used by the admission webhook.
+func ValidateDestinationIdentity(destination *volsyncv1alpha1.ReplicationDestination) error {
 	if destination.Spec.Kopia == nil {
 		return nil
 	}
@@ -735,3 +801,38 @@ func (kb *Builder) validateDestinationIdentity(destination *volsyncv1alpha1.Repl
 	// No validation error - identity will be determined automatically or from provided values
 	return nil
 }
+
+// ValidateRestorePaths validates the includePaths/excludePaths of a
+// ReplicationDestination. Paths are interpreted relative to the snapshot root,
+// so they may not be empty, refer to the root itself or contain ".." elements.
+func ValidateRestorePaths(paths []string) error {
+	for _, p := range paths {
+		for _, elem := range strings.Split(p, "/") {
+			if elem == ".." {
+				return fmt.Errorf("path %q must not contain '..'", p)
+			}
+		}
+		if normalizeRestorePath(p) == "" {
+			return fmt.Errorf("path %q must refer to a file or directory within the snapshot", p)
+		}
+	}
+	return nil
+}
+
+// normalizeRestorePath converts a restore path to the form expected by the
+// mover: cleaned and without leading or trailing slashes. The snapshot root
+// normalizes to "".
+func normalizeRestorePath(p string) string {
+	return strings.Trim(path.Clean("/"+p), "/")
+}
+
+func normalizeRestorePaths(paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+	normalized := make([]string, 0, len(paths))
+	for _, p := range paths {
+		normalized = append(normalized, normalizeRestorePath(p))
+	}
+	return normalized
+}
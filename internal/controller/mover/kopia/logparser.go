@@ -20,164 +20,18 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package kopia
 
 import (
-	"encoding/json"
-	"fmt"
+	"math"
 	"regexp"
-	"sort"
+	"strconv"
 	"strings"
 	"time"
 
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-
-	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
 )
 
-// SnapshotInfo represents a snapshot in Kopia's JSON output
-type SnapshotInfo struct {
-	ID          string    `json:"id"`
-	Source      string    `json:"source"`
-	Username    string    `json:"userName"`
-	Hostname    string    `json:"hostName"`
-	Path        string    `json:"path"`
-	StartTime   time.Time `json:"startTime"`
-	EndTime     time.Time `json:"endTime"`
-	Description string    `json:"description,omitempty"`
-}
-
-// ParseKopiaDiscoveryOutput parses Kopia job output to extract discovery information
-// when snapshots cannot be found for the requested identity
-//
-//nolint:funlen,lll
-func ParseKopiaDiscoveryOutput(logs string) (requestedIdentity string, availableIdentities []volsyncv1alpha1.KopiaIdentityInfo, errorMsg string) {
-	lines := strings.Split(logs, "\n")
-	identityMap := make(map[string]*volsyncv1alpha1.KopiaIdentityInfo)
-
-	// Regular expressions for parsing
-	noSnapshotsRegex := regexp.MustCompile(`No snapshots found for ([^@]+)@([^:]+):(.+)`)
-	snapshotListingRegex := regexp.MustCompile(`^([^@]+)@([^:]+):(.+)\s+(\d{4}-\d{2}-\d{2}\s+\d{2}:\d{2}:\d{2})`)
-	identityErrorRegex := regexp.MustCompile(`unable to find snapshots for source "([^"]+)"`)
-	discoveryHeaderRegex := regexp.MustCompile(`=== Discovery Mode: Available Snapshots ===`)
-	jsonSnapshotRegex := regexp.MustCompile(`^\{.*"id".*"userName".*"hostName".*\}`)
-
-	var inDiscoveryMode bool
-	var jsonSnapshots []string
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-
-		// Check for discovery mode header
-		if discoveryHeaderRegex.MatchString(line) {
-			inDiscoveryMode = true
-			continue
-		}
-
-		// Extract requested identity from error messages
-		if matches := noSnapshotsRegex.FindStringSubmatch(line); len(matches) > 2 {
-			requestedIdentity = fmt.Sprintf("%s@%s", matches[1], matches[2])
-			errorMsg = line
-			continue
-		}
-
-		if matches := identityErrorRegex.FindStringSubmatch(line); len(matches) > 1 {
-			// Extract username@hostname from the source path
-			parts := strings.Split(matches[1], ":")
-			if len(parts) > 0 {
-				requestedIdentity = parts[0]
-			}
-			errorMsg = fmt.Sprintf("No snapshots found for identity '%s'", requestedIdentity)
-			continue
-		}
-
-		// In discovery mode, collect JSON snapshot data
-		if inDiscoveryMode && jsonSnapshotRegex.MatchString(line) {
-			jsonSnapshots = append(jsonSnapshots, line)
-			continue
-		}
-
-		// Parse traditional snapshot listing format
-		if matches := snapshotListingRegex.FindStringSubmatch(line); len(matches) > 4 {
-			username := matches[1]
-			hostname := matches[2]
-			identity := fmt.Sprintf("%s@%s", username, hostname)
-
-			if _, exists := identityMap[identity]; !exists {
-				identityMap[identity] = &volsyncv1alpha1.KopiaIdentityInfo{
-					Identity:      identity,
-					SnapshotCount: 0,
-				}
-			}
-
-			identityMap[identity].SnapshotCount++
-
-			// Parse timestamp for latest snapshot
-			if timestamp, err := time.Parse("2006-01-02 15:04:05", matches[4]); err == nil {
-				if identityMap[identity].LatestSnapshot == nil ||
-					timestamp.After(identityMap[identity].LatestSnapshot.Time) {
-					latestTime := metav1.NewTime(timestamp)
-					identityMap[identity].LatestSnapshot = &latestTime
-				}
-			}
-		}
-	}
-
-	// Process JSON snapshots if found
-	if len(jsonSnapshots) > 0 {
-		processJSONSnapshots(jsonSnapshots, identityMap)
-	}
-
-	// Convert map to slice and sort by identity
-	for _, info := range identityMap {
-		availableIdentities = append(availableIdentities, *info)
-	}
-
-	// Sort by identity for consistent output
-	sort.Slice(availableIdentities, func(i, j int) bool {
-		return availableIdentities[i].Identity < availableIdentities[j].Identity
-	})
-
-	// Generate better error message if we have discovery information
-	if requestedIdentity != "" && len(availableIdentities) > 0 {
-		var identityList []string
-		for _, id := range availableIdentities {
-			identityList = append(identityList, id.Identity)
-		}
-		errorMsg = fmt.Sprintf("No snapshots found for identity '%s'. Available identities: %s",
-			requestedIdentity, strings.Join(identityList, ", "))
-	} else if errorMsg == "" && requestedIdentity != "" {
-		// If no available identities found, just format the basic error message
-		errorMsg = fmt.Sprintf("No snapshots found for identity '%s'", requestedIdentity)
-	}
-
-	return requestedIdentity, availableIdentities, errorMsg
-}
-
-// processJSONSnapshots processes JSON-formatted snapshot information
-func processJSONSnapshots(jsonLines []string, identityMap map[string]*volsyncv1alpha1.KopiaIdentityInfo) {
-	for _, line := range jsonLines {
-		var snapshot SnapshotInfo
-		if err := json.Unmarshal([]byte(line), &snapshot); err != nil {
-			continue
-		}
-
-		identity := fmt.Sprintf("%s@%s", snapshot.Username, snapshot.Hostname)
-
-		if _, exists := identityMap[identity]; !exists {
-			identityMap[identity] = &volsyncv1alpha1.KopiaIdentityInfo{
-				Identity:      identity,
-				SnapshotCount: 0,
-			}
-		}
-
-		identityMap[identity].SnapshotCount++
-
-		// Update latest snapshot time
-		if identityMap[identity].LatestSnapshot == nil ||
-			snapshot.EndTime.After(identityMap[identity].LatestSnapshot.Time) {
-			latestTime := metav1.NewTime(snapshot.EndTime)
-			identityMap[identity].LatestSnapshot = &latestTime
-		}
-	}
-}
+// restoredPathPrefix is printed by the mover for each path restored during a
+// partial restore
+const restoredPathPrefix = "Restored path: "
 
 // LogFilter returns a filter function for Kopia mover logs
 // It extracts meaningful error messages and discovery information
@@ -210,9 +64,84 @@ func LogFilter(line string) *string {
 	if strings.Contains(line, "Snapshot created successfully") ||
 		strings.Contains(line, "Snapshot restore completed") ||
 		strings.Contains(line, "Repository connected") ||
-		strings.Contains(line, "No eligible snapshots") {
+		strings.Contains(line, "No eligible snapshots") ||
+		strings.Contains(line, "Selected snapshot with id") ||
+		strings.HasPrefix(line, restoredPathPrefix) ||
+		strings.HasPrefix(line, "KOPIA_VERIFY_") ||
+		strings.HasPrefix(line, syncToResultPrefix) {
 		return &line
 	}
 
 	return nil
 }
+
+var (
+	// Snapshot progress, e.g.
+	// " | 3 hashing, 125 hashed (1.2 GB), 10 cached (3 MB), uploaded 1.1 GB, estimated 2.5 GB (48.0%) 1m2s left"
+	kopiaBackupProgressRegex = regexp.MustCompile(
+		`(\d+) hashed \(([\d.]+ [KMGT]?i?B)\), (\d+) cached \(([\d.]+ [KMGT]?i?B)\)` +
+			`(?:.*estimated ([\d.]+ [KMGT]?i?B)(?: \([\d.]+%\))?(?: (\S+) left)?)?`)
+	// Restore progress, e.g.
+	// "Processed 12 (1.2 MB) of 30 (2.7 MB) 1.1 MB/s (44.4%) remaining 2s."
+	kopiaRestoreProgressRegex = regexp.MustCompile(
+		`Processed (\d+) \(([\d.]+ [KMGT]?i?B)\) of (\d+) \(([\d.]+ [KMGT]?i?B)\)` +
+			`(?: ([\d.]+ [KMGT]?i?B)/s)?(?:.*remaining (\S+?)\.?$)?`)
+)
+
+// ProgressFilter extracts the progress of a snapshot or restore from a line
+// of Kopia output
+func ProgressFilter(line string) *utils.MoverProgress {
+	line = strings.TrimSpace(line)
+	if m := kopiaBackupProgressRegex.FindStringSubmatch(line); m != nil {
+		hashed, _ := strconv.ParseInt(m[1], 10, 64)
+		cached, _ := strconv.ParseInt(m[3], 10, 64)
+		return &utils.MoverProgress{
+			FilesDone:  hashed + cached,
+			BytesDone:  parseKopiaBytes(m[2]) + parseKopiaBytes(m[4]),
+			BytesTotal: parseKopiaBytes(m[5]),
+			Remaining:  parseKopiaDuration(m[6]),
+		}
+	}
+	if m := kopiaRestoreProgressRegex.FindStringSubmatch(line); m != nil {
+		p := &utils.MoverProgress{
+			BytesDone:      parseKopiaBytes(m[2]),
+			BytesTotal:     parseKopiaBytes(m[4]),
+			BytesPerSecond: parseKopiaBytes(m[5]),
+			Remaining:      parseKopiaDuration(m[6]),
+		}
+		p.FilesDone, _ = strconv.ParseInt(m[1], 10, 64)
+		p.FilesTotal, _ = strconv.ParseInt(m[3], 10, 64)
+		return p
+	}
+	return nil
+}
+
+// parseKopiaBytes parses a size formatted by Kopia, such as "1.2 GB". Kopia
+// uses base 10 units unless KOPIA_BYTES_STRING_BASE_2 is set.
+func parseKopiaBytes(size string) int64 {
+	value, unit, _ := strings.Cut(size, " ")
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	multiplier := map[string]float64{
+		"B":   1,
+		"KB":  1e3,
+		"MB":  1e6,
+		"GB":  1e9,
+		"TB":  1e12,
+		"KiB": 1 << 10,
+		"MiB": 1 << 20,
+		"GiB": 1 << 30,
+		"TiB": 1 << 40,
+	}[unit]
+	return int64(math.Round(v * multiplier))
+}
+
+func parseKopiaDuration(d string) *time.Duration {
+	remaining, err := time.ParseDuration(d)
+	if err != nil {
+		return nil
+	}
+	return &remaining
+}
@@ -24,77 +24,9 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-
-	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
 )
 
 var _ = Describe("Kopia Log Parser", func() {
-	Describe("ParseKopiaDiscoveryOutput", func() {
-		Context("when no snapshots are found", func() {
-			It("should parse error message with requested identity", func() {
-				logs := `
-=== Starting restore ===
-Discovery mode enabled - will list available snapshots if restore fails
-Selecting snapshot to restore
-Using previous offset: 0 (0=latest, 1=previous, etc.)
-No eligible snapshots found
-No snapshots found for user1@host1:/data
-
-=== Discovery Mode: Available Snapshots ===
-Listing all available snapshot identities in the repository...
-Found snapshots in repository:
-
-{"id":"abc123","userName":"user2","hostName":"host2","path":"/data",` +
-					`"startTime":"2024-01-01T10:00:00Z","endTime":"2024-01-01T10:05:00Z"}
-{"id":"def456","userName":"user3","hostName":"host3","path":"/data",` +
-					`"startTime":"2024-01-01T11:00:00Z","endTime":"2024-01-01T11:05:00Z"}
-
-Available identities (username@hostname combinations):
-user2@host2:/data - Last snapshot: 2024-01-01T10:05:00Z
-user3@host3:/data - Last snapshot: 2024-01-01T11:05:00Z
-=== End Discovery Mode ===
-`
-				requestedIdentity, availableIdentities, errorMsg := ParseKopiaDiscoveryOutput(logs)
-
-				Expect(requestedIdentity).To(Equal("user1@host1"))
-				Expect(availableIdentities).To(HaveLen(2))
-				Expect(availableIdentities[0].Identity).To(Equal("user2@host2"))
-				Expect(availableIdentities[0].SnapshotCount).To(Equal(int32(1)))
-				Expect(availableIdentities[1].Identity).To(Equal("user3@host3"))
-				Expect(errorMsg).To(ContainSubstring("No snapshots found for identity 'user1@host1'"))
-				Expect(errorMsg).To(ContainSubstring("Available identities: user2@host2, user3@host3"))
-			})
-		})
-
-		Context("when parsing traditional snapshot listing", func() {
-			It("should extract identities from listing format", func() {
-				logs := `
-user1@host1:/data 2024-01-01 10:00:00
-user1@host1:/data 2024-01-02 10:00:00
-user2@host2:/backup 2024-01-01 11:00:00
-`
-				_, availableIdentities, _ := ParseKopiaDiscoveryOutput(logs)
-
-				Expect(availableIdentities).To(HaveLen(2))
-				Expect(availableIdentities[0].Identity).To(Equal("user1@host1"))
-				Expect(availableIdentities[0].SnapshotCount).To(Equal(int32(2)))
-				Expect(availableIdentities[1].Identity).To(Equal("user2@host2"))
-				Expect(availableIdentities[1].SnapshotCount).To(Equal(int32(1)))
-			})
-		})
-
-		Context("when parsing error messages", func() {
-			It("should extract identity from error message", func() {
-				logs := `unable to find snapshots for source "user1@host1:/data"`
-
-				requestedIdentity, _, errorMsg := ParseKopiaDiscoveryOutput(logs)
-
-				Expect(requestedIdentity).To(Equal("user1@host1"))
-				Expect(errorMsg).To(Equal("No snapshots found for identity 'user1@host1'"))
-			})
-		})
-	})
-
 	Describe("LogFilter", func() {
 		It("should include error messages", func() {
 			line := "ERROR: Failed to connect to repository"
@@ -129,82 +61,39 @@ user2@host2:/backup 2024-01-01 11:00:00
 			Expect(result).NotTo(BeNil())
 			Expect(*result).To(Equal(line))
 		})
-	})
 
-	Describe("processJSONSnapshots", func() {
-		type processJSONSnapshotsTestCase struct {
-			name               string
-			jsonLines          []string
-			expectedIdentities map[string]int32
-		}
-
-		DescribeTable("processes JSON snapshot data correctly",
-			func(tc processJSONSnapshotsTestCase) {
-				identityMap := make(map[string]*volsyncv1alpha1.KopiaIdentityInfo)
-				processJSONSnapshots(tc.jsonLines, identityMap)
-
-				Expect(identityMap).To(HaveLen(len(tc.expectedIdentities)))
-
-				for identity, expectedCount := range tc.expectedIdentities {
-					Expect(identityMap).To(HaveKey(identity))
-					Expect(identityMap[identity].SnapshotCount).To(Equal(expectedCount))
-				}
-			},
-			Entry("multiple snapshots same identity", processJSONSnapshotsTestCase{
-				name: "multiple snapshots same identity",
-				jsonLines: []string{
-					`{"id":"snap1","userName":"user1","hostName":"host1","path":"/data","endTime":"2024-01-01T10:00:00Z"}`,
-					`{"id":"snap2","userName":"user1","hostName":"host1","path":"/data","endTime":"2024-01-02T10:00:00Z"}`,
-				},
-				expectedIdentities: map[string]int32{
-					"user1@host1": 2,
-				},
-			}),
-			Entry("different identities", processJSONSnapshotsTestCase{
-				name: "different identities",
-				jsonLines: []string{
-					`{"id":"snap1","userName":"user1","hostName":"host1","path":"/data","endTime":"2024-01-01T10:00:00Z"}`,
-					`{"id":"snap2","userName":"user2","hostName":"host2","path":"/data","endTime":"2024-01-02T10:00:00Z"}`,
-				},
-				expectedIdentities: map[string]int32{
-					"user1@host1": 1,
-					"user2@host2": 1,
-				},
-			}),
-			Entry("invalid JSON ignored", processJSONSnapshotsTestCase{
-				name: "invalid JSON ignored",
-				jsonLines: []string{
-					`{"id":"snap1","userName":"user1","hostName":"host1","path":"/data","endTime":"2024-01-01T10:00:00Z"}`,
-					`invalid json`,
-					`{"id":"snap2","userName":"user1","hostName":"host1","path":"/data","endTime":"2024-01-02T10:00:00Z"}`,
-				},
-				expectedIdentities: map[string]int32{
-					"user1@host1": 2,
-				},
-			}),
-		)
+		It("should include the selected snapshot", func() {
+			line := "Selected snapshot with id: k1234"
+			result := LogFilter(line)
+			Expect(result).NotTo(BeNil())
+			Expect(*result).To(Equal(line))
+		})
 	})
 
-	Describe("LatestSnapshotTime", func() {
-		It("should track the latest snapshot time correctly", func() {
-			identityMap := make(map[string]*volsyncv1alpha1.KopiaIdentityInfo)
-
-			// Process snapshots with different times
-			jsonLines := []string{
-				`{"id":"snap1","userName":"user1","hostName":"host1","path":"/data","endTime":"2024-01-01T10:00:00Z"}`,
-				`{"id":"snap2","userName":"user1","hostName":"host1","path":"/data","endTime":"2024-01-03T10:00:00Z"}`,
-				`{"id":"snap3","userName":"user1","hostName":"host1","path":"/data","endTime":"2024-01-02T10:00:00Z"}`,
-			}
-
-			processJSONSnapshots(jsonLines, identityMap)
+	Describe("ProgressFilter", func() {
+		It("should parse the progress of a snapshot", func() {
+			p := ProgressFilter(" * 3 hashing, 125 hashed (1.2 GB), 10 cached (300 MB), uploaded 1.1 GB, " +
+				"estimated 3 GB (50.0%) 1m2s left")
+			Expect(p).NotTo(BeNil())
+			Expect(p.FilesDone).To(Equal(int64(135)))
+			Expect(p.BytesDone).To(Equal(int64(1_500_000_000)))
+			Expect(p.BytesTotal).To(Equal(int64(3_000_000_000)))
+			Expect(p.Remaining).To(HaveValue(Equal(62 * time.Second)))
+		})
 
-			Expect(identityMap).To(HaveKey("user1@host1"))
-			info := identityMap["user1@host1"]
-			Expect(info).NotTo(BeNil())
-			Expect(info.LatestSnapshot).NotTo(BeNil())
+		It("should parse the progress of a restore", func() {
+			p := ProgressFilter("Processed 12 (1.5 MiB) of 30 (3 MiB) 1.1 MB/s (50.0%) remaining 2s.")
+			Expect(p).NotTo(BeNil())
+			Expect(p.FilesDone).To(Equal(int64(12)))
+			Expect(p.FilesTotal).To(Equal(int64(30)))
+			Expect(p.BytesDone).To(Equal(int64(1536 * 1024)))
+			Expect(p.BytesTotal).To(Equal(int64(3 * 1024 * 1024)))
+			Expect(p.BytesPerSecond).To(Equal(int64(1_100_000)))
+			Expect(p.Remaining).To(HaveValue(Equal(2 * time.Second)))
+		})
 
-			expectedTime, _ := time.Parse(time.RFC3339, "2024-01-03T10:00:00Z")
-			Expect(info.LatestSnapshot.Time.Equal(expectedTime)).To(BeTrue())
+		It("should ignore other lines", func() {
+			Expect(ProgressFilter("Snapshot created successfully")).To(BeNil())
 		})
 	})
 })
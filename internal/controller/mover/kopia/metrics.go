@@ -55,6 +55,11 @@ type kopiaMetrics struct {
 	DeduplicationRatio      *prometheus.SummaryVec
 	RetentionCompliance     *prometheus.GaugeVec
 
+	// Snapshot Verification Metrics
+	VerifyOperations *prometheus.CounterVec
+	VerifiedObjects  *prometheus.GaugeVec
+	VerifyErrors     *prometheus.GaugeVec
+
 	// Cache and Performance Metrics
 	CacheHitRate       *prometheus.GaugeVec
 	CacheSize          *prometheus.GaugeVec
@@ -257,6 +262,34 @@ var (
 		append(kopiaMetricLabels, "retention_type"), // hourly, daily, weekly, monthly, yearly
 	)
 
+	// Snapshot Verification Metrics
+	verifyOperations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:      "verify_operations_total",
+			Namespace: kopiaMetricsNamespace,
+			Help:      "Total number of snapshot verifications performed after backup",
+		},
+		append(kopiaMetricLabels, "result"), // success, failure
+	)
+
+	verifiedObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      "verify_objects",
+			Namespace: kopiaMetricsNamespace,
+			Help:      "Number of objects checked by the last snapshot verification",
+		},
+		kopiaMetricLabels,
+	)
+
+	verifyErrors = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      "verify_errors",
+			Namespace: kopiaMetricsNamespace,
+			Help:      "Number of errors found by the last snapshot verification",
+		},
+		kopiaMetricLabels,
+	)
+
 	// Cache and Performance Metrics
 	cacheHitRate = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -434,6 +467,11 @@ func newKopiaMetrics() kopiaMetrics {
 		DeduplicationRatio:      deduplicationRatio,
 		RetentionCompliance:     retentionCompliance,
 
+		// Snapshot Verification Metrics
+		VerifyOperations: verifyOperations,
+		VerifiedObjects:  verifiedObjects,
+		VerifyErrors:     verifyErrors,
+
 		// Cache and Performance Metrics
 		CacheHitRate:       cacheHitRate,
 		CacheSize:          cacheSize,
@@ -484,6 +522,11 @@ func init() {
 		deduplicationRatio,
 		retentionCompliance,
 
+		// Snapshot Verification Metrics
+		verifyOperations,
+		verifiedObjects,
+		verifyErrors,
+
 		// Cache and Performance Metrics
 		cacheHitRate,
 		cacheSize,
@@ -24,12 +24,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	vgsv1beta2 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta2"
 	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	"github.com/prometheus/client_golang/prometheus"
 	batchv1 "k8s.io/api/batch/v1"
@@ -52,6 +55,7 @@ const (
 	kopiaCacheMountPath     = "/cache"
 	sourceMountPath         = "/data"
 	destinationMountPath    = "/restore/data"
+	devicePath              = "/dev/block"
 	dataVolumeName          = "data"
 	kopiaCache              = "cache"
 	restoreVolumeName       = "restore"
@@ -64,6 +68,7 @@ const (
 	defaultRepoConfigFile   = "repository.config"
 	operationBackup         = "backup"
 	operationRestore        = "restore"
+	operationVerify         = "verify"
 	// Environment variable names for Kopia configuration
 	envKopiaOverrideUsername = "KOPIA_OVERRIDE_USERNAME"
 	envKopiaOverrideHostname = "KOPIA_OVERRIDE_HOSTNAME"
@@ -72,6 +77,10 @@ const (
 	envKopiaOverrideMaintenanceHostname = "KOPIA_OVERRIDE_MAINTENANCE_HOSTNAME"
 	envSftpPassword                     = "SFTP_PASSWORD"
 	envKopiaAdditionalArgs              = "KOPIA_ADDITIONAL_ARGS"
+	envKopiaRestoreIncludePaths         = "KOPIA_RESTORE_INCLUDE_PATHS"
+	envKopiaRestoreExcludePaths         = "KOPIA_RESTORE_EXCLUDE_PATHS"
+	envKopiaRestoreSnapshotID           = "KOPIA_RESTORE_SNAPSHOT_ID"
+	envKopiaVerifyFilesPercent          = "KOPIA_VERIFY_FILES_PERCENT"
 	// Mount path prefix for mover volumes
 	moverVolumesMountPrefix = "/mnt"
 	kopiaRepositoryEnvVar   = "KOPIA_REPOSITORY"
@@ -127,6 +136,8 @@ type Mover struct {
 	policyConfig             *volsyncv1alpha1.KopiaPolicySpec
 	privileged               bool
 	latestMoverStatus        *volsyncv1alpha1.MoverStatus
+	moverResult              *utils.MoverResult
+	jobFailure               *mover.AttemptFailure
 	moverConfig              volsyncv1alpha1.MoverConfig
 	metrics                  kopiaMetrics
 	builder                  *Builder
@@ -141,13 +152,25 @@ type Mover struct {
 	actions            *volsyncv1alpha1.KopiaActions
 	sourceStatus       *volsyncv1alpha1.ReplicationSourceKopiaStatus
 	additionalArgs     []string
+	verify             *volsyncv1alpha1.KopiaVerifySpec
+	verifyThisSync     bool
+	conditions         *[]metav1.Condition
+	sourcePVCSelector  *metav1.LabelSelector
+	// PVCs restored from the group snapshot, keyed by source PVC name
+	groupPVCs map[string]*corev1.PersistentVolumeClaim
+	syncTo    []volsyncv1alpha1.KopiaSyncTarget
+	// Secrets of the syncTo targets, in the same order as syncTo
+	syncToRepos []*corev1.Secret
 	// Destination-only fields
 	restoreAsOf                 *string
 	shallow                     *int32
 	previous                    *int32
+	snapshotID                  string
 	cleanupTempPVC              bool
 	cleanupCachePVC             bool
 	enableFileDeletionOnRestore bool
+	includePaths                []string
+	excludePaths                []string
 	destinationStatus           *volsyncv1alpha1.ReplicationDestinationKopiaStatus
 	// Calculated cache limits for status update after successful job
 	calculatedMetadataCacheLimit *int32
@@ -207,6 +230,10 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.InProgress(), err
 	}
 	if job == nil {
+		if m.jobFailure != nil {
+			// The job failed and was deleted so that it can be retried
+			return mover.Failed(*m.jobFailure), nil
+		}
 		// Job is still running/retrying but hasn't completed or definitively failed yet
 		// Don't record this as a failure - it's just in progress
 		return mover.InProgress(), nil
@@ -246,7 +273,11 @@ func (m *Mover) Cleanup(ctx context.Context) (mover.Result, error) {
 		}
 	}
 
-	err := utils.CleanupObjects(ctx, m.client, m.logger, m.owner, cleanupTypes)
+	types := cleanupTypes
+	if m.sourcePVCSelector != nil {
+		types = append(slices.Clone(cleanupTypes), &vgsv1beta2.VolumeGroupSnapshot{})
+	}
+	err := utils.CleanupObjects(ctx, m.client, m.logger, m.owner, types)
 	if err != nil {
 		return mover.InProgress(), err
 	}
@@ -254,6 +285,9 @@ func (m *Mover) Cleanup(ctx context.Context) (mover.Result, error) {
 }
 
 func (m *Mover) ensureSourcePVC(ctx context.Context) (*corev1.PersistentVolumeClaim, error) {
+	if m.sourcePVCSelector != nil {
+		return m.ensureGroupSourcePVCs(ctx)
+	}
 	srcPVC := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      *m.mainPVCName,
@@ -279,6 +313,19 @@ func (m *Mover) ensureSourcePVC(ctx context.Context) (*corev1.PersistentVolumeCl
 	return pvc, err
 }
 
+// ensureGroupSourcePVCs ensures the PVCs restored from a group snapshot of the
+// selected source PVCs. The returned PVC is the first of the group and stands
+// in for the data PVC where only one is needed.
+func (m *Mover) ensureGroupSourcePVCs(ctx context.Context) (*corev1.PersistentVolumeClaim, error) {
+	dataName := mover.VolSyncPrefix + m.owner.GetName() + "-src"
+	pvcs, err := m.vh.EnsurePVCsFromGroupSnapshot(ctx, m.logger, m.sourcePVCSelector, dataName, true)
+	if len(pvcs) == 0 || err != nil {
+		return nil, err
+	}
+	m.groupPVCs = pvcs
+	return pvcs[slices.Sorted(maps.Keys(pvcs))[0]], nil
+}
+
 func (m *Mover) ensureDestinationPVC(ctx context.Context) (*corev1.PersistentVolumeClaim, error) {
 	isProvidedPVC, dataPVCName := m.getDestinationPVCName()
 	if isProvidedPVC {
@@ -331,7 +378,9 @@ func (m *Mover) ensureCache(ctx context.Context,
 	if m.cacheCapacity != nil {
 		cacheCapacity = *m.cacheCapacity
 	}
-	cacheConfig = append(cacheConfig, volumehandler.Capacity(&cacheCapacity))
+	cacheConfig = append(cacheConfig, volumehandler.Capacity(&cacheCapacity), volumehandler.ScaledWithData())
+	// The cache is a filesystem even when the data volume is a block device
+	cacheConfig = append(cacheConfig, volumehandler.VolumeMode(ptr.To(corev1.PersistentVolumeFilesystem)))
 
 	// AccessModes are generated in the following priority:
 	// 1. Directly specified cache accessMode
@@ -560,14 +609,30 @@ func (m *Mover) configureJobSpec(ctx context.Context, job *batchv1.Job, cachePVC
 	}
 
 	m.setupJobMetadata(job)
+	if m.isSource {
+		m.verifyThisSync = m.shouldVerifyJob(job, time.Now())
+	}
 	readOnlyVolume, actions := m.determineJobActions(dataPVC)
 	logger.Info("job actions", "actions", actions)
 
 	podSpec := &job.Spec.Template.Spec
 	envVars := m.buildEnvironmentVariables(repo)
-	m.configureContainer(podSpec, envVars, actions, readOnlyVolume, sa)
+	if m.isSource && dataPVC != nil && len(m.groupPVCs) == 0 {
+		// Recorded in the snapshot so that destinations can follow the
+		// capacity of the source
+		envVars = append(envVars, utils.CapacityEnvVar("SOURCE_CAPACITY", dataPVC))
+	}
+	blockVolume := utils.PvcIsBlockMode(dataPVC)
+	if blockVolume {
+		// The device is snapshotted as a single file
+		envVars = append(envVars, corev1.EnvVar{Name: "BLOCK_DEVICE", Value: devicePath})
+	}
+	m.configureContainer(podSpec, envVars, actions, readOnlyVolume, blockVolume, sa)
 	m.configureBasicVolumes(podSpec, dataPVC, readOnlyVolume)
 	m.configureCacheVolume(podSpec, cachePVC)
+	if len(m.groupPVCs) > 0 {
+		utils.MountGroupDataVolumes(podSpec, dataVolumeName, m.groupPVCs)
+	}
 
 	if err := m.configureAffinity(ctx, podSpec, dataPVC, logger); err != nil {
 		return err
@@ -596,8 +661,7 @@ func (m *Mover) setupJobMetadata(job *batchv1.Job) {
 	utils.MarkForCleanup(m.owner, job)
 	job.Spec.Template.Name = job.Name
 	utils.SetOwnedByVolSync(&job.Spec.Template)
-	backoffLimit := int32(8)
-	job.Spec.BackoffLimit = &backoffLimit
+	job.Spec.BackoffLimit = mover.JobBackoffLimit(m.owner, 8)
 	parallelism := int32(1)
 	if m.paused {
 		parallelism = int32(0)
@@ -625,6 +689,7 @@ func (m *Mover) buildEnvironmentVariables(repo *corev1.Secret) []corev1.EnvVar {
 	envVars = append(envVars, m.buildRepositoryEnvironmentVariables(repo)...)
 	envVars = append(envVars, m.buildBackendEnvironmentVariables(repo)...)
 	envVars = m.addSourceDestinationEnvVars(envVars)
+	envVars = m.addTransferLimitEnvVars(envVars)
 	envVars = utils.AppendEnvVarsForClusterWideProxy(envVars)
 	envVars = m.addIdentityEnvironmentVariables(envVars)
 	envVars = utils.AppendDebugMoverEnvVar(m.owner, envVars)
@@ -828,6 +893,21 @@ func (m *Mover) addIdentityEnvironmentVariables(envVars []corev1.EnvVar) []corev
 	return envVars
 }
 
+// addTransferLimitEnvVars adds the bandwidth limits of the transferPolicy, in
+// bytes per second
+func (m *Mover) addTransferLimitEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
+	limits := utils.SyncTransferLimits(m.owner, m.moverConfig.TransferPolicy)
+	if limits.Upload > 0 {
+		envVars = append(envVars, corev1.EnvVar{Name: "KOPIA_UPLOAD_SPEED",
+			Value: strconv.FormatInt(limits.Upload, 10)})
+	}
+	if limits.Download > 0 {
+		envVars = append(envVars, corev1.EnvVar{Name: "KOPIA_DOWNLOAD_SPEED",
+			Value: strconv.FormatInt(limits.Download, 10)})
+	}
+	return envVars
+}
+
 // addSourceDestinationEnvVars adds environment variables specific to source or destination
 func (m *Mover) addSourceDestinationEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
 	if m.isSource {
@@ -855,6 +935,12 @@ func (m *Mover) addSourceEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
 	// Add actions
 	envVars = m.addActionsEnvVars(envVars)
 
+	// Request verification of the new snapshot
+	envVars = m.addVerifyEnvVars(envVars)
+
+	// Mirror the repository to the syncTo targets
+	envVars = m.addSyncToEnvVars(envVars)
+
 	// Add additional args if specified
 	envVars = m.addAdditionalArgsEnvVar(envVars)
 
@@ -875,6 +961,9 @@ func (m *Mover) addDestinationEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
 	if m.previous != nil {
 		envVars = append(envVars, corev1.EnvVar{Name: "KOPIA_PREVIOUS", Value: strconv.Itoa(int(*m.previous))})
 	}
+	if m.snapshotID != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: envKopiaRestoreSnapshotID, Value: m.snapshotID})
+	}
 	// Pass sourcePathOverride to destination jobs for correct snapshot path restoration
 	if m.sourcePathOverride != nil {
 		envVars = append(envVars, corev1.EnvVar{Name: "KOPIA_SOURCE_PATH_OVERRIDE", Value: *m.sourcePathOverride})
@@ -883,6 +972,16 @@ func (m *Mover) addDestinationEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
 	if m.enableFileDeletionOnRestore {
 		envVars = append(envVars, corev1.EnvVar{Name: "KOPIA_ENABLE_FILE_DELETION", Value: "true"})
 	}
+	// Pass partial restore paths, one per line since paths may contain
+	// any character other than a newline
+	if len(m.includePaths) > 0 {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: envKopiaRestoreIncludePaths, Value: strings.Join(m.includePaths, "\n")})
+	}
+	if len(m.excludePaths) > 0 {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: envKopiaRestoreExcludePaths, Value: strings.Join(m.excludePaths, "\n")})
+	}
 
 	// Add additional args if specified
 	envVars = m.addAdditionalArgsEnvVar(envVars)
@@ -1058,16 +1157,21 @@ func (m *Mover) addCacheLimitEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
 
 // configureContainer sets up the main container configuration
 func (m *Mover) configureContainer(podSpec *corev1.PodSpec, envVars []corev1.EnvVar,
-	actions []string, readOnlyVolume bool, sa *corev1.ServiceAccount) {
+	actions []string, readOnlyVolume, blockVolume bool, sa *corev1.ServiceAccount) {
 	// Use different mount paths for source (backup) vs destination (restore)
 	dataMountPath := sourceMountPath
 	if !m.isSource {
 		dataMountPath = destinationMountPath
 	}
-	volumeMounts := []corev1.VolumeMount{
-		{Name: dataVolumeName, MountPath: dataMountPath, ReadOnly: readOnlyVolume},
-		{Name: "tempdir", MountPath: "/tmp"},
+	volumeMounts := []corev1.VolumeMount{}
+	var volumeDevices []corev1.VolumeDevice
+	if blockVolume {
+		volumeDevices = []corev1.VolumeDevice{{Name: dataVolumeName, DevicePath: devicePath}}
+	} else {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{Name: dataVolumeName, MountPath: dataMountPath, ReadOnly: readOnlyVolume})
 	}
+	volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: "tempdir", MountPath: "/tmp"})
 
 	// For destination movers, add the /restore volume mount
 	// This is needed for Kopia's atomic file operations which create temp files
@@ -1093,7 +1197,8 @@ func (m *Mover) configureContainer(podSpec *corev1.PodSpec, envVars []corev1.Env
 			Privileged:             ptr.To(false),
 			ReadOnlyRootFilesystem: ptr.To(true),
 		},
-		VolumeMounts: volumeMounts,
+		VolumeMounts:  volumeMounts,
+		VolumeDevices: volumeDevices,
 	}}
 	podSpec.RestartPolicy = corev1.RestartPolicyNever
 	podSpec.ServiceAccountName = sa.Name
@@ -1451,15 +1556,16 @@ func (m *Mover) handleJobStatus(ctx context.Context, job *batchv1.Job,
 			// Use Kopia-specific log filter for destinations to extract discovery info
 			logFilter = LogFilter
 		}
-		utils.UpdateMoverStatusForFailedJob(ctx, m.logger, m.latestMoverStatus, job.GetName(), job.GetNamespace(),
-			logFilter)
+		m.moverResult = utils.UpdateMoverStatusForFailedJob(ctx, m.logger, m.latestMoverStatus,
+			job.GetName(), job.GetNamespace(), logFilter)
 
-		// For destination jobs, parse logs for discovery information
+		// For destination jobs, record the discovery information
 		if !m.isSource && m.destinationStatus != nil {
 			m.updateDestinationDiscoveryStatus()
 		}
 
 		logger.Info("deleting job -- terminal failure", "reason", failureReason)
+		m.jobFailure = ptr.To(mover.JobFailure(m.latestMoverStatus))
 		err = m.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
 		return nil, err
 	}
@@ -1470,6 +1576,7 @@ func (m *Mover) handleJobStatus(ctx context.Context, job *batchv1.Job,
 
 	// Stop here if the job hasn't completed yet
 	if !jobComplete {
+		utils.UpdateSyncProgress(ctx, logger, m.owner, job.GetName(), job.GetNamespace(), ProgressFilter)
 		return nil, nil
 	}
 
@@ -1481,8 +1588,8 @@ func (m *Mover) handleJobStatus(ctx context.Context, job *batchv1.Job,
 	if !m.isSource {
 		logFilter = LogFilter
 	}
-	utils.UpdateMoverStatusForSuccessfulJob(ctx, m.logger, m.latestMoverStatus, job.GetName(), job.GetNamespace(),
-		logFilter)
+	m.moverResult = utils.UpdateMoverStatusForSuccessfulJob(ctx, m.logger, m.latestMoverStatus,
+		job.GetName(), job.GetNamespace(), logFilter)
 
 	// We only continue reconciling if the kopia job has completed
 	return job, nil
@@ -1541,6 +1648,14 @@ func (m *Mover) setupPrerequisites(ctx context.Context) (*corev1.PersistentVolum
 		return nil, nil, nil, nil, nil, nil, err
 	}
 
+	// Validate the Secrets of the syncTo targets
+	if m.isSource {
+		if m.syncToRepos, err = m.validateSyncTargets(ctx); err != nil {
+			m.recordConfigurationError("sync_to_validation_failed")
+			return nil, nil, nil, nil, nil, nil, err
+		}
+	}
+
 	// Validate custom CA if in spec
 	customCAObj, err := utils.ValidateCustomCA(ctx, m.client, m.logger,
 		m.owner.GetNamespace(), m.customCASpec)
@@ -1590,6 +1705,7 @@ func (m *Mover) handleJobCompletion(ctx context.Context, job *batchv1.Job,
 	if !m.isSource && m.destinationStatus != nil {
 		m.destinationStatus.AvailableIdentities = nil
 		m.destinationStatus.SnapshotsFound = 0
+		m.updateDestinationRestoredPaths()
 	}
 
 	// On the destination, preserve the image and return it
@@ -1601,7 +1717,10 @@ func (m *Mover) handleJobCompletion(ctx context.Context, job *batchv1.Job,
 		return mover.CompleteWithImage(image), nil
 	}
 
-	// On the source, just signal completion
+	// On the source, record the verification and sync results and signal
+	// completion
+	m.updateSourceVerifyStatus()
+	m.updateSourceSyncToStatus()
 	return mover.Complete(), nil
 }
 
@@ -1794,15 +1913,19 @@ func (m *Mover) getMetricLabels(operation string) prometheus.Labels {
 	return labels
 }
 
-// updateDestinationDiscoveryStatus updates the destination status with discovery information
-// parsed from failed job logs
+// updateDestinationDiscoveryStatus updates the destination status with the
+// discovery information reported by a failed restore
 func (m *Mover) updateDestinationDiscoveryStatus() {
 	if m.destinationStatus == nil || m.latestMoverStatus == nil {
 		return
 	}
 
-	// Parse the logs for discovery information
-	requestedIdentity, availableIdentities, errorMsg := ParseKopiaDiscoveryOutput(m.latestMoverStatus.Logs)
+	details := m.resultDetails()
+	requestedIdentity := details.RequestedIdentity
+	availableIdentities := details.AvailableIdentities
+	slices.SortFunc(availableIdentities, func(a, b volsyncv1alpha1.KopiaIdentityInfo) int {
+		return strings.Compare(a.Identity, b.Identity)
+	})
 
 	// Update the destination status with discovery information
 	if requestedIdentity != "" {
@@ -1821,6 +1944,7 @@ func (m *Mover) updateDestinationDiscoveryStatus() {
 	}
 
 	// Update the error message with more helpful information
+	errorMsg := discoveryErrorMessage(requestedIdentity, availableIdentities)
 	if errorMsg != "" && m.latestMoverStatus.Result == volsyncv1alpha1.MoverResultFailed {
 		// Enhance the error message in the mover status
 		if !strings.Contains(m.latestMoverStatus.Logs, errorMsg) {
@@ -1834,6 +1958,33 @@ func (m *Mover) updateDestinationDiscoveryStatus() {
 		"errorMsg", errorMsg)
 }
 
+// discoveryErrorMessage explains why a restore found no snapshots, listing
+// the identities that do have snapshots
+func discoveryErrorMessage(requestedIdentity string, availableIdentities []volsyncv1alpha1.KopiaIdentityInfo) string {
+	if requestedIdentity == "" {
+		return ""
+	}
+	if len(availableIdentities) == 0 {
+		return fmt.Sprintf("No snapshots found for identity '%s'", requestedIdentity)
+	}
+	identityList := make([]string, 0, len(availableIdentities))
+	for _, id := range availableIdentities {
+		identityList = append(identityList, id.Identity)
+	}
+	return fmt.Sprintf("No snapshots found for identity '%s'. Available identities: %s",
+		requestedIdentity, strings.Join(identityList, ", "))
+}
+
+// updateDestinationRestoredPaths records the paths restored by a partial
+// restore, as reported by the successful job
+func (m *Mover) updateDestinationRestoredPaths() {
+	if len(m.includePaths) == 0 && len(m.excludePaths) == 0 {
+		m.destinationStatus.RestoredPaths = nil
+		return
+	}
+	m.destinationStatus.RestoredPaths = m.resultDetails().RestoredPaths
+}
+
 // ReconcileMaintenance ensures a maintenance CronJob exists for this source's repository
 func (m *Mover) ReconcileMaintenance(ctx context.Context) error {
 	// Only handle maintenance for sources
@@ -0,0 +1,108 @@
+//go:build !disable_kopia
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package kopia
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var _ = Describe("Kopia partial restore", func() {
+	Describe("ValidateRestorePaths", func() {
+		It("should accept paths relative to the snapshot root", func() {
+			Expect(ValidateRestorePaths(nil)).To(Succeed())
+			Expect(ValidateRestorePaths([]string{"app", "app/config/", "/db/dump.sql", "./cache"})).To(Succeed())
+		})
+
+		It("should reject paths containing '..'", func() {
+			Expect(ValidateRestorePaths([]string{"app/../../etc"})).To(MatchError(ContainSubstring("'..'")))
+		})
+
+		It("should reject paths that refer to the snapshot root", func() {
+			for _, p := range []string{"", "/", ".", "//"} {
+				Expect(ValidateRestorePaths([]string{p})).NotTo(Succeed(), "path %q", p)
+			}
+		})
+	})
+
+	Describe("normalizeRestorePaths", func() {
+		It("should strip leading and trailing slashes and clean the paths", func() {
+			Expect(normalizeRestorePaths([]string{"/app/config/", "db//dump.sql", "./cache"})).To(
+				Equal([]string{"app/config", "db/dump.sql", "cache"}))
+			Expect(normalizeRestorePaths(nil)).To(BeNil())
+		})
+	})
+
+	Describe("environment variables", func() {
+		var mover *Mover
+
+		BeforeEach(func() {
+			mover = &Mover{isSource: false}
+		})
+
+		It("should pass include and exclude paths one per line", func() {
+			mover.includePaths = []string{"app/config", "db"}
+			mover.excludePaths = []string{"app/config/cache"}
+
+			envVars := mover.addDestinationEnvVars([]corev1.EnvVar{})
+			Expect(envVars).To(ContainElement(corev1.EnvVar{
+				Name:  envKopiaRestoreIncludePaths,
+				Value: "app/config\ndb",
+			}))
+			Expect(envVars).To(ContainElement(corev1.EnvVar{
+				Name:  envKopiaRestoreExcludePaths,
+				Value: "app/config/cache",
+			}))
+		})
+
+		It("should not set the variables for a full restore", func() {
+			envVars := mover.addDestinationEnvVars([]corev1.EnvVar{})
+			for _, env := range envVars {
+				Expect(env.Name).NotTo(Equal(envKopiaRestoreIncludePaths))
+				Expect(env.Name).NotTo(Equal(envKopiaRestoreExcludePaths))
+			}
+		})
+	})
+
+	Describe("restored paths status", func() {
+		It("should keep restored path lines in the filtered logs", func() {
+			Expect(LogFilter("Restored path: /db")).NotTo(BeNil())
+		})
+
+		It("should only be reported for partial restores", func() {
+			mover := &Mover{
+				destinationStatus: &volsyncv1alpha1.ReplicationDestinationKopiaStatus{
+					RestoredPaths: []string{"/stale"},
+				},
+				latestMoverStatus: &volsyncv1alpha1.MoverStatus{},
+				moverResult:       moverResultWithDetails(`{"restoredPaths":["/app/config/settings.yaml","/db"]}`),
+			}
+			mover.updateDestinationRestoredPaths()
+			Expect(mover.destinationStatus.RestoredPaths).To(BeNil())
+
+			mover.includePaths = []string{"app/config", "db"}
+			mover.updateDestinationRestoredPaths()
+			Expect(mover.destinationStatus.RestoredPaths).To(Equal([]string{"/app/config/settings.yaml", "/db"}))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+//go:build !disable_kopia
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package kopia
+
+import (
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+// kopiaResultDetails are the Kopia specific results that the mover writes in
+// the details of its utils.MoverResult
+type kopiaResultDetails struct {
+	// The identity whose snapshots couldn't be found, set when a restore
+	// fails because there are none
+	RequestedIdentity string `json:"requestedIdentity,omitempty"`
+	// The identities that have snapshots in the repository, listed when a
+	// restore fails in discovery mode
+	AvailableIdentities []volsyncv1alpha1.KopiaIdentityInfo `json:"availableIdentities,omitempty"`
+	// The paths restored by a partial restore
+	RestoredPaths []string `json:"restoredPaths,omitempty"`
+	// The result of the verification of the new snapshot
+	Verify *kopiaVerifyResult `json:"verify,omitempty"`
+	// Whether the sync to each syncTo target succeeded, keyed by the name of
+	// the target's Secret
+	SyncTo map[string]bool `json:"syncTo,omitempty"`
+}
+
+// resultDetails returns the Kopia specific results of the last mover job.
+// They are empty if the mover didn't report any.
+func (m *Mover) resultDetails() *kopiaResultDetails {
+	details := &kopiaResultDetails{}
+	if err := m.moverResult.DecodeDetails(details); err != nil {
+		m.logger.Error(err, "unable to read the result of the mover")
+	}
+	return details
+}
@@ -0,0 +1,85 @@
+//go:build !disable_kopia
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package kopia
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+// moverResultWithDetails returns a mover result with the given Kopia details
+func moverResultWithDetails(details string) *utils.MoverResult {
+	return &utils.MoverResult{Version: utils.MoverResultVersion, Details: json.RawMessage(details)}
+}
+
+var _ = Describe("Kopia mover result", func() {
+	var mover *Mover
+
+	BeforeEach(func() {
+		mover = &Mover{
+			logger:            logr.Discard(),
+			destinationStatus: &volsyncv1alpha1.ReplicationDestinationKopiaStatus{},
+			latestMoverStatus: &volsyncv1alpha1.MoverStatus{
+				Result: volsyncv1alpha1.MoverResultFailed,
+				Logs:   "ERROR: Failed to restore snapshot",
+			},
+		}
+	})
+
+	It("should be empty when the mover didn't report a result", func() {
+		Expect(mover.resultDetails()).To(Equal(&kopiaResultDetails{}))
+		mover.moverResult = moverResultWithDetails(`not json`)
+		Expect(mover.resultDetails()).To(Equal(&kopiaResultDetails{}))
+	})
+
+	It("should record the identities discovered by a failed restore", func() {
+		mover.moverResult = moverResultWithDetails(`{"requestedIdentity":"user1@host1","availableIdentities":[` +
+			`{"identity":"user3@host3","snapshotCount":1,"latestSnapshot":"2024-01-01T11:05:00Z"},` +
+			`{"identity":"user2@host2","snapshotCount":2,"latestSnapshot":"2024-01-02T10:05:00Z"}]}`)
+		mover.updateDestinationDiscoveryStatus()
+
+		status := mover.destinationStatus
+		Expect(status.RequestedIdentity).To(Equal("user1@host1"))
+		Expect(status.AvailableIdentities).To(HaveLen(2))
+		Expect(status.AvailableIdentities[0].Identity).To(Equal("user2@host2"))
+		Expect(status.AvailableIdentities[0].SnapshotCount).To(Equal(int32(2)))
+		Expect(status.AvailableIdentities[0].LatestSnapshot.Time).To(
+			BeTemporally("==", time.Date(2024, 1, 2, 10, 5, 0, 0, time.UTC)))
+		Expect(status.AvailableIdentities[1].Identity).To(Equal("user3@host3"))
+		Expect(status.SnapshotsFound).To(BeZero())
+		Expect(mover.latestMoverStatus.Logs).To(HavePrefix("No snapshots found for identity 'user1@host1'. " +
+			"Available identities: user2@host2, user3@host3\n\n"))
+	})
+
+	It("should explain a missing identity without discovery information", func() {
+		mover.moverResult = moverResultWithDetails(`{"requestedIdentity":"user1@host1"}`)
+		mover.updateDestinationDiscoveryStatus()
+		Expect(mover.destinationStatus.RequestedIdentity).To(Equal("user1@host1"))
+		Expect(mover.destinationStatus.AvailableIdentities).To(BeEmpty())
+		Expect(mover.latestMoverStatus.Logs).To(HavePrefix("No snapshots found for identity 'user1@host1'\n\n"))
+	})
+})
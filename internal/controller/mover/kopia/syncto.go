@@ -0,0 +1,135 @@
+//go:build !disable_kopia
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package kopia
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+const (
+	envKopiaSyncToCount = "KOPIA_SYNC_TO_COUNT"
+
+	// Line printed by the mover after syncing the repository to a target
+	syncToResultPrefix = "KOPIA_SYNC_TO_RESULT: "
+)
+
+// ValidateSyncTargets checks that each syncTo target is a different
+// repository than the source's and the other targets
+func ValidateSyncTargets(repository string, targets []volsyncv1alpha1.KopiaSyncTarget) error {
+	seen := map[string]bool{repository: true}
+	for _, t := range targets {
+		if seen[t.Repository] {
+			return fmt.Errorf("repository %q is used more than once", t.Repository)
+		}
+		seen[t.Repository] = true
+	}
+	return nil
+}
+
+// validateSyncTargets fetches the Secrets of the syncTo targets
+func (m *Mover) validateSyncTargets(ctx context.Context) ([]*corev1.Secret, error) {
+	secrets := make([]*corev1.Secret, 0, len(m.syncTo))
+	for _, target := range m.syncTo {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      target.Repository,
+				Namespace: m.owner.GetNamespace(),
+			},
+		}
+		logger := m.logger.WithValues("syncToSecret", client.ObjectKeyFromObject(secret))
+		if err := utils.GetAndValidateSecret(ctx, m.client, logger, secret); err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// addSyncToEnvVars passes the configuration of the syncTo targets to the
+// mover. The variables of each target have the same names as the
+// repository's, prefixed by KOPIA_SYNC_TO_<index>_.
+func (m *Mover) addSyncToEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
+	if len(m.syncToRepos) == 0 {
+		return envVars
+	}
+	envVars = append(envVars, corev1.EnvVar{Name: envKopiaSyncToCount, Value: strconv.Itoa(len(m.syncToRepos))})
+	for i, repo := range m.syncToRepos {
+		prefix := fmt.Sprintf("KOPIA_SYNC_TO_%d_", i)
+		envVars = append(envVars, corev1.EnvVar{Name: prefix + "NAME", Value: repo.Name})
+		targetVars := append([]corev1.EnvVar{utils.EnvFromSecret(repo.Name, kopiaRepositoryEnvVar, true)},
+			m.buildBackendEnvironmentVariables(repo)...)
+		for _, env := range targetVars {
+			env.Name = prefix + env.Name
+			envVars = append(envVars, env)
+		}
+	}
+	return envVars
+}
+
+// updateSourceSyncToStatus records the results of the syncs done by a
+// completed backup job. A failed sync doesn't fail the synchronization; it is
+// retried after the next backup.
+func (m *Mover) updateSourceSyncToStatus() {
+	if m.sourceStatus == nil {
+		return
+	}
+	if len(m.syncToRepos) == 0 {
+		m.sourceStatus.SyncTo = nil
+		return
+	}
+	results := m.resultDetails().SyncTo
+
+	previous := map[string]volsyncv1alpha1.KopiaSyncTargetStatus{}
+	for _, s := range m.sourceStatus.SyncTo {
+		previous[s.Repository] = s
+	}
+	now := metav1.Now()
+	statuses := make([]volsyncv1alpha1.KopiaSyncTargetStatus, 0, len(m.syncToRepos))
+	for _, repo := range m.syncToRepos {
+		status := previous[repo.Name]
+		status.Repository = repo.Name
+		succeeded, found := results[repo.Name]
+		switch {
+		case !found:
+			m.logger.Info("unable to find the result of the sync in the mover result", "syncTo", repo.Name)
+		case succeeded:
+			status.LastSynced = &now
+			status.ConsecutiveFailures = 0
+			m.logger.Info("repository synced", "syncTo", repo.Name)
+		default:
+			status.LastFailure = &now
+			status.ConsecutiveFailures++
+			m.eventRecorder.Eventf(m.owner, nil, corev1.EventTypeWarning,
+				volsyncv1alpha1.EvRSyncToFailed, volsyncv1alpha1.EvANone,
+				"unable to sync the repository to %s", repo.Name)
+		}
+		statuses = append(statuses, status)
+	}
+	m.sourceStatus.SyncTo = statuses
+}
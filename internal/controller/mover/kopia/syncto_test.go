@@ -0,0 +1,116 @@
+//go:build !disable_kopia
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package kopia
+
+import (
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var _ = Describe("Kopia syncTo", func() {
+	var mover *Mover
+	var recorder *events.FakeRecorder
+
+	BeforeEach(func() {
+		recorder = events.NewFakeRecorder(10)
+		mover = &Mover{
+			logger:        logr.Discard(),
+			eventRecorder: recorder,
+			owner: &volsyncv1alpha1.ReplicationSource{
+				ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "ns"},
+			},
+			isSource: true,
+			syncTo: []volsyncv1alpha1.KopiaSyncTarget{
+				{Repository: "local-bucket"},
+				{Repository: "remote-bucket"},
+			},
+			syncToRepos: []*corev1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Name: "local-bucket", Namespace: "ns"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "remote-bucket", Namespace: "ns"}},
+			},
+			sourceStatus:      &volsyncv1alpha1.ReplicationSourceKopiaStatus{},
+			latestMoverStatus: &volsyncv1alpha1.MoverStatus{Result: volsyncv1alpha1.MoverResultSuccessful},
+		}
+	})
+
+	It("should reject targets that are used more than once", func() {
+		Expect(ValidateSyncTargets("kopia-secret", mover.syncTo)).To(Succeed())
+		Expect(ValidateSyncTargets("local-bucket", mover.syncTo)).NotTo(Succeed())
+		Expect(ValidateSyncTargets("kopia-secret", append(mover.syncTo,
+			volsyncv1alpha1.KopiaSyncTarget{Repository: "remote-bucket"}))).NotTo(Succeed())
+	})
+
+	It("should pass the backend of each target with a prefix", func() {
+		envVars := mover.addSyncToEnvVars(nil)
+		Expect(envVars).To(ContainElements(
+			corev1.EnvVar{Name: envKopiaSyncToCount, Value: "2"},
+			corev1.EnvVar{Name: "KOPIA_SYNC_TO_0_NAME", Value: "local-bucket"},
+			corev1.EnvVar{Name: "KOPIA_SYNC_TO_1_NAME", Value: "remote-bucket"},
+		))
+		byName := map[string]corev1.EnvVar{}
+		for _, env := range envVars {
+			byName[env.Name] = env
+		}
+		Expect(byName).To(HaveKey("KOPIA_SYNC_TO_0_KOPIA_REPOSITORY"))
+		Expect(byName).To(HaveKey("KOPIA_SYNC_TO_1_AWS_ACCESS_KEY_ID"))
+		Expect(byName).NotTo(HaveKey("KOPIA_SYNC_TO_1_KOPIA_PASSWORD"))
+		secretRef := byName["KOPIA_SYNC_TO_1_AWS_ACCESS_KEY_ID"].ValueFrom.SecretKeyRef
+		Expect(secretRef.Name).To(Equal("remote-bucket"))
+		Expect(secretRef.Key).To(Equal("AWS_ACCESS_KEY_ID"))
+
+		mover.syncToRepos = nil
+		Expect(mover.addSyncToEnvVars(nil)).To(BeEmpty())
+	})
+
+	It("should keep the result lines in the filtered logs", func() {
+		Expect(LogFilter("KOPIA_SYNC_TO_RESULT: local-bucket SUCCESS")).NotTo(BeNil())
+	})
+
+	It("should record the result of each sync", func() {
+		mover.sourceStatus.SyncTo = []volsyncv1alpha1.KopiaSyncTargetStatus{
+			{Repository: "remote-bucket", ConsecutiveFailures: 1},
+			{Repository: "removed-bucket"},
+		}
+		mover.moverResult = moverResultWithDetails(`{"syncTo":{"local-bucket":true,"remote-bucket":false}}`)
+		mover.updateSourceSyncToStatus()
+
+		status := mover.sourceStatus.SyncTo
+		Expect(status).To(HaveLen(2))
+		Expect(status[0].Repository).To(Equal("local-bucket"))
+		Expect(status[0].LastSynced).NotTo(BeNil())
+		Expect(status[1].Repository).To(Equal("remote-bucket"))
+		Expect(status[1].LastSynced).To(BeNil())
+		Expect(status[1].LastFailure).NotTo(BeNil())
+		Expect(status[1].ConsecutiveFailures).To(Equal(int32(2)))
+		Expect(recorder.Events).To(Receive(ContainSubstring(volsyncv1alpha1.EvRSyncToFailed)))
+		// A failed sync doesn't fail the backup
+		Expect(mover.latestMoverStatus.Result).To(Equal(volsyncv1alpha1.MoverResultSuccessful))
+
+		mover.syncToRepos = nil
+		mover.updateSourceSyncToStatus()
+		Expect(mover.sourceStatus.SyncTo).To(BeNil())
+	})
+})
@@ -0,0 +1,185 @@
+//go:build !disable_kopia
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package kopia
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+const defaultVerifyFilesPercent = 10
+
+// kopiaVerifyResult is the result of a verification as reported by the mover
+type kopiaVerifyResult struct {
+	SnapshotID string `json:"snapshotID"`
+	Objects    int64  `json:"objects"`
+	Errors     int64  `json:"errors"`
+	Succeeded  bool   `json:"succeeded"`
+}
+
+// verifyFilesPercent returns the percentage of files to read back
+func (m *Mover) verifyFilesPercent() int32 {
+	if m.verify == nil || m.verify.FilesPercent == nil {
+		return defaultVerifyFilesPercent
+	}
+	return *m.verify.FilesPercent
+}
+
+// shouldVerify determines whether the snapshot created by the next sync
+// should be verified
+func (m *Mover) shouldVerify(current time.Time) bool {
+	if m.verify == nil {
+		return false
+	}
+	var status volsyncv1alpha1.KopiaVerifyStatus
+	if m.sourceStatus != nil && m.sourceStatus.Verify != nil {
+		status = *m.sourceStatus.Verify
+	}
+
+	switch {
+	case m.verify.EveryNSyncs != nil:
+		return status.SyncsSinceLastVerify+1 >= *m.verify.EveryNSyncs
+	case m.verify.Schedule != nil:
+		if status.LastVerified.IsZero() {
+			return true
+		}
+		parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+		schedule, err := parser.Parse(*m.verify.Schedule)
+		if err != nil {
+			m.logger.Error(err, "invalid verify schedule", "schedule", *m.verify.Schedule)
+			return false
+		}
+		return !schedule.Next(status.LastVerified.Time).After(current)
+	default:
+		return true
+	}
+}
+
+// shouldVerifyJob determines whether the mover job should verify its
+// snapshot. Once the job exists the decision is kept, so that the job isn't
+// recreated when a verify schedule passes while it is running.
+func (m *Mover) shouldVerifyJob(job *batchv1.Job, current time.Time) bool {
+	if m.verify == nil {
+		return false
+	}
+	if job.CreationTimestamp.IsZero() {
+		return m.shouldVerify(current)
+	}
+	for _, c := range job.Spec.Template.Spec.Containers {
+		for _, env := range c.Env {
+			if env.Name == envKopiaVerifyFilesPercent {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addVerifyEnvVars requests the mover to verify the new snapshot
+func (m *Mover) addVerifyEnvVars(envVars []corev1.EnvVar) []corev1.EnvVar {
+	if !m.verifyThisSync {
+		return envVars
+	}
+	return append(envVars, corev1.EnvVar{
+		Name:  envKopiaVerifyFilesPercent,
+		Value: strconv.Itoa(int(m.verifyFilesPercent())),
+	})
+}
+
+// updateSourceVerifyStatus records the result of the verification done by a
+// completed backup job. A failed verification marks the latest mover status
+// as failed; the snapshot itself was created, so the sync still completes.
+func (m *Mover) updateSourceVerifyStatus() {
+	if m.verify == nil || m.sourceStatus == nil {
+		return
+	}
+	if m.sourceStatus.Verify == nil {
+		m.sourceStatus.Verify = &volsyncv1alpha1.KopiaVerifyStatus{}
+	}
+	status := m.sourceStatus.Verify
+
+	if !m.verifyThisSync {
+		status.SyncsSinceLastVerify++
+		return
+	}
+
+	result := m.resultDetails().Verify
+	if result == nil {
+		// The job ran with verification enabled but its result couldn't be
+		// read back, treat it as a failure rather than silently passing
+		result = &kopiaVerifyResult{Errors: 1}
+	}
+	result.Succeeded = result.Succeeded && result.Errors == 0
+
+	now := metav1.Now()
+	status.LastVerified = &now
+	status.SnapshotID = result.SnapshotID
+	status.VerifiedObjects = result.Objects
+	status.Errors = result.Errors
+	status.SyncsSinceLastVerify = 0
+
+	m.recordVerifyResult(result)
+
+	condition := metav1.Condition{
+		Type:    volsyncv1alpha1.ConditionVerified,
+		Status:  metav1.ConditionTrue,
+		Reason:  volsyncv1alpha1.VerifiedReasonSucceeded,
+		Message: fmt.Sprintf("Verified %d objects of snapshot %s", result.Objects, result.SnapshotID),
+	}
+	if !result.Succeeded {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = volsyncv1alpha1.VerifiedReasonFailed
+		condition.Message = fmt.Sprintf("Verification of snapshot %s found %d errors in %d objects",
+			result.SnapshotID, result.Errors, result.Objects)
+		if m.latestMoverStatus != nil {
+			m.latestMoverStatus.Result = volsyncv1alpha1.MoverResultFailed
+		}
+		m.eventRecorder.Eventf(m.owner, nil, corev1.EventTypeWarning,
+			volsyncv1alpha1.EvRVerifyFailed, volsyncv1alpha1.EvANone, condition.Message)
+	}
+	if m.conditions != nil {
+		apimeta.SetStatusCondition(m.conditions, condition)
+	}
+	m.logger.Info("snapshot verification completed", "snapshotID", result.SnapshotID,
+		"objects", result.Objects, "errors", result.Errors)
+}
+
+// recordVerifyResult records the metrics of a verification
+func (m *Mover) recordVerifyResult(result *kopiaVerifyResult) {
+	labels := m.getMetricLabels(operationVerify)
+	m.metrics.VerifiedObjects.With(labels).Set(float64(result.Objects))
+	m.metrics.VerifyErrors.With(labels).Set(float64(result.Errors))
+
+	resultLabels := m.getMetricLabels(operationVerify)
+	resultLabels["result"] = "success"
+	if !result.Succeeded {
+		resultLabels["result"] = "failure"
+	}
+	m.metrics.VerifyOperations.With(resultLabels).Inc()
+}
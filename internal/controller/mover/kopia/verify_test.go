@@ -0,0 +1,165 @@
+//go:build !disable_kopia
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package kopia
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var _ = Describe("Kopia snapshot verification", func() {
+	var mover *Mover
+	var conditions []metav1.Condition
+
+	BeforeEach(func() {
+		conditions = nil
+		mover = &Mover{
+			logger:        logr.Discard(),
+			eventRecorder: &events.FakeRecorder{},
+			owner: &volsyncv1alpha1.ReplicationSource{
+				ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "ns"},
+			},
+			isSource:          true,
+			metrics:           newKopiaMetrics(),
+			verify:            &volsyncv1alpha1.KopiaVerifySpec{},
+			sourceStatus:      &volsyncv1alpha1.ReplicationSourceKopiaStatus{},
+			latestMoverStatus: &volsyncv1alpha1.MoverStatus{Result: volsyncv1alpha1.MoverResultSuccessful},
+			conditions:        &conditions,
+		}
+	})
+
+	Describe("reading the mover result", func() {
+		It("should decode the verification", func() {
+			mover.moverResult = moverResultWithDetails(
+				`{"verify":{"snapshotID":"k1234","objects":42,"errors":0,"succeeded":true}}`)
+			Expect(mover.resultDetails().Verify).To(Equal(
+				&kopiaVerifyResult{SnapshotID: "k1234", Objects: 42, Succeeded: true}))
+		})
+		It("should return nil without a verification", func() {
+			Expect(mover.resultDetails().Verify).To(BeNil())
+			mover.moverResult = moverResultWithDetails(`{"syncTo":{"target":true}}`)
+			Expect(mover.resultDetails().Verify).To(BeNil())
+		})
+		It("should keep the result lines in the filtered logs", func() {
+			Expect(LogFilter("KOPIA_VERIFY_RESULT: SUCCESS")).NotTo(BeNil())
+			Expect(LogFilter("KOPIA_VERIFY_OBJECTS: 42")).NotTo(BeNil())
+		})
+	})
+
+	Describe("deciding when to verify", func() {
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+		It("should not verify without a verify spec", func() {
+			mover.verify = nil
+			Expect(mover.shouldVerify(now)).To(BeFalse())
+		})
+		It("should verify every sync by default", func() {
+			Expect(mover.shouldVerify(now)).To(BeTrue())
+		})
+		It("should verify every Nth sync", func() {
+			mover.verify.EveryNSyncs = ptr.To(int32(3))
+			mover.sourceStatus.Verify = &volsyncv1alpha1.KopiaVerifyStatus{SyncsSinceLastVerify: 1}
+			Expect(mover.shouldVerify(now)).To(BeFalse())
+			mover.sourceStatus.Verify.SyncsSinceLastVerify = 2
+			Expect(mover.shouldVerify(now)).To(BeTrue())
+		})
+		It("should verify once the schedule has passed", func() {
+			mover.verify.Schedule = ptr.To("0 0 * * *")
+			Expect(mover.shouldVerify(now)).To(BeTrue())
+			mover.sourceStatus.Verify = &volsyncv1alpha1.KopiaVerifyStatus{
+				LastVerified: &metav1.Time{Time: now.Add(-2 * time.Hour)},
+			}
+			Expect(mover.shouldVerify(now)).To(BeFalse())
+			mover.sourceStatus.Verify.LastVerified = &metav1.Time{Time: now.Add(-13 * time.Hour)}
+			Expect(mover.shouldVerify(now)).To(BeTrue())
+		})
+		It("should keep the decision of an existing job", func() {
+			job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Now()}}
+			Expect(mover.shouldVerifyJob(job, now)).To(BeFalse())
+			job.Spec.Template.Spec.Containers = []corev1.Container{{
+				Env: []corev1.EnvVar{{Name: envKopiaVerifyFilesPercent, Value: "10"}},
+			}}
+			Expect(mover.shouldVerifyJob(job, now)).To(BeTrue())
+		})
+	})
+
+	It("should pass the percentage of files to verify", func() {
+		mover.verifyThisSync = true
+		mover.verify.FilesPercent = ptr.To(int32(25))
+		Expect(mover.addVerifyEnvVars(nil)).To(ConsistOf(corev1.EnvVar{
+			Name: envKopiaVerifyFilesPercent, Value: "25"}))
+		mover.verifyThisSync = false
+		Expect(mover.addVerifyEnvVars(nil)).To(BeEmpty())
+	})
+
+	Describe("recording the result", func() {
+		It("should count syncs that weren't verified", func() {
+			mover.updateSourceVerifyStatus()
+			mover.updateSourceVerifyStatus()
+			Expect(mover.sourceStatus.Verify.SyncsSinceLastVerify).To(Equal(int32(2)))
+			Expect(conditions).To(BeEmpty())
+		})
+		It("should record a successful verification", func() {
+			mover.verifyThisSync = true
+			mover.sourceStatus.Verify = &volsyncv1alpha1.KopiaVerifyStatus{SyncsSinceLastVerify: 4}
+			mover.moverResult = moverResultWithDetails(
+				`{"verify":{"snapshotID":"k1","objects":7,"errors":0,"succeeded":true}}`)
+			mover.updateSourceVerifyStatus()
+
+			status := mover.sourceStatus.Verify
+			Expect(status.LastVerified).NotTo(BeNil())
+			Expect(status.SnapshotID).To(Equal("k1"))
+			Expect(status.VerifiedObjects).To(Equal(int64(7)))
+			Expect(status.SyncsSinceLastVerify).To(BeZero())
+			Expect(apimeta.IsStatusConditionTrue(conditions, volsyncv1alpha1.ConditionVerified)).To(BeTrue())
+			Expect(mover.latestMoverStatus.Result).To(Equal(volsyncv1alpha1.MoverResultSuccessful))
+		})
+		It("should mark the mover status as failed when verification fails", func() {
+			mover.verifyThisSync = true
+			mover.moverResult = moverResultWithDetails(
+				`{"verify":{"snapshotID":"k1","objects":7,"errors":3,"succeeded":false}}`)
+			mover.updateSourceVerifyStatus()
+
+			Expect(mover.sourceStatus.Verify.Errors).To(Equal(int64(3)))
+			cond := apimeta.FindStatusCondition(conditions, volsyncv1alpha1.ConditionVerified)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(volsyncv1alpha1.VerifiedReasonFailed))
+			Expect(mover.latestMoverStatus.Result).To(Equal(volsyncv1alpha1.MoverResultFailed))
+		})
+		It("should treat a missing result as a failure", func() {
+			mover.verifyThisSync = true
+			mover.updateSourceVerifyStatus()
+			Expect(apimeta.IsStatusConditionFalse(conditions, volsyncv1alpha1.ConditionVerified)).To(BeTrue())
+			Expect(mover.latestMoverStatus.Result).To(Equal(volsyncv1alpha1.MoverResultFailed))
+		})
+	})
+})
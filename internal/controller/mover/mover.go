@@ -60,6 +60,17 @@ type Result struct {
 	// is modified. Setting to 0 indicates an immediate retry. Other values
 	// provide a delay.
 	RetryAfter *time.Duration
+
+	// Failure is set if the synchronization attempt failed. Synchronize() will
+	// be called again to retry the synchronization, subject to the retry
+	// policy of the ReplicationSource or ReplicationDestination.
+	Failure *AttemptFailure
+
+	// TransferredBytesTotal is the amount of data transferred since it started
+	// by a mover that synchronizes continuously (e.g., Syncthing), and so
+	// never completes a synchronization. Its increase is added to the
+	// transferred bytes metric.
+	TransferredBytesTotal *int64
 }
 
 // ReconcileResult converts a Result into controllerruntime's reconcile result
@@ -89,6 +100,14 @@ func InProgress() Result {
 // requeueing after the provided duration.
 func RetryAfter(s time.Duration) Result { return Result{RetryAfter: &s} }
 
+// Failed indicates that the synchronization attempt failed (e.g., the mover
+// Job failed) and that a new attempt should be made.
+func Failed(failure AttemptFailure) Result {
+	r := InProgress()
+	r.Failure = &failure
+	return r
+}
+
 // Complete indicates that the operation has completed.
 func Complete() Result {
 	return Result{
@@ -100,6 +100,9 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 	if source.Spec.Rclone == nil {
 		return nil, nil
 	}
+	if source.Spec.Rclone.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		return nil, mover.ErrGroupSnapshotNotSupported
+	}
 
 	if source.Status.LatestMoverStatus == nil {
 		source.Status.LatestMoverStatus = &volsyncv1alpha1.MoverStatus{}
@@ -149,6 +152,9 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 	if destination.Spec.Rclone == nil {
 		return nil, nil
 	}
+	if destination.Spec.Rclone.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		return nil, mover.ErrGroupSnapshotDestination
+	}
 
 	if destination.Status.LatestMoverStatus == nil {
 		destination.Status.LatestMoverStatus = &volsyncv1alpha1.MoverStatus{}
@@ -67,6 +67,7 @@ type Mover struct {
 	customCASpec        volsyncv1alpha1.CustomCASpec
 	privileged          bool // true if the mover should have elevated privileges
 	latestMoverStatus   *volsyncv1alpha1.MoverStatus
+	jobFailure          *mover.AttemptFailure
 	moverConfig         volsyncv1alpha1.MoverConfig
 	moverVolumes        []volsyncv1alpha1.MoverVolume
 	// Destination-only fields
@@ -133,6 +134,9 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 	// Start mover Job
 	job, err := m.ensureJob(ctx, dataPVC, sa, rcloneConfigSecret, customCAObj)
 	if job == nil || err != nil {
+		if err == nil && m.jobFailure != nil {
+			return mover.Failed(*m.jobFailure), nil
+		}
 		return mover.InProgress(), err
 	}
 
@@ -248,8 +252,7 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 		utils.MarkForCleanup(m.owner, job)
 		job.Spec.Template.Name = job.Name
 		utils.SetOwnedByVolSync(&job.Spec.Template)
-		backoffLimit := int32(2) //TODO: backofflimit was 8 for restic
-		job.Spec.BackoffLimit = &backoffLimit
+		job.Spec.BackoffLimit = mover.JobBackoffLimit(m.owner, 2)
 
 		parallelism := int32(1)
 		if m.paused {
@@ -268,6 +271,9 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 			{Name: "MOUNT_PATH", Value: mountPath},
 			{Name: "RCLONE_CONFIG_SECTION", Value: *m.rcloneConfigSection},
 		}
+		if bwlimit := utils.RcloneBandwidthLimit(m.moverConfig.TransferPolicy); bwlimit != "" {
+			defaultEnvVars = append(defaultEnvVars, corev1.EnvVar{Name: "RCLONE_BWLIMIT", Value: bwlimit})
+		}
 
 		// Add our defaults after RCLONE_ env vars so any duplicates will be
 		// overridden by the defaults
@@ -382,12 +388,13 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 		return nil
 	})
 	// If Job had failed, delete it so it can be recreated
-	if job.Status.Failed >= *job.Spec.BackoffLimit {
+	if mover.JobFailed(job) {
 		// Update status with mover logs from failed job
 		utils.UpdateMoverStatusForFailedJob(ctx, m.logger, m.latestMoverStatus, job.GetName(), job.GetNamespace(),
 			utils.AllLines)
 
 		logger.Info("deleting job -- backoff limit reached")
+		m.jobFailure = ptr.To(mover.JobFailure(m.latestMoverStatus))
 		err = m.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
 		return nil, err
 	}
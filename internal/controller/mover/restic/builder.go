@@ -22,6 +22,9 @@ package restic
 import (
 	"flag"
 	"fmt"
+	"path"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/viper"
@@ -81,6 +84,13 @@ func newBuilder(viper *viper.Viper, flags *flag.FlagSet) (*Builder, error) {
 	return b, err
 }
 
+// ContainerImage returns the container image of the restic data mover, as
+// configured by its command line flag or env var. Register must have been
+// called first.
+func ContainerImage() string {
+	return viper.GetString(resticContainerImageFlag)
+}
+
 func (rb *Builder) Name() string { return resticMoverName }
 
 func (rb *Builder) VersionInfo() string {
@@ -109,6 +119,24 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 		source.Status.LatestMoverStatus = &volsyncv1alpha1.MoverStatus{}
 	}
 
+	// Validate the include and exclude patterns
+	if err := ValidateBackupIncludes(source.Spec.Restic.Includes); err != nil {
+		return nil, fmt.Errorf("invalid includes: %w", err)
+	}
+	if err := ValidatePatterns(source.Spec.Restic.Excludes); err != nil {
+		return nil, fmt.Errorf("invalid excludes: %w", err)
+	}
+	if err := ValidatePatterns(source.Spec.Restic.CaseInsensitiveExcludes); err != nil {
+		return nil, fmt.Errorf("invalid caseInsensitiveExcludes: %w", err)
+	}
+	if err := ValidatePatterns(source.Spec.Restic.ExcludeIfPresent); err != nil {
+		return nil, fmt.Errorf("invalid excludeIfPresent: %w", err)
+	}
+	if err := ValidateSecondaryRepositories(source.Spec.Restic.Repository,
+		source.Spec.Restic.SecondaryRepositories); err != nil {
+		return nil, fmt.Errorf("invalid secondaryRepositories: %w", err)
+	}
+
 	vh, err := volumehandler.NewVolumeHandler(
 		volumehandler.WithClient(client),
 		volumehandler.WithRecorder(eventRecorder),
@@ -139,11 +167,18 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 		isSource:              isSource,
 		paused:                source.Spec.Paused,
 		mainPVCName:           &source.Spec.SourcePVC,
+		sourcePVCSelector:     source.Spec.SourcePVCSelector,
 		customCASpec:          volsyncv1alpha1.CustomCASpec(source.Spec.Restic.CustomCA),
 		privileged:            privileged,
 		pruneInterval:         source.Spec.Restic.PruneIntervalDays,
 		retainPolicy:          source.Spec.Restic.Retain,
 		unlock:                source.Spec.Restic.Unlock,
+		check:                 source.Spec.Restic.Check,
+		includes:              normalizeBackupIncludes(source.Spec.Restic.Includes),
+		excludes:              source.Spec.Restic.Excludes,
+		iexcludes:             source.Spec.Restic.CaseInsensitiveExcludes,
+		excludeIfPresent:      source.Spec.Restic.ExcludeIfPresent,
+		secondaries:           source.Spec.Restic.SecondaryRepositories,
 		sourceStatus:          source.Status.Restic,
 		latestMoverStatus:     source.Status.LatestMoverStatus,
 		moverConfig:           source.Spec.Restic.MoverConfig,
@@ -158,16 +193,24 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 	if destination.Spec.Restic == nil {
 		return nil, nil
 	}
+	if destination.Spec.Restic.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		return nil, mover.ErrGroupSnapshotDestination
+	}
 
 	if destination.Status.LatestMoverStatus == nil {
 		destination.Status.LatestMoverStatus = &volsyncv1alpha1.MoverStatus{}
 	}
 
+	if err := ValidatePatterns(destination.Spec.Restic.RestoreIncludes); err != nil {
+		return nil, fmt.Errorf("invalid restoreIncludes: %w", err)
+	}
+
 	vh, err := volumehandler.NewVolumeHandler(
 		volumehandler.WithClient(client),
 		volumehandler.WithRecorder(eventRecorder),
 		volumehandler.WithOwner(destination),
 		volumehandler.FromDestination(&destination.Spec.Restic.ReplicationDestinationVolumeOptions),
+		volumehandler.VolumeMode(destination.Spec.Restic.VolumeMode), // Allow setting block mode for dynamic dest PVC
 	)
 	if err != nil {
 		return nil, err
@@ -199,9 +242,75 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 		privileged:                  privileged,
 		restoreAsOf:                 destination.Spec.Restic.RestoreAsOf,
 		previous:                    destination.Spec.Restic.Previous,
+		snapshotID:                  destination.Spec.Restic.SnapshotID,
 		enableFileDeletionOnRestore: destination.Spec.Restic.EnableFileDeletion,
+		restoreIncludes:             destination.Spec.Restic.RestoreIncludes,
 		latestMoverStatus:           destination.Status.LatestMoverStatus,
 		moverConfig:                 destination.Spec.Restic.MoverConfig,
 		moverVolumes:                destination.Spec.Restic.MoverVolumes,
 	}, nil
 }
+
+// ValidatePatterns validates the include and exclude patterns of the restic
+// mover. They are passed to the mover one per line, so they may not be empty
+// or span multiple lines.
+func ValidatePatterns(patterns []string) error {
+	for _, p := range patterns {
+		if strings.TrimSpace(p) == "" {
+			return fmt.Errorf("patterns must not be empty")
+		}
+		if strings.ContainsAny(p, "\r\n") {
+			return fmt.Errorf("pattern %q must not contain a newline", p)
+		}
+	}
+	return nil
+}
+
+// ValidateBackupIncludes validates the includes of a ReplicationSource. Paths
+// are interpreted relative to the root of the volume, so they may not refer
+// to the root itself or contain ".." elements.
+func ValidateBackupIncludes(paths []string) error {
+	if err := ValidatePatterns(paths); err != nil {
+		return err
+	}
+	for _, p := range paths {
+		if slices.Contains(strings.Split(p, "/"), "..") {
+			return fmt.Errorf("path %q must not contain '..'", p)
+		}
+		if normalizeBackupInclude(p) == "" {
+			return fmt.Errorf("path %q must refer to a file or directory within the volume", p)
+		}
+	}
+	return nil
+}
+
+// normalizeBackupInclude converts an include to a cleaned path relative to the
+// root of the volume. The root normalizes to "".
+func normalizeBackupInclude(p string) string {
+	return strings.Trim(path.Clean("/"+p), "/")
+}
+
+func normalizeBackupIncludes(paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+	normalized := make([]string, 0, len(paths))
+	for _, p := range paths {
+		normalized = append(normalized, normalizeBackupInclude(p))
+	}
+	return normalized
+}
+
+// ValidateSecondaryRepositories checks that each secondary repository of a
+// ReplicationSource uses a different Secret than the primary repository and
+// the other secondaries
+func ValidateSecondaryRepositories(primary string, secondaries []volsyncv1alpha1.ResticSecondaryRepository) error {
+	seen := map[string]bool{primary: true}
+	for _, s := range secondaries {
+		if seen[s.Repository] {
+			return fmt.Errorf("repository %q is used more than once", s.Repository)
+		}
+		seen[s.Repository] = true
+	}
+	return nil
+}
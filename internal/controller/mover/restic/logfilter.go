@@ -20,7 +20,13 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package restic
 
 import (
+	"math"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/backube/volsync/internal/controller/utils"
 )
 
 var resticRegex = regexp.MustCompile(
@@ -40,7 +46,9 @@ var resticRegex = regexp.MustCompile(
 		`([iI]nitialize [dD]ir)|` +
 		`^\s*([fF]atal)|` +
 		`^\s*(ERROR)|` +
-		`^\s*([rR]estic completed in)`)
+		`^\s*([rR]estic completed in)|` +
+		`^\s*(Repository check (passed|failed))|` +
+		`^\s*(Copy to secondary repository)`)
 
 // Filter restic log lines for a successful move job
 func LogLineFilterSuccess(line string) *string {
@@ -49,3 +57,59 @@ func LogLineFilterSuccess(line string) *string {
 	}
 	return nil
 }
+
+// Restic prints its progress every 10 seconds (RESTIC_PROGRESS_FPS) as
+// "[0:10] 45.23%  120 files 1.234 GiB, total 500 files 2.729 GiB, 0 errors ETA 0:12".
+// Restores count "files/dirs" and don't estimate the time remaining.
+var resticProgressRegex = regexp.MustCompile(
+	`^\[[\d:]+\]\s+[\d.]+%\s+(\d+) files(?:/dirs)? ([\d.]+ [KMGT]?i?B), ` +
+		`total (\d+) files(?:/dirs)? ([\d.]+ [KMGT]?i?B)(?:.*ETA ([\d:]+))?`)
+
+// ProgressFilter extracts the progress of a backup or restore from a line of
+// restic output
+func ProgressFilter(line string) *utils.MoverProgress {
+	m := resticProgressRegex.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return nil
+	}
+	p := &utils.MoverProgress{}
+	p.FilesDone, _ = strconv.ParseInt(m[1], 10, 64)
+	p.BytesDone = parseResticBytes(m[2])
+	p.FilesTotal, _ = strconv.ParseInt(m[3], 10, 64)
+	p.BytesTotal = parseResticBytes(m[4])
+	if m[5] != "" {
+		p.Remaining = parseResticDuration(m[5])
+	}
+	return p
+}
+
+// parseResticBytes parses a size formatted by restic, such as "1.234 GiB"
+func parseResticBytes(size string) int64 {
+	value, unit, _ := strings.Cut(size, " ")
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	multiplier := map[string]float64{
+		"B":   1,
+		"KiB": 1 << 10,
+		"MiB": 1 << 20,
+		"GiB": 1 << 30,
+		"TiB": 1 << 40,
+	}[unit]
+	return int64(math.Round(v * multiplier))
+}
+
+// parseResticDuration parses a duration formatted by restic as [[h:]m:]s
+func parseResticDuration(d string) *time.Duration {
+	var seconds int64
+	for _, part := range strings.Split(d, ":") {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil
+		}
+		seconds = seconds*60 + n
+	}
+	remaining := time.Duration(seconds) * time.Second
+	return &remaining
+}
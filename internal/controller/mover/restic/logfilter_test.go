@@ -21,6 +21,7 @@ package restic_test
 
 import (
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -264,6 +265,53 @@ Restic completed in 4s`
 		})
 	})
 
+	Context("Restic source mover logs - repository check", func() {
+		// Sample backup log for restic mover where the repository check failed
+		resticSourceLog := `Starting container
+VolSync restic container version: v0.14.0+4d9ce89-dirty
+backup check
+restic 0.18.0 compiled with go1.24.4 on linux/amd64
+Testing mandatory env variables
+=== Starting backup ===
+repository 1d0d2e5f opened (version 2, compression level auto)
+using parent snapshot 5e9c8c7b
+Added to the repository: 1.236 KiB (804 B stored)
+processed 12 files, 4.001 MiB in 0:01
+snapshot 9a04bd17 saved
+=== Starting forget ===
+=== Starting check ===
+using temporary cache in /tmp/restic-check-cache-1790461337
+create exclusive lock for repository
+load indexes
+check all packs
+pack 3b8f6b0a: not referenced in any index
+check snapshots, trees and blobs
+error for tree 0ab4e3c1:
+  tree 0ab4e3c1: file "data.db" blob 0 size could not be found
+Fatal: repository contains errors
+Repository check failed
+Restic completed in 6s
+=== Done ===`
+
+		expectedFilteredResticSourceLog := `repository 1d0d2e5f opened (version 2, compression level auto)
+using parent snapshot 5e9c8c7b
+Added to the repository: 1.236 KiB (804 B stored)
+processed 12 files, 4.001 MiB in 0:01
+snapshot 9a04bd17 saved
+Fatal: repository contains errors
+Repository check failed
+Restic completed in 6s`
+
+		It("Should keep the result of the check", func() {
+			reader := strings.NewReader(resticSourceLog)
+			filteredLines, err := utils.FilterLogs(reader, restic.LogLineFilterSuccess)
+			Expect(err).NotTo(HaveOccurred())
+
+			logger.Info("Logs after filter", "filteredLines", filteredLines)
+			Expect(filteredLines).To(Equal(expectedFilteredResticSourceLog))
+		})
+	})
+
 	Context("Restic mover logs - bad password", func() {
 		// Sample restore log for restic mover
 		// nolint:lll
@@ -291,4 +339,29 @@ ERROR: failure checking existence of repository`
 		})
 	})
 
+	Context("Restic progress", func() {
+		It("Should parse the progress of a backup", func() {
+			p := restic.ProgressFilter(
+				"[0:10] 45.23%  120 files 1.500 GiB, total 500 files 3.000 GiB, 0 errors ETA 1:02")
+			Expect(p).NotTo(BeNil())
+			Expect(p.FilesDone).To(Equal(int64(120)))
+			Expect(p.FilesTotal).To(Equal(int64(500)))
+			Expect(p.BytesDone).To(Equal(int64(1536 * 1024 * 1024)))
+			Expect(p.BytesTotal).To(Equal(int64(3 * 1024 * 1024 * 1024)))
+			Expect(p.Remaining).To(HaveValue(Equal(62 * time.Second)))
+		})
+
+		It("Should parse the progress of a restore", func() {
+			p := restic.ProgressFilter("[0:20] 10.00%  3 files/dirs 512 KiB, total 30 files/dirs 5.000 MiB")
+			Expect(p).NotTo(BeNil())
+			Expect(p.FilesDone).To(Equal(int64(3)))
+			Expect(p.BytesDone).To(Equal(int64(512 * 1024)))
+			Expect(p.BytesTotal).To(Equal(int64(5 * 1024 * 1024)))
+			Expect(p.Remaining).To(BeNil())
+		})
+
+		It("Should ignore other lines", func() {
+			Expect(restic.ProgressFilter("snapshot 5e5cd2a5 saved")).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,63 @@
+//go:build !disable_restic
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package restic
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	resticMetricsNamespace = "volsync_restic"
+)
+
+var (
+	resticMetricLabels = []string{
+		"obj_name",      // Name of the replication CR
+		"obj_namespace", // Namespace containing the CR
+		"repository",    // Repository secret name
+	}
+
+	checkFailed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      "repository_check_failed",
+			Namespace: resticMetricsNamespace,
+			Help:      "Set to 1 if the last integrity check of the repository failed",
+		},
+		resticMetricLabels,
+	)
+
+	lastCheckTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:      "repository_last_check_timestamp_seconds",
+			Namespace: resticMetricsNamespace,
+			Help:      "Unix time of the last integrity check of the repository",
+		},
+		resticMetricLabels,
+	)
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(
+		checkFailed,
+		lastCheckTimestamp,
+	)
+}
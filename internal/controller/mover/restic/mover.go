@@ -20,15 +20,21 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package restic
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"path"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	vgsv1beta2 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta2"
 	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	"github.com/prometheus/client_golang/prometheus"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -49,12 +55,15 @@ import (
 const (
 	resticCacheMountPath = "/cache"
 	mountPath            = "/data"
+	devicePath           = "/dev/block"
 	dataVolumeName       = "data"
 	resticCache          = "cache"
 	resticCAMountPath    = "/customCA"
 	resticCAFilename     = "ca.crt"
 	credentialDir        = "/credentials"
 	gcsCredentialFile    = "gcs.json"
+	// Set on the mover only when the job checks the repository
+	envCheckFailureFatal = "CHECK_FAILURE_FATAL"
 )
 
 // Mover is the reconciliation logic for the Restic-based data mover.
@@ -76,17 +85,34 @@ type Mover struct {
 	customCASpec          volsyncv1alpha1.CustomCASpec
 	privileged            bool
 	latestMoverStatus     *volsyncv1alpha1.MoverStatus
+	moverResult           *utils.MoverResult
+	jobFailure            *mover.AttemptFailure
 	moverConfig           volsyncv1alpha1.MoverConfig
 	moverVolumes          []volsyncv1alpha1.MoverVolume
 	// Source-only fields
-	pruneInterval *int32
-	unlock        string
-	retainPolicy  *volsyncv1alpha1.ResticRetainPolicy
-	sourceStatus  *volsyncv1alpha1.ReplicationSourceResticStatus
+	pruneInterval     *int32
+	maintenanceName   string
+	unlock            string
+	check             *volsyncv1alpha1.ReplicationSourceResticCheckSpec
+	checkThisSync     bool
+	includes          []string
+	excludes          []string
+	iexcludes         []string
+	excludeIfPresent  []string
+	secondaries       []volsyncv1alpha1.ResticSecondaryRepository
+	retainPolicy      *volsyncv1alpha1.ResticRetainPolicy
+	sourceStatus      *volsyncv1alpha1.ReplicationSourceResticStatus
+	sourcePVCSelector *metav1.LabelSelector
+	// PVCs restored from the group snapshot, keyed by source PVC name
+	groupPVCs map[string]*corev1.PersistentVolumeClaim
+	// Secrets of the secondary repositories, in the same order as secondaries
+	secondaryRepos []*corev1.Secret
 	// Destination-only fields
 	previous                    *int32
 	restoreAsOf                 *string
+	snapshotID                  string
 	enableFileDeletionOnRestore bool
+	restoreIncludes             []string
 	cleanupTempPVC              bool
 	cleanupCachePVC             bool
 }
@@ -135,6 +161,13 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.InProgress(), err
 	}
 
+	// Validate the Secrets of the secondary repositories
+	if m.isSource {
+		if m.secondaryRepos, err = m.validateSecondaryRepositories(ctx, repo); err != nil {
+			return mover.InProgress(), err
+		}
+	}
+
 	// Validate custom CA if in spec
 	customCAObj, err := utils.ValidateCustomCA(ctx, m.client, m.logger,
 		m.owner.GetNamespace(), m.customCASpec)
@@ -149,9 +182,19 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 		return mover.InProgress(), err
 	}
 
+	// Leave pruning to the ResticMaintenance of the repository, if any
+	if m.isSource {
+		if m.maintenanceName, err = m.repositoryMaintenance(ctx); err != nil {
+			return mover.InProgress(), err
+		}
+	}
+
 	// Start mover Job
 	job, err := m.ensureJob(ctx, cachePVC, dataPVC, sa, repo, customCAObj)
 	if job == nil || err != nil {
+		if err == nil && m.jobFailure != nil {
+			return mover.Failed(*m.jobFailure), nil
+		}
 		return mover.InProgress(), err
 	}
 
@@ -180,7 +223,11 @@ func (m *Mover) Cleanup(ctx context.Context) (mover.Result, error) {
 		}
 	}
 
-	err := utils.CleanupObjects(ctx, m.client, m.logger, m.owner, cleanupTypes)
+	types := cleanupTypes
+	if m.sourcePVCSelector != nil {
+		types = append(slices.Clone(cleanupTypes), &vgsv1beta2.VolumeGroupSnapshot{})
+	}
+	err := utils.CleanupObjects(ctx, m.client, m.logger, m.owner, types)
 	if err != nil {
 		return mover.InProgress(), err
 	}
@@ -201,7 +248,9 @@ func (m *Mover) ensureCache(ctx context.Context,
 	if m.cacheCapacity != nil {
 		cacheCapacity = *m.cacheCapacity
 	}
-	cacheConfig = append(cacheConfig, volumehandler.Capacity(&cacheCapacity))
+	cacheConfig = append(cacheConfig, volumehandler.Capacity(&cacheCapacity), volumehandler.ScaledWithData())
+	// The cache is a filesystem even when the data volume is a block device
+	cacheConfig = append(cacheConfig, volumehandler.VolumeMode(ptr.To(corev1.PersistentVolumeFilesystem)))
 
 	// AccessModes are generated in the following priority:
 	// 1. Directly specified cache accessMode
@@ -268,6 +317,9 @@ func (m *Mover) migrationCleanupObsoleteCachePVC(ctx context.Context) error {
 }
 
 func (m *Mover) ensureSourcePVC(ctx context.Context) (*corev1.PersistentVolumeClaim, error) {
+	if m.sourcePVCSelector != nil {
+		return m.ensureGroupSourcePVCs(ctx)
+	}
 	srcPVC := &corev1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      *m.mainPVCName,
@@ -293,6 +345,19 @@ func (m *Mover) ensureSourcePVC(ctx context.Context) (*corev1.PersistentVolumeCl
 	return pvc, err
 }
 
+// ensureGroupSourcePVCs ensures the PVCs restored from a group snapshot of the
+// selected source PVCs. The returned PVC is the first of the group and stands
+// in for the data PVC where only one is needed.
+func (m *Mover) ensureGroupSourcePVCs(ctx context.Context) (*corev1.PersistentVolumeClaim, error) {
+	dataName := mover.VolSyncPrefix + m.owner.GetName() + "-src"
+	pvcs, err := m.vh.EnsurePVCsFromGroupSnapshot(ctx, m.logger, m.sourcePVCSelector, dataName, true)
+	if len(pvcs) == 0 || err != nil {
+		return nil, err
+	}
+	m.groupPVCs = pvcs
+	return pvcs[slices.Sorted(maps.Keys(pvcs))[0]], nil
+}
+
 func (m *Mover) ensureDestinationPVC(ctx context.Context) (*corev1.PersistentVolumeClaim, error) {
 	isProvidedPVC, dataPVCName := m.getDestinationPVCName()
 	if isProvidedPVC {
@@ -311,9 +376,57 @@ func (m *Mover) getDestinationPVCName() (bool, string) {
 }
 
 func (m *Mover) validateRepository(ctx context.Context) (*corev1.Secret, error) {
+	return m.validateRepositorySecret(ctx, m.repositoryName)
+}
+
+func (m *Mover) validateSecondaryRepositories(ctx context.Context,
+	primary *corev1.Secret) ([]*corev1.Secret, error) {
+	secrets := make([]*corev1.Secret, 0, len(m.secondaries))
+	for _, secondary := range m.secondaries {
+		secret, err := m.validateRepositorySecret(ctx, secondary.Repository)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateSecondaryCredentials(primary, secret); err != nil {
+			m.logger.Error(err, "Secondary repository can't be copied to")
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// Keys of the repository Secrets that restic reads separately for the primary
+// and a secondary repository during a copy
+var perRepositoryKeys = []string{
+	"RESTIC_REPOSITORY",
+	"RESTIC_PASSWORD",
+	"RESTIC_COMPRESSION",
+	"RESTIC_PACK_SIZE",
+	"RESTIC_READ_CONCURRENCY",
+}
+
+// validateSecondaryCredentials makes sure that a secondary repository doesn't
+// use different backend credentials than the primary. restic copy reads both
+// repositories with the same environment, so the primary would otherwise be
+// read with the credentials of the secondary.
+func validateSecondaryCredentials(primary, secondary *corev1.Secret) error {
+	for _, key := range slices.Sorted(maps.Keys(secondary.Data)) {
+		if slices.Contains(perRepositoryKeys, key) {
+			continue
+		}
+		if primaryValue, ok := primary.Data[key]; ok && !bytes.Equal(primaryValue, secondary.Data[key]) {
+			return fmt.Errorf("secondary repository %s sets %s to a different value than the primary repository %s",
+				secondary.Name, key, primary.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Mover) validateRepositorySecret(ctx context.Context, name string) (*corev1.Secret, error) {
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      m.repositoryName,
+			Name:      name,
 			Namespace: m.owner.GetNamespace(),
 		},
 	}
@@ -352,22 +465,23 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 		utils.MarkForCleanup(m.owner, job)
 		job.Spec.Template.Name = job.Name
 		utils.SetOwnedByVolSync(&job.Spec.Template)
-		backoffLimit := int32(8)
-		job.Spec.BackoffLimit = &backoffLimit
+		job.Spec.BackoffLimit = mover.JobBackoffLimit(m.owner, 8)
 		parallelism := int32(1)
 		if m.paused {
 			parallelism = int32(0)
 		}
 		job.Spec.Parallelism = &parallelism
-		forgetOptions := generateForgetOptions(m.retainPolicy)
+		forgetOptions := GenerateForgetOptions(m.retainPolicy)
 		// set default values
 		var restoreAsOf = ""
+		var snapshotID = ""
 		var previous = strconv.Itoa(int(int32(0)))
 		var restoreOptions = ""
 
 		readOnlyVolume := false
 		var actions []string
 		if m.isSource {
+			m.checkThisSync = m.shouldCheckJob(job, time.Now())
 			actions = []string{"backup"}
 
 			if m.shouldUnlock() {
@@ -379,6 +493,14 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 				actions = append(actions, "prune")
 			}
 
+			if len(m.secondaryRepos) > 0 {
+				actions = append(actions, "copy")
+			}
+
+			if m.checkThisSync {
+				actions = append(actions, "check")
+			}
+
 			// Set read-only for volume in source mover job spec if the PVC only supports read-only
 			readOnlyVolume = utils.PvcIsReadOnly(dataPVC)
 		} else {
@@ -390,6 +512,7 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 			if m.previous != nil {
 				previous = strconv.Itoa(int(*m.previous))
 			}
+			snapshotID = m.snapshotID
 
 			// Delete option for restores, default is false (mover.enableFileDeletionOnRestore is only set in the builder
 			// for replicationdestinations)
@@ -406,70 +529,35 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 			{Name: "RESTIC_CACHE_DIR", Value: resticCacheMountPath},
 			{Name: "RESTORE_AS_OF", Value: restoreAsOf},
 			{Name: "SELECT_PREVIOUS", Value: previous},
+			{Name: "RESTORE_SNAPSHOT_ID", Value: snapshotID},
 			{Name: "RESTORE_OPTIONS", Value: restoreOptions},
-			// We populate environment variables from the restic repo
-			// Secret. They are taken 1-for-1 from the Secret into env vars.
-			// The allowed variables are defined by restic.
-			// https://restic.readthedocs.io/en/stable/040_backup.html#environment-variables
-			// Mandatory variables are needed to define the repository
-			// location and its password.
-			utils.EnvFromSecret(repo.Name, "RESTIC_REPOSITORY", false),
-			utils.EnvFromSecret(repo.Name, "RESTIC_PASSWORD", false),
-
-			// Optional variables
-			utils.EnvFromSecret(repo.Name, "RESTIC_COMPRESSION", true), // New in v0.14.0
-			utils.EnvFromSecret(repo.Name, "RESTIC_PACK_SIZE", true),   // New in v0.14.0
-
-			utils.EnvFromSecret(repo.Name, "RESTIC_READ_CONCURRENCY", true), // New in v0.15.0
-
-			// Optional variables based on what backend is used for restic
-			utils.EnvFromSecret(repo.Name, "AWS_ACCESS_KEY_ID", true),
-			utils.EnvFromSecret(repo.Name, "AWS_SECRET_ACCESS_KEY", true),
-			utils.EnvFromSecret(repo.Name, "AWS_SESSION_TOKEN", true), // New in v0.14.0
-			utils.EnvFromSecret(repo.Name, "AWS_DEFAULT_REGION", true),
-			utils.EnvFromSecret(repo.Name, "AWS_PROFILE", true),
-			// AWS_SHARED_CREDENTIALS_FILE <- not implementing
-			utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_ARN", true),          // New in v0.17.0
-			utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_SESSION_NAME", true), // New in v0.17.0
-			utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_EXTERNAL_ID", true),  // New in v0.17.0
-			utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_POLICY", true),       // New in v0.17.0
-			utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_REGION", true),       // New in v0.17.0
-			utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_STS_ENDPOINT", true), // New in v0.17.0
-			utils.EnvFromSecret(repo.Name, "ST_AUTH", true),
-			utils.EnvFromSecret(repo.Name, "ST_USER", true),
-			utils.EnvFromSecret(repo.Name, "ST_KEY", true),
-			utils.EnvFromSecret(repo.Name, "OS_AUTH_URL", true),
-			utils.EnvFromSecret(repo.Name, "OS_REGION_NAME", true),
-			utils.EnvFromSecret(repo.Name, "OS_USERNAME", true),
-			utils.EnvFromSecret(repo.Name, "OS_USER_ID", true),
-			utils.EnvFromSecret(repo.Name, "OS_PASSWORD", true),
-			utils.EnvFromSecret(repo.Name, "OS_TENANT_ID", true),
-			utils.EnvFromSecret(repo.Name, "OS_TENANT_NAME", true),
-			utils.EnvFromSecret(repo.Name, "OS_USER_DOMAIN_NAME", true),
-			utils.EnvFromSecret(repo.Name, "OS_USER_DOMAIN_ID", true),
-			utils.EnvFromSecret(repo.Name, "OS_PROJECT_NAME", true),
-			utils.EnvFromSecret(repo.Name, "OS_PROJECT_DOMAIN_NAME", true),
-			utils.EnvFromSecret(repo.Name, "OS_PROJECT_DOMAIN_ID", true),
-			utils.EnvFromSecret(repo.Name, "OS_TRUST_ID", true),
-			utils.EnvFromSecret(repo.Name, "OS_APPLICATION_CREDENTIAL_ID", true),
-			utils.EnvFromSecret(repo.Name, "OS_APPLICATION_CREDENTIAL_NAME", true),
-			utils.EnvFromSecret(repo.Name, "OS_APPLICATION_CREDENTIAL_SECRET", true),
-			utils.EnvFromSecret(repo.Name, "OS_STORAGE_URL", true),
-			utils.EnvFromSecret(repo.Name, "OS_AUTH_TOKEN", true),
-			utils.EnvFromSecret(repo.Name, "B2_ACCOUNT_ID", true),
-			utils.EnvFromSecret(repo.Name, "B2_ACCOUNT_KEY", true),
-			utils.EnvFromSecret(repo.Name, "AZURE_ACCOUNT_NAME", true),
-			utils.EnvFromSecret(repo.Name, "AZURE_ACCOUNT_KEY", true),
-			utils.EnvFromSecret(repo.Name, "AZURE_ACCOUNT_SAS", true),     // New in v0.14.0
-			utils.EnvFromSecret(repo.Name, "AZURE_ENDPOINT_SUFFIX", true), // New in v0.16.0
-			// AZURE_FORCE_CLI_CREDENTIAL <- not implementing, requires azure cli or local credentials stored from cli?
-			utils.EnvFromSecret(repo.Name, "GOOGLE_PROJECT_ID", true),
-			utils.EnvFromSecret(repo.Name, "RESTIC_REST_USERNAME", true), // New in v0.16.1
-			utils.EnvFromSecret(repo.Name, "RESTIC_REST_PASSWORD", true), // New in v0.16.1
-		}
-
-		// Rclone env vars for restic if they are in the secret
-		envVars = utils.AppendRCloneEnvVars(repo, envVars)
+		}
+		envVars = append(envVars, RepositoryEnvVars(repo)...)
+		envVars = append(envVars, m.filterEnvVars()...)
+		if m.isSource {
+			envVars = append(envVars, m.secondaryEnvVars()...)
+		}
+
+		if m.isSource && m.checkThisSync {
+			// A failed check is reported in the status rather than failing
+			// the backup
+			envVars = append(envVars, corev1.EnvVar{Name: envCheckFailureFatal, Value: "0"})
+			if m.check.ReadDataSubset != nil {
+				envVars = append(envVars, corev1.EnvVar{Name: "CHECK_READ_DATA_SUBSET",
+					Value: *m.check.ReadDataSubset})
+			}
+		}
+
+		// Bandwidth limits
+		limits := utils.SyncTransferLimits(m.owner, m.moverConfig.TransferPolicy)
+		if limits.Upload > 0 {
+			envVars = append(envVars, corev1.EnvVar{Name: "RESTIC_LIMIT_UPLOAD",
+				Value: strconv.FormatInt(limits.UploadKiB(), 10)})
+		}
+		if limits.Download > 0 {
+			envVars = append(envVars, corev1.EnvVar{Name: "RESTIC_LIMIT_DOWNLOAD",
+				Value: strconv.FormatInt(limits.DownloadKiB(), 10)})
+		}
 
 		// Cluster-wide proxy settings
 		envVars = utils.AppendEnvVarsForClusterWideProxy(envVars)
@@ -477,6 +565,19 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 		// Run mover in debug mode if required
 		envVars = utils.AppendDebugMoverEnvVar(m.owner, envVars)
 
+		volumeMounts := []corev1.VolumeMount{}
+		var volumeDevices []corev1.VolumeDevice
+		if utils.PvcIsBlockMode(dataPVC) {
+			// The device is backed up as a single file
+			envVars = append(envVars, corev1.EnvVar{Name: "BLOCK_DEVICE", Value: devicePath})
+			volumeDevices = []corev1.VolumeDevice{{Name: dataVolumeName, DevicePath: devicePath}}
+		} else {
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{Name: dataVolumeName, MountPath: mountPath})
+		}
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{Name: resticCache, MountPath: resticCacheMountPath},
+			corev1.VolumeMount{Name: "tempdir", MountPath: "/tmp"})
+
 		podSpec.Containers = []corev1.Container{{
 			Name:    "restic",
 			Env:     envVars,
@@ -491,11 +592,8 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 				Privileged:             ptr.To(false),
 				ReadOnlyRootFilesystem: ptr.To(true),
 			},
-			VolumeMounts: []corev1.VolumeMount{
-				{Name: dataVolumeName, MountPath: mountPath},
-				{Name: resticCache, MountPath: resticCacheMountPath},
-				{Name: "tempdir", MountPath: "/tmp"},
-			},
+			VolumeMounts:  volumeMounts,
+			VolumeDevices: volumeDevices,
 		}}
 		podSpec.RestartPolicy = corev1.RestartPolicyNever
 		podSpec.ServiceAccountName = sa.Name
@@ -517,6 +615,9 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 				}},
 			},
 		}
+		if len(m.groupPVCs) > 0 {
+			utils.MountGroupDataVolumes(podSpec, dataVolumeName, m.groupPVCs)
+		}
 		if m.vh.IsCopyMethodDirect() {
 			affinity, err := utils.AffinityFromVolume(ctx, m.client, logger, dataPVC)
 			if err != nil {
@@ -526,54 +627,7 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 			podSpec.NodeSelector = affinity.NodeSelector
 			podSpec.Tolerations = affinity.Tolerations
 		}
-		if customCAObj != nil {
-			// Tell mover where to find the cert
-			podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
-				Name:  "CUSTOM_CA",
-				Value: path.Join(resticCAMountPath, resticCAFilename),
-			})
-			// Mount the custom CA certificate
-			podSpec.Containers[0].VolumeMounts =
-				append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
-					Name:      "custom-ca",
-					MountPath: resticCAMountPath,
-				})
-			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
-				Name:         "custom-ca",
-				VolumeSource: customCAObj.GetVolumeSource(resticCAFilename),
-			})
-		}
-		// We handle GOOGLE_APPLICATION_CREDENTIALS specially...
-		// restic expects it to be an env var pointing to a file w/ the
-		// credentials, but we have users provide the actual file data in the
-		// Secret under that key name. The following code sets the env var to be
-		// what restic expects, then mounts just that Secret key into the
-		// container, pointed to by the env var.
-		if _, ok := repo.Data["GOOGLE_APPLICATION_CREDENTIALS"]; ok {
-			container := &podSpec.Containers[0]
-			// Tell restic where to look for the credential file
-			container.Env = append(container.Env, corev1.EnvVar{
-				Name:  "GOOGLE_APPLICATION_CREDENTIALS",
-				Value: path.Join(credentialDir, gcsCredentialFile),
-			})
-			// Mount the credential file
-			container.VolumeMounts =
-				append(container.VolumeMounts, corev1.VolumeMount{
-					Name:      "gcs-credentials",
-					MountPath: credentialDir,
-				})
-			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
-				Name: "gcs-credentials",
-				VolumeSource: corev1.VolumeSource{
-					Secret: &corev1.SecretVolumeSource{
-						SecretName: repo.Name,
-						Items: []corev1.KeyToPath{
-							{Key: "GOOGLE_APPLICATION_CREDENTIALS", Path: gcsCredentialFile},
-						},
-					},
-				},
-			})
-		}
+		MountRepositoryCredentials(podSpec, repo, customCAObj)
 
 		// Update the job securityContext, podLabels and resourceRequirements from moverConfig (if specified)
 		utils.UpdatePodTemplateSpecFromMoverConfig(&job.Spec.Template, m.moverConfig, corev1.ResourceRequirements{})
@@ -604,12 +658,13 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 		return nil
 	})
 	// If Job had failed, delete it so it can be recreated
-	if job.Status.Failed >= *job.Spec.BackoffLimit {
+	if mover.JobFailed(job) {
 		// Update status with mover logs from failed job
-		utils.UpdateMoverStatusForFailedJob(ctx, m.logger, m.latestMoverStatus, job.GetName(), job.GetNamespace(),
-			utils.AllLines)
+		m.moverResult = utils.UpdateMoverStatusForFailedJob(ctx, m.logger, m.latestMoverStatus,
+			job.GetName(), job.GetNamespace(), utils.AllLines)
 
 		logger.Info("deleting job -- backoff limit reached")
+		m.jobFailure = ptr.To(mover.JobFailure(m.latestMoverStatus))
 		err = m.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
 		return nil, err
 	}
@@ -620,6 +675,7 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 
 	// Stop here if the job hasn't completed yet
 	if job.Status.Succeeded == 0 {
+		utils.UpdateSyncProgress(ctx, m.logger, m.owner, job.GetName(), job.GetNamespace(), ProgressFilter)
 		return nil, nil
 	}
 
@@ -643,14 +699,61 @@ func (m *Mover) ensureJob(ctx context.Context, cachePVC *corev1.PersistentVolume
 	}
 
 	// update status with mover logs from successful job
-	utils.UpdateMoverStatusForSuccessfulJob(ctx, m.logger, m.latestMoverStatus, job.GetName(), job.GetNamespace(),
-		LogLineFilterSuccess)
+	m.moverResult = utils.UpdateMoverStatusForSuccessfulJob(ctx, m.logger, m.latestMoverStatus,
+		job.GetName(), job.GetNamespace(), LogLineFilterSuccess)
+
+	if m.isSource {
+		m.recordCopyResults(logger)
+	}
+
+	if m.isSource && m.checkThisSync {
+		m.recordCheckResult(logger)
+	}
 
 	// We only continue reconciling if the restic job has completed
 	return job, nil
 }
 
+// filterEnvVars returns the environment variables that pass the include and
+// exclude patterns to the mover, one per line
+func (m *Mover) filterEnvVars() []corev1.EnvVar {
+	lists := map[string][]string{}
+	if m.isSource {
+		lists["BACKUP_INCLUDES"] = m.includes
+		lists["BACKUP_EXCLUDES"] = anchorPatterns(m.excludes)
+		lists["BACKUP_IEXCLUDES"] = anchorPatterns(m.iexcludes)
+		lists["BACKUP_EXCLUDE_IF_PRESENT"] = m.excludeIfPresent
+	} else {
+		lists["RESTORE_INCLUDES"] = m.restoreIncludes
+	}
+	envVars := []corev1.EnvVar{}
+	for _, name := range slices.Sorted(maps.Keys(lists)) {
+		if len(lists[name]) > 0 {
+			envVars = append(envVars, corev1.EnvVar{Name: name, Value: strings.Join(lists[name], "\n")})
+		}
+	}
+	return envVars
+}
+
+// anchorPatterns prefixes the backup exclude patterns that start with a "/"
+// with the mount path of the data volume, since restic matches them against
+// the absolute paths of the files
+func anchorPatterns(patterns []string) []string {
+	anchored := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		if strings.HasPrefix(p, "/") {
+			p = mountPath + p
+		}
+		anchored = append(anchored, p)
+	}
+	return anchored
+}
+
 func (m *Mover) shouldPrune(current time.Time) bool {
+	if m.maintenanceName != "" {
+		// The repository is pruned by its ResticMaintenance
+		return false
+	}
 	delta := time.Hour * 24 * 7 // default prune every 7 days
 	if m.pruneInterval != nil {
 		delta = time.Hour * 24 * time.Duration(*m.pruneInterval)
@@ -663,6 +766,173 @@ func (m *Mover) shouldPrune(current time.Time) bool {
 	return current.After(lastPruned.Add(delta))
 }
 
+func (m *Mover) shouldCheck(current time.Time) bool {
+	if m.check == nil {
+		return false
+	}
+	delta := time.Hour * 24 * 7 // default check every 7 days
+	if m.check.IntervalDays != nil {
+		delta = time.Hour * 24 * time.Duration(*m.check.IntervalDays)
+	}
+	// If we've never checked, the 1st one should be "delta" after creation.
+	lastChecked := m.owner.GetCreationTimestamp().Time
+	if !m.sourceStatus.LastChecked.IsZero() {
+		lastChecked = m.sourceStatus.LastChecked.Time
+	}
+	return current.After(lastChecked.Add(delta))
+}
+
+// shouldCheckJob determines whether the mover job should check the
+// repository. Once the job exists the decision is kept, so that the job isn't
+// recreated when the check interval passes while it is running.
+func (m *Mover) shouldCheckJob(job *batchv1.Job, current time.Time) bool {
+	if m.check == nil {
+		return false
+	}
+	if job.CreationTimestamp.IsZero() {
+		return m.shouldCheck(current)
+	}
+	for _, c := range job.Spec.Template.Spec.Containers {
+		for _, env := range c.Env {
+			if env.Name == envCheckFailureFatal {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// recordCheckResult updates the status and metrics with the result of the
+// repository check, as reported by the mover. If the mover didn't report it,
+// the check is run again on the next sync.
+func (m *Mover) recordCheckResult(logger logr.Logger) {
+	result := m.resultDetails(logger).Check
+	if result == "" {
+		logger.Info("unable to find the result of the repository check in the mover result")
+		return
+	}
+	now := metav1.Now()
+	m.sourceStatus.LastChecked = &now
+	m.sourceStatus.LastCheckResult = result
+	logger.Info("check completed", ".Status.Restic.LastCheckResult", result)
+
+	labels := prometheus.Labels{
+		"obj_name":      m.owner.GetName(),
+		"obj_namespace": m.owner.GetNamespace(),
+		"repository":    m.repositoryName,
+	}
+	lastCheckTimestamp.With(labels).Set(float64(now.Unix()))
+	if result == volsyncv1alpha1.ResticCheckFailed {
+		checkFailed.With(labels).Set(1)
+		m.eventRecorder.Eventf(m.owner, nil, corev1.EventTypeWarning,
+			volsyncv1alpha1.EvRRepositoryCheckFailed, volsyncv1alpha1.EvANone,
+			"integrity check of restic repository %s failed", m.repositoryName)
+	} else {
+		checkFailed.With(labels).Set(0)
+	}
+}
+
+// resticResultDetails are the restic specific results that the mover writes
+// in the details of its utils.MoverResult
+type resticResultDetails struct {
+	// The result of the repository check
+	Check volsyncv1alpha1.ResticCheckResult `json:"check,omitempty"`
+	// Whether the copy to each secondary repository succeeded, keyed by the
+	// name of the repository's Secret
+	Copies map[string]bool `json:"copies,omitempty"`
+}
+
+// resultDetails returns the restic specific results of the last mover job.
+// They are empty if the mover didn't report any.
+func (m *Mover) resultDetails(logger logr.Logger) *resticResultDetails {
+	details := &resticResultDetails{}
+	if err := m.moverResult.DecodeDetails(details); err != nil {
+		logger.Error(err, "unable to read the result of the mover")
+	}
+	return details
+}
+
+// secondaryEnvVars returns the environment variables of the secondary
+// repositories. The variables of each repository have the same names as the
+// primary repository's, prefixed by SECONDARY_<index>_.
+func (m *Mover) secondaryEnvVars() []corev1.EnvVar {
+	if len(m.secondaryRepos) == 0 {
+		return nil
+	}
+	envVars := []corev1.EnvVar{
+		{Name: "SECONDARY_REPOSITORY_COUNT", Value: strconv.Itoa(len(m.secondaryRepos))},
+	}
+	if m.shouldPrune(time.Now()) {
+		envVars = append(envVars, corev1.EnvVar{Name: "SECONDARY_PRUNE", Value: "1"})
+	}
+	for i, repo := range m.secondaryRepos {
+		prefix := fmt.Sprintf("SECONDARY_%d_", i)
+		envVars = append(envVars, corev1.EnvVar{Name: prefix + "NAME", Value: repo.Name})
+		if forgetOptions := GenerateForgetOptions(m.secondaries[i].Retain); forgetOptions != "" {
+			envVars = append(envVars, corev1.EnvVar{Name: prefix + "FORGET_OPTIONS", Value: forgetOptions})
+		}
+		for _, env := range RepositoryEnvVars(repo) {
+			env.Name = prefix + env.Name
+			envVars = append(envVars, env)
+		}
+	}
+	return envVars
+}
+
+// recordCopyResults updates the status of the secondary repositories with the
+// results of the copies, as reported by the mover
+func (m *Mover) recordCopyResults(logger logr.Logger) {
+	if len(m.secondaryRepos) == 0 {
+		m.sourceStatus.SecondaryRepositories = nil
+		return
+	}
+	results := m.resultDetails(logger).Copies
+	previous := map[string]volsyncv1alpha1.ResticSecondaryRepositoryStatus{}
+	for _, s := range m.sourceStatus.SecondaryRepositories {
+		previous[s.Repository] = s
+	}
+	now := metav1.Now()
+	statuses := make([]volsyncv1alpha1.ResticSecondaryRepositoryStatus, 0, len(m.secondaryRepos))
+	for _, repo := range m.secondaryRepos {
+		status := previous[repo.Name]
+		status.Repository = repo.Name
+		succeeded, found := results[repo.Name]
+		switch {
+		case !found:
+			logger.Info("unable to find the result of the copy in the mover result",
+				"secondaryRepository", repo.Name)
+		case succeeded:
+			status.LastCopied = &now
+			status.ConsecutiveFailures = 0
+			logger.Info("copy to secondary repository completed", "secondaryRepository", repo.Name)
+		default:
+			status.LastFailure = &now
+			status.ConsecutiveFailures++
+			logger.Info("copy to secondary repository failed", "secondaryRepository", repo.Name)
+			m.eventRecorder.Eventf(m.owner, nil, corev1.EventTypeWarning,
+				volsyncv1alpha1.EvRSecondaryCopyFailed, volsyncv1alpha1.EvANone,
+				"unable to copy snapshots to secondary restic repository %s", repo.Name)
+		}
+		statuses = append(statuses, status)
+	}
+	m.sourceStatus.SecondaryRepositories = statuses
+}
+
+// repositoryMaintenance returns the name of the ResticMaintenance in the
+// owner's namespace that maintains the repository, or "" if there is none
+func (m *Mover) repositoryMaintenance(ctx context.Context) (string, error) {
+	maintenances := &volsyncv1alpha1.ResticMaintenanceList{}
+	if err := m.client.List(ctx, maintenances, client.InNamespace(m.owner.GetNamespace())); err != nil {
+		return "", err
+	}
+	for _, rm := range maintenances.Items {
+		if rm.Spec.Repository == m.repositoryName && rm.DeletionTimestamp.IsZero() {
+			return rm.Name, nil
+		}
+	}
+	return "", nil
+}
+
 func (m *Mover) shouldUnlock() bool {
 	if m.unlock != "" && m.sourceStatus.LastUnlocked != m.unlock {
 		return true
@@ -670,7 +940,132 @@ func (m *Mover) shouldUnlock() bool {
 	return false
 }
 
-func generateForgetOptions(policy *volsyncv1alpha1.ResticRetainPolicy) string {
+// RepositoryEnvVars returns the environment variables of the restic
+// container that are populated from the repository Secret
+func RepositoryEnvVars(repo *corev1.Secret) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{
+		// We populate environment variables from the restic repo
+		// Secret. They are taken 1-for-1 from the Secret into env vars.
+		// The allowed variables are defined by restic.
+		// https://restic.readthedocs.io/en/stable/040_backup.html#environment-variables
+		// Mandatory variables are needed to define the repository
+		// location and its password.
+		utils.EnvFromSecret(repo.Name, "RESTIC_REPOSITORY", false),
+		utils.EnvFromSecret(repo.Name, "RESTIC_PASSWORD", false),
+
+		// Optional variables
+		utils.EnvFromSecret(repo.Name, "RESTIC_COMPRESSION", true), // New in v0.14.0
+		utils.EnvFromSecret(repo.Name, "RESTIC_PACK_SIZE", true),   // New in v0.14.0
+
+		utils.EnvFromSecret(repo.Name, "RESTIC_READ_CONCURRENCY", true), // New in v0.15.0
+
+		// Optional variables based on what backend is used for restic
+		utils.EnvFromSecret(repo.Name, "AWS_ACCESS_KEY_ID", true),
+		utils.EnvFromSecret(repo.Name, "AWS_SECRET_ACCESS_KEY", true),
+		utils.EnvFromSecret(repo.Name, "AWS_SESSION_TOKEN", true), // New in v0.14.0
+		utils.EnvFromSecret(repo.Name, "AWS_DEFAULT_REGION", true),
+		utils.EnvFromSecret(repo.Name, "AWS_PROFILE", true),
+		// AWS_SHARED_CREDENTIALS_FILE <- not implementing
+		utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_ARN", true),          // New in v0.17.0
+		utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_SESSION_NAME", true), // New in v0.17.0
+		utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_EXTERNAL_ID", true),  // New in v0.17.0
+		utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_POLICY", true),       // New in v0.17.0
+		utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_REGION", true),       // New in v0.17.0
+		utils.EnvFromSecret(repo.Name, "RESTIC_AWS_ASSUME_ROLE_STS_ENDPOINT", true), // New in v0.17.0
+		utils.EnvFromSecret(repo.Name, "ST_AUTH", true),
+		utils.EnvFromSecret(repo.Name, "ST_USER", true),
+		utils.EnvFromSecret(repo.Name, "ST_KEY", true),
+		utils.EnvFromSecret(repo.Name, "OS_AUTH_URL", true),
+		utils.EnvFromSecret(repo.Name, "OS_REGION_NAME", true),
+		utils.EnvFromSecret(repo.Name, "OS_USERNAME", true),
+		utils.EnvFromSecret(repo.Name, "OS_USER_ID", true),
+		utils.EnvFromSecret(repo.Name, "OS_PASSWORD", true),
+		utils.EnvFromSecret(repo.Name, "OS_TENANT_ID", true),
+		utils.EnvFromSecret(repo.Name, "OS_TENANT_NAME", true),
+		utils.EnvFromSecret(repo.Name, "OS_USER_DOMAIN_NAME", true),
+		utils.EnvFromSecret(repo.Name, "OS_USER_DOMAIN_ID", true),
+		utils.EnvFromSecret(repo.Name, "OS_PROJECT_NAME", true),
+		utils.EnvFromSecret(repo.Name, "OS_PROJECT_DOMAIN_NAME", true),
+		utils.EnvFromSecret(repo.Name, "OS_PROJECT_DOMAIN_ID", true),
+		utils.EnvFromSecret(repo.Name, "OS_TRUST_ID", true),
+		utils.EnvFromSecret(repo.Name, "OS_APPLICATION_CREDENTIAL_ID", true),
+		utils.EnvFromSecret(repo.Name, "OS_APPLICATION_CREDENTIAL_NAME", true),
+		utils.EnvFromSecret(repo.Name, "OS_APPLICATION_CREDENTIAL_SECRET", true),
+		utils.EnvFromSecret(repo.Name, "OS_STORAGE_URL", true),
+		utils.EnvFromSecret(repo.Name, "OS_AUTH_TOKEN", true),
+		utils.EnvFromSecret(repo.Name, "B2_ACCOUNT_ID", true),
+		utils.EnvFromSecret(repo.Name, "B2_ACCOUNT_KEY", true),
+		utils.EnvFromSecret(repo.Name, "AZURE_ACCOUNT_NAME", true),
+		utils.EnvFromSecret(repo.Name, "AZURE_ACCOUNT_KEY", true),
+		utils.EnvFromSecret(repo.Name, "AZURE_ACCOUNT_SAS", true),     // New in v0.14.0
+		utils.EnvFromSecret(repo.Name, "AZURE_ENDPOINT_SUFFIX", true), // New in v0.16.0
+		// AZURE_FORCE_CLI_CREDENTIAL <- not implementing, requires azure cli or local credentials stored from cli?
+		utils.EnvFromSecret(repo.Name, "GOOGLE_PROJECT_ID", true),
+		utils.EnvFromSecret(repo.Name, "RESTIC_REST_USERNAME", true), // New in v0.16.1
+		utils.EnvFromSecret(repo.Name, "RESTIC_REST_PASSWORD", true), // New in v0.16.1
+	}
+
+	// Rclone env vars for restic if they are in the secret
+	return utils.AppendRCloneEnvVars(repo, envVars)
+}
+
+// MountRepositoryCredentials mounts the custom CA and the credential files of
+// the repository Secret into the restic container of podSpec
+func MountRepositoryCredentials(podSpec *corev1.PodSpec, repo *corev1.Secret,
+	customCAObj utils.CustomCAObject) {
+	if customCAObj != nil {
+		// Tell mover where to find the cert
+		podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, corev1.EnvVar{
+			Name:  "CUSTOM_CA",
+			Value: path.Join(resticCAMountPath, resticCAFilename),
+		})
+		// Mount the custom CA certificate
+		podSpec.Containers[0].VolumeMounts =
+			append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      "custom-ca",
+				MountPath: resticCAMountPath,
+			})
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name:         "custom-ca",
+			VolumeSource: customCAObj.GetVolumeSource(resticCAFilename),
+		})
+	}
+	// We handle GOOGLE_APPLICATION_CREDENTIALS specially...
+	// restic expects it to be an env var pointing to a file w/ the
+	// credentials, but we have users provide the actual file data in the
+	// Secret under that key name. The following code sets the env var to be
+	// what restic expects, then mounts just that Secret key into the
+	// container, pointed to by the env var.
+	if _, ok := repo.Data["GOOGLE_APPLICATION_CREDENTIALS"]; ok {
+		container := &podSpec.Containers[0]
+		// Tell restic where to look for the credential file
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+			Value: path.Join(credentialDir, gcsCredentialFile),
+		})
+		// Mount the credential file
+		container.VolumeMounts =
+			append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      "gcs-credentials",
+				MountPath: credentialDir,
+			})
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: "gcs-credentials",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: repo.Name,
+					Items: []corev1.KeyToPath{
+						{Key: "GOOGLE_APPLICATION_CREDENTIALS", Path: gcsCredentialFile},
+					},
+				},
+			},
+		})
+	}
+}
+
+// GenerateForgetOptions returns the options of `restic forget` for the
+// retention policy
+func GenerateForgetOptions(policy *volsyncv1alpha1.ResticRetainPolicy) string {
 	const defaultForget = "--keep-last 1"
 
 	if policy == nil { // Retain policy isn't present
@@ -21,6 +21,7 @@ package restic
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"os"
 	"path"
@@ -58,14 +59,14 @@ var _ = Describe("Restic retain policy", func() {
 	Context("When a retain policy is omitted", func() {
 		It("has forget options that keep only the last backup", func() {
 			var policy *volsyncv1alpha1.ResticRetainPolicy = nil
-			forget := generateForgetOptions(policy)
+			forget := GenerateForgetOptions(policy)
 			Expect(forget).To(MatchRegexp("^\\s*--keep-last\\s+1\\s*$"))
 		})
 	})
 	Context("When a retain policy is empty", func() {
 		It("has forget options that keep only the last backup", func() {
 			policy := &volsyncv1alpha1.ResticRetainPolicy{}
-			forget := generateForgetOptions(policy)
+			forget := GenerateForgetOptions(policy)
 			Expect(forget).To(MatchRegexp("^\\s*--keep-last\\s+1\\s*$"))
 		})
 	})
@@ -83,7 +84,7 @@ var _ = Describe("Restic retain policy", func() {
 				Monthly: &two,
 				Yearly:  &one,
 			}
-			forget := generateForgetOptions(policy)
+			forget := GenerateForgetOptions(policy)
 			Expect(forget).NotTo(MatchRegexp("--keep-last"))
 			Expect(forget).NotTo(MatchRegexp("--within"))
 			Expect(forget).To(MatchRegexp("(^|\\s)--keep-hourly\\s+5(\\s|$)"))
@@ -97,7 +98,7 @@ var _ = Describe("Restic retain policy", func() {
 			policy := &volsyncv1alpha1.ResticRetainPolicy{
 				Within: &duration,
 			}
-			forget := generateForgetOptions(policy)
+			forget := GenerateForgetOptions(policy)
 			Expect(forget).To(MatchRegexp("^\\s*--keep-within\\s+5m3w1d\\s*$"))
 		})
 	})
@@ -232,6 +233,214 @@ var _ = Describe("Restic prune policy", func() {
 	})
 })
 
+var _ = Describe("Restic checks repositories periodically", func() {
+	var m *Mover
+	var start metav1.Time
+	const day = 24 * time.Hour
+
+	BeforeEach(func() {
+		start = metav1.Now()
+		m = &Mover{
+			owner: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "name",
+					Namespace:         "ns",
+					CreationTimestamp: start,
+				},
+			},
+			sourceStatus: &volsyncv1alpha1.ReplicationSourceResticStatus{},
+		}
+	})
+	It("doesn't check if check is omitted", func() {
+		Expect(m.shouldCheck(start.Add(365 * day))).To(BeFalse())
+	})
+	It("defaults to checking every week", func() {
+		m.check = &volsyncv1alpha1.ReplicationSourceResticCheckSpec{}
+		Expect(m.shouldCheck(start.Add(time.Minute))).To(BeFalse())
+		Expect(m.shouldCheck(start.Add(7*day + time.Minute))).To(BeTrue())
+	})
+	It("uses the interval and the last checked time", func() {
+		m.check = &volsyncv1alpha1.ReplicationSourceResticCheckSpec{IntervalDays: ptr.To[int32](2)}
+		lastChecked := start.Add(5 * day)
+		m.sourceStatus.LastChecked = &metav1.Time{Time: lastChecked}
+		Expect(m.shouldCheck(start.Add(3 * day))).To(BeFalse())
+		Expect(m.shouldCheck(lastChecked.Add(day))).To(BeFalse())
+		Expect(m.shouldCheck(lastChecked.Add(2*day + time.Minute))).To(BeTrue())
+	})
+	It("keeps the decision of an existing job", func() {
+		m.check = &volsyncv1alpha1.ReplicationSourceResticCheckSpec{}
+		job := &batchv1.Job{}
+		Expect(m.shouldCheckJob(job, start.Add(7*day+time.Minute))).To(BeTrue())
+
+		job.CreationTimestamp = start
+		job.Spec.Template.Spec.Containers = []corev1.Container{{Name: "restic"}}
+		Expect(m.shouldCheckJob(job, start.Add(7*day+time.Minute))).To(BeFalse())
+		job.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: envCheckFailureFatal, Value: "0"}}
+		Expect(m.shouldCheckJob(job, start.Add(time.Minute))).To(BeTrue())
+	})
+	It("reads the result from the mover result", func() {
+		logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
+		Expect(m.resultDetails(logger).Check).To(BeEmpty())
+		m.moverResult = &utils.MoverResult{Version: 1, Details: json.RawMessage(`{"check":"Passed"}`)}
+		Expect(m.resultDetails(logger).Check).To(Equal(volsyncv1alpha1.ResticCheckPassed))
+		m.moverResult.Details = json.RawMessage(`{"check":"Failed","copies":{"offsite":true}}`)
+		Expect(m.resultDetails(logger).Check).To(Equal(volsyncv1alpha1.ResticCheckFailed))
+	})
+})
+
+var _ = Describe("Restic include and exclude patterns", func() {
+	It("validates and normalizes the backup includes", func() {
+		Expect(ValidateBackupIncludes([]string{"app/data", "/config.yaml", "./app//logs/"})).To(Succeed())
+		Expect(normalizeBackupIncludes([]string{"app/data", "/config.yaml", "./app//logs/"})).
+			To(Equal([]string{"app/data", "config.yaml", "app/logs"}))
+		Expect(ValidateBackupIncludes([]string{"../etc"})).NotTo(Succeed())
+		Expect(ValidateBackupIncludes([]string{"/"})).NotTo(Succeed())
+		Expect(ValidateBackupIncludes([]string{""})).NotTo(Succeed())
+		Expect(ValidatePatterns([]string{"*.tmp\n*.bak"})).NotTo(Succeed())
+	})
+	It("passes the backup patterns to the mover", func() {
+		m := &Mover{
+			isSource:         true,
+			includes:         []string{"app/data", "config.yaml"},
+			excludes:         []string{"/app/data/cache", "*.tmp"},
+			excludeIfPresent: []string{".nobackup"},
+		}
+		Expect(m.filterEnvVars()).To(Equal([]corev1.EnvVar{
+			{Name: "BACKUP_EXCLUDES", Value: "/data/app/data/cache\n*.tmp"},
+			{Name: "BACKUP_EXCLUDE_IF_PRESENT", Value: ".nobackup"},
+			{Name: "BACKUP_INCLUDES", Value: "app/data\nconfig.yaml"},
+		}))
+	})
+	It("passes the restore patterns to the mover", func() {
+		m := &Mover{
+			restoreIncludes: []string{"/app/config", "*.sql"},
+		}
+		Expect(m.filterEnvVars()).To(Equal([]corev1.EnvVar{
+			{Name: "RESTORE_INCLUDES", Value: "/app/config\n*.sql"},
+		}))
+		Expect((&Mover{}).filterEnvVars()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Restic secondary repositories", func() {
+	var m *Mover
+	var recorder *events.FakeRecorder
+	logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
+
+	BeforeEach(func() {
+		recorder = events.NewFakeRecorder(10)
+		m = &Mover{
+			eventRecorder: recorder,
+			owner: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "name",
+					Namespace:         "ns",
+					CreationTimestamp: metav1.Now(),
+				},
+			},
+			isSource: true,
+			secondaries: []volsyncv1alpha1.ResticSecondaryRepository{
+				{Repository: "offsite", Retain: &volsyncv1alpha1.ResticRetainPolicy{Daily: ptr.To[int32](30)}},
+				{Repository: "archive"},
+			},
+			secondaryRepos: []*corev1.Secret{
+				{ObjectMeta: metav1.ObjectMeta{Name: "offsite"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "archive"}},
+			},
+			sourceStatus:      &volsyncv1alpha1.ReplicationSourceResticStatus{},
+			latestMoverStatus: &volsyncv1alpha1.MoverStatus{},
+		}
+	})
+
+	It("rejects reusing a repository", func() {
+		Expect(ValidateSecondaryRepositories("primary", m.secondaries)).To(Succeed())
+		Expect(ValidateSecondaryRepositories("offsite", m.secondaries)).NotTo(Succeed())
+		Expect(ValidateSecondaryRepositories("primary", append(m.secondaries,
+			volsyncv1alpha1.ResticSecondaryRepository{Repository: "archive"}))).NotTo(Succeed())
+	})
+
+	It("rejects secondary repositories with different backend credentials", func() {
+		primary := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "primary"},
+			Data: map[string][]byte{
+				"RESTIC_REPOSITORY":     []byte("s3:s3.amazonaws.com/primary"),
+				"RESTIC_PASSWORD":       []byte("one"),
+				"AWS_ACCESS_KEY_ID":     []byte("key"),
+				"AWS_SECRET_ACCESS_KEY": []byte("secret"),
+			},
+		}
+		secondary := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "offsite"},
+			Data: map[string][]byte{
+				"RESTIC_REPOSITORY":     []byte("s3:s3.amazonaws.com/offsite"),
+				"RESTIC_PASSWORD":       []byte("two"),
+				"AWS_ACCESS_KEY_ID":     []byte("key"),
+				"AWS_SECRET_ACCESS_KEY": []byte("secret"),
+			},
+		}
+		Expect(validateSecondaryCredentials(primary, secondary)).To(Succeed())
+
+		secondary.Data = map[string][]byte{
+			"RESTIC_REPOSITORY":    []byte("rest:https://backup.example.com/offsite"),
+			"RESTIC_PASSWORD":      []byte("two"),
+			"RESTIC_REST_PASSWORD": []byte("rest"),
+		}
+		Expect(validateSecondaryCredentials(primary, secondary)).To(Succeed())
+
+		secondary.Data["AWS_SECRET_ACCESS_KEY"] = []byte("other")
+		Expect(validateSecondaryCredentials(primary, secondary)).To(MatchError(ContainSubstring("AWS_SECRET_ACCESS_KEY")))
+	})
+
+	It("passes each secondary repository to the mover with a prefix", func() {
+		envVars := m.secondaryEnvVars()
+		Expect(envVars).To(ContainElements(
+			corev1.EnvVar{Name: "SECONDARY_REPOSITORY_COUNT", Value: "2"},
+			corev1.EnvVar{Name: "SECONDARY_0_NAME", Value: "offsite"},
+			corev1.EnvVar{Name: "SECONDARY_0_FORGET_OPTIONS", Value: " --keep-daily 30"},
+			corev1.EnvVar{Name: "SECONDARY_1_NAME", Value: "archive"},
+			corev1.EnvVar{Name: "SECONDARY_1_FORGET_OPTIONS", Value: "--keep-last 1"},
+		))
+		names := []string{}
+		for _, env := range envVars {
+			names = append(names, env.Name)
+			if env.Name == "SECONDARY_1_RESTIC_PASSWORD" {
+				Expect(env.ValueFrom.SecretKeyRef.Name).To(Equal("archive"))
+				Expect(env.ValueFrom.SecretKeyRef.Key).To(Equal("RESTIC_PASSWORD"))
+			}
+		}
+		Expect(names).To(ContainElements("SECONDARY_0_RESTIC_REPOSITORY", "SECONDARY_1_RESTIC_PASSWORD"))
+		// The owner was just created, so it isn't time to prune
+		Expect(names).NotTo(ContainElement("SECONDARY_PRUNE"))
+	})
+
+	It("records the result of each copy", func() {
+		m.sourceStatus.SecondaryRepositories = []volsyncv1alpha1.ResticSecondaryRepositoryStatus{
+			{Repository: "archive", ConsecutiveFailures: 2},
+			{Repository: "removed"},
+		}
+		m.moverResult = &utils.MoverResult{
+			Version: 1,
+			Details: json.RawMessage(`{"copies":{"offsite":true,"archive":false}}`),
+		}
+		m.recordCopyResults(logger)
+
+		statuses := m.sourceStatus.SecondaryRepositories
+		Expect(statuses).To(HaveLen(2))
+		Expect(statuses[0].Repository).To(Equal("offsite"))
+		Expect(statuses[0].LastCopied).NotTo(BeNil())
+		Expect(statuses[0].ConsecutiveFailures).To(BeZero())
+		Expect(statuses[1].Repository).To(Equal("archive"))
+		Expect(statuses[1].LastCopied).To(BeNil())
+		Expect(statuses[1].LastFailure).NotTo(BeNil())
+		Expect(statuses[1].ConsecutiveFailures).To(Equal(int32(3)))
+		Expect(recorder.Events).To(Receive(ContainSubstring(volsyncv1alpha1.EvRSecondaryCopyFailed)))
+
+		m.secondaryRepos = nil
+		m.recordCopyResults(logger)
+		Expect(m.sourceStatus.SecondaryRepositories).To(BeNil())
+	})
+})
+
 var _ = Describe("Restic properly registers", func() {
 	When("Restic's registration function is called", func() {
 		BeforeEach(func() {
@@ -1625,6 +1834,48 @@ var _ = Describe("Restic as a source", func() {
 				})
 			})
 
+			When("The source PVC is a block volume", func() {
+				var blockPVC *corev1.PersistentVolumeClaim
+				BeforeEach(func() {
+					blockPVC = &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							GenerateName: "test-block-pvc-",
+							Namespace:    ns.Name,
+						},
+						Spec: corev1.PersistentVolumeClaimSpec{
+							AccessModes: []corev1.PersistentVolumeAccessMode{
+								corev1.ReadWriteOnce,
+							},
+							VolumeMode: ptr.To(corev1.PersistentVolumeBlock),
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{
+									"storage": resource.MustParse("1Gi"),
+								},
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, blockPVC)).To(Succeed())
+				})
+				It("Mover job should attach the PVC as a device", func() {
+					j, e := mover.ensureJob(ctx, cache, blockPVC, sa, repo, nil)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+
+					container := job.Spec.Template.Spec.Containers[0]
+					Expect(container.VolumeDevices).To(ConsistOf(corev1.VolumeDevice{
+						Name:       dataVolumeName,
+						DevicePath: devicePath,
+					}))
+					for _, v := range container.VolumeMounts {
+						Expect(v.Name).NotTo(Equal(dataVolumeName))
+					}
+					Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "BLOCK_DEVICE", Value: devicePath}))
+				})
+			})
+
 			// nolint:dupl
 			Context("Unlock tests", func() {
 				When("Unlock is used (spec.restic.unlock", func() {
@@ -1776,6 +2027,64 @@ var _ = Describe("Restic as a source", func() {
 				})
 			})
 
+			When("it's time to check the repository", func() {
+				JustBeforeEach(func() {
+					mover.check = &volsyncv1alpha1.ReplicationSourceResticCheckSpec{
+						ReadDataSubset: ptr.To("10%"),
+					}
+					mover.sourceStatus = &volsyncv1alpha1.ReplicationSourceResticStatus{
+						LastChecked: &metav1.Time{Time: time.Now().Add(-28 * 24 * time.Hour)},
+					}
+				})
+				It("should have the backup and check actions", func() {
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+					container := job.Spec.Template.Spec.Containers[0]
+					Expect(container.Args).To(Equal([]string{"backup", "check"}))
+					Expect(container.Env).To(ContainElements(
+						corev1.EnvVar{Name: "CHECK_FAILURE_FATAL", Value: "0"},
+						corev1.EnvVar{Name: "CHECK_READ_DATA_SUBSET", Value: "10%"},
+					))
+				})
+			})
+
+			When("the repository has a ResticMaintenance", func() {
+				JustBeforeEach(func() {
+					mover.repositoryName = repo.Name
+					mover.sourceStatus = &volsyncv1alpha1.ReplicationSourceResticStatus{
+						LastPruned: &metav1.Time{Time: time.Now().Add(-28 * 24 * time.Hour)},
+					}
+					rm := &volsyncv1alpha1.ResticMaintenance{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "maint",
+							Namespace: ns.Name,
+						},
+						Spec: volsyncv1alpha1.ResticMaintenanceSpec{
+							Repository: repo.Name,
+						},
+					}
+					Expect(k8sClient.Create(ctx, rm)).To(Succeed())
+				})
+				It("should leave pruning to the ResticMaintenance", func() {
+					name, err := mover.repositoryMaintenance(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(name).To(Equal("maint"))
+					mover.maintenanceName = name
+
+					j, e := mover.ensureJob(ctx, cache, sPVC, sa, repo, nil)
+					Expect(e).NotTo(HaveOccurred())
+					Expect(j).To(BeNil()) // hasn't completed
+					nsn := types.NamespacedName{Name: jobName, Namespace: ns.Name}
+					job = &batchv1.Job{}
+					Expect(k8sClient.Get(ctx, nsn, job)).To(Succeed())
+					Expect(job.Spec.Template.Spec.Containers[0].Args).To(ConsistOf("backup"))
+				})
+			})
+
 			When("Doing a sync when the job already exists", func() {
 				JustBeforeEach(func() {
 					mover.containerImage = "my-restic-mover-image"
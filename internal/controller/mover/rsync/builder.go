@@ -99,6 +99,9 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 	if source.Spec.Rsync == nil {
 		return nil, nil
 	}
+	if source.Spec.Rsync.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		return nil, mover.ErrGroupSnapshotNotSupported
+	}
 
 	// Make sure there's a place to write status info
 	if source.Status.Rsync == nil {
@@ -146,6 +149,7 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 			MoverSecurityContext: nil, // Not supported for rsync ssh
 			MoverPodLabels:       source.Spec.Rsync.MoverPodLabels,
 			MoverResources:       source.Spec.Rsync.MoverResources,
+			TransferPolicy:       source.Spec.Rsync.TransferPolicy,
 		},
 	}, nil
 }
@@ -158,6 +162,9 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 	if destination.Spec.Rsync == nil {
 		return nil, nil
 	}
+	if destination.Spec.Rsync.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		return nil, mover.ErrGroupSnapshotDestination
+	}
 
 	// Make sure there's a place to write status info
 	if destination.Status.Rsync == nil {
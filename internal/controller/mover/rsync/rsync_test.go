@@ -220,6 +220,50 @@ var _ = Describe("Rsync ignores other movers", func() {
 	})
 })
 
+var _ = Describe("Rsync rejects the GroupSnapshot copyMethod", func() {
+	logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
+	It("is rejected for an RS", func() {
+		rs := &volsyncv1alpha1.ReplicationSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cr",
+				Namespace: "blah",
+			},
+			Spec: volsyncv1alpha1.ReplicationSourceSpec{
+				Rsync: &volsyncv1alpha1.ReplicationSourceRsyncSpec{
+					ReplicationSourceVolumeOptions: volsyncv1alpha1.ReplicationSourceVolumeOptions{
+						CopyMethod: volsyncv1alpha1.CopyMethodGroupSnapshot,
+					},
+				},
+			},
+			Status: &volsyncv1alpha1.ReplicationSourceStatus{},
+		}
+		m, e := commonBuilderForTestSuite.FromSource(k8sClient, logger, &events.FakeRecorder{}, rs,
+			true /* privileged */)
+		Expect(m).To(BeNil())
+		Expect(e).To(MatchError(mover.ErrGroupSnapshotNotSupported))
+	})
+	It("is rejected for an RD", func() {
+		rd := &volsyncv1alpha1.ReplicationDestination{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "x",
+				Namespace: "y",
+			},
+			Spec: volsyncv1alpha1.ReplicationDestinationSpec{
+				Rsync: &volsyncv1alpha1.ReplicationDestinationRsyncSpec{
+					ReplicationDestinationVolumeOptions: volsyncv1alpha1.ReplicationDestinationVolumeOptions{
+						CopyMethod: volsyncv1alpha1.CopyMethodGroupSnapshot,
+					},
+				},
+			},
+			Status: &volsyncv1alpha1.ReplicationDestinationStatus{},
+		}
+		m, e := commonBuilderForTestSuite.FromDestination(k8sClient, logger, &events.FakeRecorder{}, rd,
+			true /* privileged */)
+		Expect(m).To(BeNil())
+		Expect(e).To(MatchError(mover.ErrGroupSnapshotDestination))
+	})
+})
+
 //nolint:goconst
 var _ = Describe("Rsync as a source", func() {
 	var ns *corev1.Namespace
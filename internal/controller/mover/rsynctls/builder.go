@@ -99,6 +99,9 @@ func (rb *Builder) FromSource(client client.Client, logger logr.Logger,
 	if source.Spec.RsyncTLS == nil {
 		return nil, nil
 	}
+	if source.Spec.RsyncTLS.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		return nil, mover.ErrGroupSnapshotNotSupported
+	}
 
 	// Make sure there's a place to write status info
 	if source.Status.RsyncTLS == nil {
@@ -156,6 +159,9 @@ func (rb *Builder) FromDestination(client client.Client, logger logr.Logger,
 	if destination.Spec.RsyncTLS == nil {
 		return nil, nil
 	}
+	if destination.Spec.RsyncTLS.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		return nil, mover.ErrGroupSnapshotDestination
+	}
 
 	// Make sure there's a place to write status info
 	if destination.Status.RsyncTLS == nil {
@@ -74,6 +74,7 @@ type Mover struct {
 	mainPVCName        *string
 	privileged         bool
 	latestMoverStatus  *volsyncv1alpha1.MoverStatus
+	jobFailure         *mover.AttemptFailure
 	moverConfig        volsyncv1alpha1.MoverConfig
 	moverVolumes       []volsyncv1alpha1.MoverVolume
 	// Source-only fields
@@ -136,6 +137,9 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 	// Ensure mover Job
 	job, err := m.ensureJob(ctx, dataPVC, sa, *rsyncPSKSecretName)
 	if job == nil || err != nil {
+		if err == nil && m.jobFailure != nil {
+			return mover.Failed(*m.jobFailure), nil
+		}
 		return mover.InProgress(), err
 	}
 
@@ -385,8 +389,7 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 		job.Spec.Template.Name = job.Name
 		utils.AddAllLabels(&job.Spec.Template, m.serviceSelector())
 		utils.SetOwnedByVolSync(&job.Spec.Template) // ensure the Job's Pod gets the ownership label
-		backoffLimit := int32(2)
-		job.Spec.BackoffLimit = &backoffLimit
+		job.Spec.BackoffLimit = mover.JobBackoffLimit(m.owner, 2)
 
 		parallelism := int32(1)
 		if m.paused {
@@ -408,11 +411,23 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 				connectPort := strconv.Itoa(int(*m.port))
 				containerEnv = append(containerEnv, corev1.EnvVar{Name: "DESTINATION_PORT", Value: connectPort})
 			}
+			// rsync can only limit the data sent by the source
+			limits := utils.SyncTransferLimits(m.owner, m.moverConfig.TransferPolicy)
+			if limits.Upload > 0 {
+				containerEnv = append(containerEnv, corev1.EnvVar{Name: "RSYNC_BWLIMIT",
+					Value: strconv.FormatInt(limits.UploadKiB(), 10)})
+			}
+			// Report the capacity of the source to the destination
+			containerEnv = append(containerEnv, utils.CapacityEnvVar("SOURCE_CAPACITY", dataPVC))
 			// Set container cmd for the replicationSource job
 			containerCmd = []string{"/bin/bash", "-c", "/mover-rsync-tls/client.sh"}
 
 			// Set read-only for volume in repl source job spec if the PVC only supports read-only
 			readOnlyVolume = utils.PvcIsReadOnly(dataPVC)
+		} else if m.vh.FollowsSourceCapacity() {
+			// The destination stops if the source doesn't fit, so that the
+			// volume can be expanded
+			containerEnv = append(containerEnv, utils.CapacityEnvVar("DESTINATION_CAPACITY", dataPVC))
 		}
 		podSpec := &job.Spec.Template.Spec
 		podSpec.Containers = []corev1.Container{{
@@ -507,7 +522,7 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 		return nil
 	})
 	// If Job had failed, delete it so it can be recreated
-	if job.Status.Failed >= *job.Spec.BackoffLimit {
+	if mover.JobFailed(job) {
 		// Update status with mover logs from failed job
 		utils.UpdateMoverStatusForFailedJob(ctx, m.logger, m.latestMoverStatus, job.GetName(), job.GetNamespace(),
 			LogLineFilterFailure)
@@ -515,6 +530,7 @@ func (m *Mover) ensureJob(ctx context.Context, dataPVC *corev1.PersistentVolumeC
 		logger.Info("deleting job -- backoff limit reached")
 		m.eventRecorder.Eventf(m.owner, job, corev1.EventTypeWarning,
 			volsyncv1alpha1.EvRTransferFailed, volsyncv1alpha1.EvADeleteMover, "mover Job backoff limit reached")
+		m.jobFailure = ptr.To(mover.JobFailure(m.latestMoverStatus))
 		err = m.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
 		return nil, err
 	}
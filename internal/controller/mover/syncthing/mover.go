@@ -154,11 +154,16 @@ func (m *Mover) Synchronize(ctx context.Context) (mover.Result, error) {
 	if err != nil {
 		return mover.InProgress(), err
 	}
-	if err = m.interactWithSyncthing(dataService, secretAPIKey); err != nil {
+	syncthingState, err := m.interactWithSyncthing(dataService, secretAPIKey)
+	if err != nil {
 		return mover.InProgress(), err
 	}
 	var retryAfter = 20 * time.Second
-	return mover.RetryAfter(retryAfter), nil
+	result := mover.RetryAfter(retryAfter)
+	// Syncthing never completes a synchronization, so report its traffic
+	total := syncthingState.SystemConnections.Total
+	result.TransferredBytesTotal = ptr.To(int64(total.InBytesTotal + total.OutBytesTotal))
+	return result, nil
 }
 
 // ensureNecessaryResources Creates the resources required for VolSync to operate the Syncthing mover,
@@ -213,35 +218,35 @@ func (m *Mover) ensureNecessaryResources(ctx context.Context) (*corev1.Service,
 
 // interactWithSyncthing Updates the Syncthing instance with the required connections as defined by VolSync,
 // and sets the status of the ReplicationSource to reflect the current state of the Syncthing instance.
-// An error is returned when it is unable to do so.
-func (m *Mover) interactWithSyncthing(dataService *corev1.Service, apiSecret *corev1.Secret) error {
+// The latest state of the Syncthing instance is returned, or an error when it is unable to do so.
+func (m *Mover) interactWithSyncthing(dataService *corev1.Service, apiSecret *corev1.Secret) (*api.Syncthing, error) {
 	// get the API key from the secret
 	var err error
 	if err = m.validatePeerList(); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = m.configureSyncthingAPIClient(apiSecret); err != nil {
-		return err
+		return nil, err
 	}
 
 	// fetch the latest data from Syncthing
 	syncthingState, err := m.syncthingConnection.Fetch()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// configure syncthing before grabbing info & updating status
 	if err = m.ensureIsConfigured(apiSecret, syncthingState); err != nil {
-		return err
+		return nil, err
 	}
 
 	// obtain the latest state
 	if syncthingState, err = m.syncthingConnection.Fetch(); err != nil {
-		return err
+		return nil, err
 	}
 
-	return m.ensureStatusIsUpdated(dataService, syncthingState)
+	return syncthingState, m.ensureStatusIsUpdated(dataService, syncthingState)
 }
 
 // ensureConfigPVC Ensures that there is a PVC persisting Syncthing's config data.
@@ -0,0 +1,324 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// Package notify sends the synchronization events of ReplicationSources and
+// ReplicationDestinations to NotificationChannels.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	// operatorNamespaceEnvVar holds the namespace the operator runs in
+	operatorNamespaceEnvVar  = "POD_NAMESPACE"
+	defaultOperatorNamespace = "volsync-system"
+)
+
+// Event is a synchronization event of a ReplicationSource or
+// ReplicationDestination. It is the JSON document sent by Webhook channels
+// and the data passed to their bodyTemplate.
+type Event struct {
+	Type         volsyncv1alpha1.NotificationEventType `json:"type"`
+	Kind         string                                `json:"kind"`
+	Namespace    string                                `json:"namespace"`
+	Name         string                                `json:"name"`
+	Time         time.Time                             `json:"time"`
+	Message      string                                `json:"message"`
+	FailureClass volsyncv1alpha1.FailureClass          `json:"failureClass,omitempty"`
+	Attempts     int32                                 `json:"attempts,omitempty"`
+	SyncDuration string                                `json:"syncDuration,omitempty"`
+	Logs         string                                `json:"logs,omitempty"`
+}
+
+// NewSyncEvent creates the event of a synchronization of obj from its status.
+// The failure is only set for failure events.
+func NewSyncEvent(kind string, obj client.Object, eventType volsyncv1alpha1.NotificationEventType,
+	failure *mover.AttemptFailure, retry *volsyncv1alpha1.RetryStatus, moverStatus *volsyncv1alpha1.MoverStatus,
+	duration *metav1.Duration) Event {
+	event := Event{
+		Type:      eventType,
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Time:      time.Now().UTC(),
+		Message:   "Synchronization completed",
+	}
+	if duration != nil {
+		event.SyncDuration = duration.Duration.String()
+	}
+	if failure != nil {
+		event.Message = failure.Message
+		event.FailureClass = failure.Class
+		event.SyncDuration = ""
+		if retry != nil {
+			event.Attempts = retry.Attempts
+		}
+		if moverStatus != nil {
+			event.Logs = moverStatus.Logs
+		}
+	}
+	return event
+}
+
+// Wants returns true if the event should be sent to the referenced channel.
+// Channels that receive successes also receive recoveries, since a recovery is
+// a successful synchronization.
+func Wants(ref volsyncv1alpha1.NotificationReference, eventType volsyncv1alpha1.NotificationEventType) bool {
+	on := ref.On
+	if len(on) == 0 {
+		on = []volsyncv1alpha1.NotificationEventType{
+			volsyncv1alpha1.NotificationEventFailure,
+			volsyncv1alpha1.NotificationEventRecovery,
+		}
+	}
+	if eventType == volsyncv1alpha1.NotificationEventRecovery &&
+		slices.Contains(on, volsyncv1alpha1.NotificationEventSuccess) {
+		return true
+	}
+	return slices.Contains(on, eventType)
+}
+
+// Notify sends the event to each of the referenced channels that wants it.
+// Notifications are best effort: a failure to send one is logged and
+// recorded as an event on owner, but doesn't affect the synchronization.
+func Notify(ctx context.Context, c client.Client, l logr.Logger, er events.EventRecorder,
+	owner client.Object, refs []volsyncv1alpha1.NotificationReference, event Event) {
+	for _, ref := range refs {
+		if !Wants(ref, event.Type) {
+			continue
+		}
+		logger := l.WithValues("notificationChannel", ref.Channel, "event", event.Type)
+		channel := &volsyncv1alpha1.NotificationChannel{}
+		err := c.Get(ctx, client.ObjectKey{Name: ref.Channel}, channel)
+		if err == nil {
+			err = Send(ctx, c, channel, event)
+		}
+		if err != nil {
+			logger.Error(err, "unable to send notification")
+			er.Eventf(owner, nil, corev1.EventTypeWarning, volsyncv1alpha1.EvRNotificationFailed,
+				volsyncv1alpha1.EvASendNotification, "unable to send %s notification to channel %s: %s",
+				event.Type, ref.Channel, err)
+			continue
+		}
+		logger.V(1).Info("notification sent")
+	}
+}
+
+// NotifyInBackground sends the events with Notify without waiting for the
+// channels to respond, so that a slow endpoint doesn't hold up a reconcile.
+func NotifyInBackground(c client.Client, l logr.Logger, er events.EventRecorder,
+	owner client.Object, refs []volsyncv1alpha1.NotificationReference, queued []Event) {
+	if len(queued) == 0 {
+		return
+	}
+	go func() {
+		for _, event := range queued {
+			Notify(context.Background(), c, l, er, owner, refs, event)
+		}
+	}()
+}
+
+// Send POSTs the event to the channel in the channel's format
+func Send(ctx context.Context, c client.Client, channel *volsyncv1alpha1.NotificationChannel, event Event) error {
+	url, err := channelURL(ctx, c, channel)
+	if err != nil {
+		return err
+	}
+	body, err := Body(channel, event)
+	if err != nil {
+		return err
+	}
+
+	timeout := defaultTimeout
+	if channel.Spec.Timeout != nil {
+		timeout = channel.Spec.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range channel.Spec.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("notification endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// channelURL returns the url of the channel, reading it from a Secret if
+// needed. Secrets are only read from the operator's namespace, so that a
+// channel can't be used to read the Secrets of other namespaces.
+func channelURL(ctx context.Context, c client.Client, channel *volsyncv1alpha1.NotificationChannel) (string, error) {
+	ref := channel.Spec.URLFrom
+	if ref == nil {
+		return channel.Spec.URL, nil
+	}
+	if ns := operatorNamespace(); ref.Namespace != ns {
+		return "", fmt.Errorf("the urlFrom Secret must be in the operator namespace %s, not %s", ns, ref.Namespace)
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return "", err
+	}
+	url, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no key %s", ref.Namespace, ref.Name, ref.Key)
+	}
+	return strings.TrimSpace(string(url)), nil
+}
+
+// operatorNamespace returns the namespace the operator runs in
+func operatorNamespace() string {
+	if ns := os.Getenv(operatorNamespaceEnvVar); ns != "" {
+		return ns
+	}
+	const nsFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	if nsBytes, err := os.ReadFile(nsFile); err == nil {
+		if ns := strings.TrimSpace(string(nsBytes)); ns != "" {
+			return ns
+		}
+	}
+	return defaultOperatorNamespace
+}
+
+// Body renders the body of the notification for the channel's type
+func Body(channel *volsyncv1alpha1.NotificationChannel, event Event) ([]byte, error) {
+	switch channel.Spec.Type {
+	case volsyncv1alpha1.NotificationChannelSlack:
+		return json.Marshal(map[string]string{"text": slackText(event)})
+	case volsyncv1alpha1.NotificationChannelAlertmanager:
+		return json.Marshal([]alertmanagerAlert{newAlertmanagerAlert(event)})
+	default:
+		if channel.Spec.BodyTemplate == "" {
+			return json.Marshal(event)
+		}
+		return renderTemplate(channel.Spec.BodyTemplate, event)
+	}
+}
+
+// renderTemplate renders a webhook bodyTemplate. The json function quotes a
+// value as JSON so that it can be safely embedded in the body.
+func renderTemplate(bodyTemplate string, event Event) ([]byte, error) {
+	tmpl, err := template.New("body").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(bodyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse bodyTemplate: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event); err != nil {
+		return nil, fmt.Errorf("unable to render bodyTemplate: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func summary(event Event) string {
+	what := "synchronization succeeded"
+	switch event.Type {
+	case volsyncv1alpha1.NotificationEventFailure:
+		what = fmt.Sprintf("synchronization failed (%s)", event.FailureClass)
+	case volsyncv1alpha1.NotificationEventRecovery:
+		what = "synchronization recovered"
+	}
+	return fmt.Sprintf("%s %s/%s: %s", event.Kind, event.Namespace, event.Name, what)
+}
+
+func slackText(event Event) string {
+	icon := ":white_check_mark:"
+	if event.Type == volsyncv1alpha1.NotificationEventFailure {
+		icon = ":x:"
+	}
+	text := fmt.Sprintf("%s *%s*", icon, summary(event))
+	if event.Message != "" {
+		text += "\n" + event.Message
+	}
+	if event.Logs != "" {
+		text += "\n```\n" + event.Logs + "\n```"
+	}
+	return text
+}
+
+// alertmanagerAlert is an alert of the Alertmanager v2 API
+type alertmanagerAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    *time.Time        `json:"startsAt,omitempty"`
+	EndsAt      *time.Time        `json:"endsAt,omitempty"`
+}
+
+// newAlertmanagerAlert fires an alert on failures and resolves it on
+// successes and recoveries
+func newAlertmanagerAlert(event Event) alertmanagerAlert {
+	alert := alertmanagerAlert{
+		Labels: map[string]string{
+			"alertname": "VolSyncSynchronizationFailed",
+			"severity":  "warning",
+			"kind":      event.Kind,
+			"namespace": event.Namespace,
+			"name":      event.Name,
+		},
+		Annotations: map[string]string{
+			"summary":     summary(event),
+			"description": event.Message,
+		},
+	}
+	if event.Type == volsyncv1alpha1.NotificationEventFailure {
+		alert.StartsAt = &event.Time
+		alert.Annotations["failureClass"] = string(event.FailureClass)
+		if event.Logs != "" {
+			alert.Annotations["logs"] = event.Logs
+		}
+	} else {
+		alert.EndsAt = &event.Time
+	}
+	return alert
+}
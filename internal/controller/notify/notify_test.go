@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+)
+
+var _ = Describe("Notifications", func() {
+	var (
+		ctx      context.Context
+		server   *httptest.Server
+		requests []*http.Request
+		bodies   [][]byte
+		status   int
+		channel  *volsyncv1alpha1.NotificationChannel
+		rs       *volsyncv1alpha1.ReplicationSource
+		c        client.Client
+		recorder *events.FakeRecorder
+		failure  Event
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		requests, bodies = nil, nil
+		status = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r)
+			bodies = append(bodies, body)
+			w.WriteHeader(status)
+		}))
+		DeferCleanup(server.Close)
+
+		channel = &volsyncv1alpha1.NotificationChannel{
+			ObjectMeta: metav1.ObjectMeta{Name: "ops"},
+			Spec: volsyncv1alpha1.NotificationChannelSpec{
+				Type:    volsyncv1alpha1.NotificationChannelWebhook,
+				URL:     server.URL,
+				Headers: map[string]string{"Authorization": "Bearer token"},
+			},
+		}
+		rs = &volsyncv1alpha1.ReplicationSource{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "apps"},
+			Spec: volsyncv1alpha1.ReplicationSourceSpec{
+				Notifications: []volsyncv1alpha1.NotificationReference{{Channel: "ops"}},
+			},
+		}
+		failure = NewSyncEvent("ReplicationSource", rs, volsyncv1alpha1.NotificationEventFailure,
+			&mover.AttemptFailure{Class: volsyncv1alpha1.FailureClassAuth, Message: "mover job failed"},
+			&volsyncv1alpha1.RetryStatus{Attempts: 2},
+			&volsyncv1alpha1.MoverStatus{Logs: "Fatal: wrong password"},
+			nil)
+		recorder = events.NewFakeRecorder(10)
+	})
+
+	JustBeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(volsyncv1alpha1.AddToScheme(scheme)).To(Succeed())
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(channel).Build()
+	})
+
+	notify := func(event Event) {
+		logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
+		Notify(ctx, c, logger, recorder, rs, rs.Spec.Notifications, event)
+	}
+
+	It("should send failures and recoveries by default", func() {
+		ref := volsyncv1alpha1.NotificationReference{Channel: "ops"}
+		Expect(Wants(ref, volsyncv1alpha1.NotificationEventFailure)).To(BeTrue())
+		Expect(Wants(ref, volsyncv1alpha1.NotificationEventRecovery)).To(BeTrue())
+		Expect(Wants(ref, volsyncv1alpha1.NotificationEventSuccess)).To(BeFalse())
+
+		ref.On = []volsyncv1alpha1.NotificationEventType{volsyncv1alpha1.NotificationEventSuccess}
+		Expect(Wants(ref, volsyncv1alpha1.NotificationEventRecovery)).To(BeTrue())
+		Expect(Wants(ref, volsyncv1alpha1.NotificationEventFailure)).To(BeFalse())
+	})
+
+	It("should POST the event as JSON to a webhook", func() {
+		notify(failure)
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer token"))
+
+		sent := Event{}
+		Expect(json.Unmarshal(bodies[0], &sent)).To(Succeed())
+		Expect(sent.Type).To(Equal(volsyncv1alpha1.NotificationEventFailure))
+		Expect(sent.Kind).To(Equal("ReplicationSource"))
+		Expect(sent.Namespace).To(Equal("apps"))
+		Expect(sent.Name).To(Equal("db"))
+		Expect(sent.FailureClass).To(Equal(volsyncv1alpha1.FailureClassAuth))
+		Expect(sent.Attempts).To(Equal(int32(2)))
+		Expect(sent.Logs).To(Equal("Fatal: wrong password"))
+	})
+
+	It("should not send events the channel doesn't want", func() {
+		notify(NewSyncEvent("ReplicationSource", rs, volsyncv1alpha1.NotificationEventSuccess,
+			nil, nil, nil, &metav1.Duration{Duration: time.Minute}))
+		Expect(requests).To(BeEmpty())
+	})
+
+	When("the webhook has a bodyTemplate", func() {
+		BeforeEach(func() {
+			channel.Spec.BodyTemplate = `{"summary": {{ json (printf "%s/%s %s" .Namespace .Name .Type) }}}`
+		})
+		It("should render the template", func() {
+			notify(failure)
+			Expect(requests).To(HaveLen(1))
+			Expect(string(bodies[0])).To(Equal(`{"summary": "apps/db Failure"}`))
+		})
+	})
+
+	When("the channel is a Slack webhook read from a Secret", func() {
+		var secret *corev1.Secret
+		BeforeEach(func() {
+			channel.Spec.Type = volsyncv1alpha1.NotificationChannelSlack
+			channel.Spec.URL = ""
+			channel.Spec.URLFrom = &volsyncv1alpha1.SecretKeyReference{
+				Namespace: "volsync-system", Name: "slack", Key: "url"}
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "volsync-system"},
+				Data:       map[string][]byte{"url": []byte(server.URL + "\n")},
+			}
+			Expect(os.Setenv(operatorNamespaceEnvVar, "volsync-system")).To(Succeed())
+			DeferCleanup(os.Unsetenv, operatorNamespaceEnvVar)
+		})
+		JustBeforeEach(func() {
+			Expect(c.Create(ctx, secret)).To(Succeed())
+		})
+		When("the Secret isn't in the operator namespace", func() {
+			BeforeEach(func() {
+				channel.Spec.URLFrom.Namespace = "apps"
+				secret.Namespace = "apps"
+			})
+			It("should not read it", func() {
+				notify(failure)
+				Expect(requests).To(BeEmpty())
+				Expect(<-recorder.Events).To(ContainSubstring("operator namespace"))
+			})
+		})
+		It("should send a Slack message", func() {
+			notify(failure)
+			Expect(requests).To(HaveLen(1))
+			msg := map[string]string{}
+			Expect(json.Unmarshal(bodies[0], &msg)).To(Succeed())
+			Expect(msg["text"]).To(ContainSubstring("ReplicationSource apps/db: synchronization failed (Auth)"))
+			Expect(msg["text"]).To(ContainSubstring("Fatal: wrong password"))
+		})
+	})
+
+	When("the channel is an Alertmanager", func() {
+		BeforeEach(func() {
+			channel.Spec.Type = volsyncv1alpha1.NotificationChannelAlertmanager
+		})
+		It("should fire an alert on failure and resolve it on recovery", func() {
+			notify(failure)
+			notify(NewSyncEvent("ReplicationSource", rs, volsyncv1alpha1.NotificationEventRecovery,
+				nil, nil, nil, nil))
+			Expect(requests).To(HaveLen(2))
+
+			alerts := []alertmanagerAlert{}
+			Expect(json.Unmarshal(bodies[0], &alerts)).To(Succeed())
+			Expect(alerts).To(HaveLen(1))
+			Expect(alerts[0].Labels).To(HaveKeyWithValue("name", "db"))
+			Expect(alerts[0].StartsAt).NotTo(BeNil())
+			Expect(alerts[0].EndsAt).To(BeNil())
+
+			resolved := []alertmanagerAlert{}
+			Expect(json.Unmarshal(bodies[1], &resolved)).To(Succeed())
+			Expect(resolved[0].Labels).To(Equal(alerts[0].Labels))
+			Expect(resolved[0].EndsAt).NotTo(BeNil())
+		})
+	})
+
+	When("the notification can't be sent", func() {
+		BeforeEach(func() {
+			status = http.StatusInternalServerError
+			rs.Spec.Notifications = append(rs.Spec.Notifications,
+				volsyncv1alpha1.NotificationReference{Channel: "missing"})
+		})
+		It("should record a warning event for each channel", func() {
+			notify(failure)
+			Expect(recorder.Events).To(HaveLen(2))
+			Expect(<-recorder.Events).To(ContainSubstring("500"))
+			Expect(<-recorder.Events).To(ContainSubstring("missing"))
+		})
+		It("should record the warnings when sending in the background", func() {
+			logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
+			NotifyInBackground(c, logger, recorder, rs, rs.Spec.Notifications, []Event{failure})
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("500")))
+			Eventually(recorder.Events).Should(Receive(ContainSubstring("missing")))
+		})
+	})
+})
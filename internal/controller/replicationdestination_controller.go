@@ -41,6 +41,7 @@ import (
 
 	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
 	"github.com/backube/volsync/internal/controller/mover"
+	"github.com/backube/volsync/internal/controller/notify"
 	sm "github.com/backube/volsync/internal/controller/statemachine"
 	"github.com/backube/volsync/internal/controller/utils"
 )
@@ -54,11 +55,13 @@ type ReplicationDestinationReconciler struct {
 }
 
 type rdMachine struct {
-	rd      *volsyncv1alpha1.ReplicationDestination
-	client  client.Client
-	logger  logr.Logger
-	metrics volsyncMetrics
-	mover   mover.Mover
+	rd            *volsyncv1alpha1.ReplicationDestination
+	client        client.Client
+	logger        logr.Logger
+	eventRecorder events.EventRecorder
+	metrics       volsyncMetrics
+	mover         mover.Mover
+	notifications []notify.Event
 }
 
 var _ sm.ReplicationMachine = &rdMachine{}
@@ -66,6 +69,7 @@ var _ sm.ReplicationMachine = &rdMachine{}
 //nolint:lll
 //+kubebuilder:rbac:groups=volsync.backube,resources=replicationdestinations,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=volsync.backube,resources=replicationdestinations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=volsync.backube,resources=notificationchannels,verbs=get;list;watch
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete;deletecollection
 //+kubebuilder:rbac:groups=core,resources=events,verbs=create;update;patch
 //+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete;deletecollection
@@ -127,14 +131,20 @@ func (r *ReplicationDestinationReconciler) Reconcile(ctx context.Context, req ct
 			Message: err.Error(),
 		})
 	}
-	// No method found
+	// No method found, or the mover can't use its copyMethod
 	if rdm == nil && inst.Spec.External == nil {
-		err = mover.ErrNoMoverFound
+		if err == nil {
+			err = mover.ErrNoMoverFound
+		}
+		message := err.Error()
+		if errors.Is(err, mover.ErrNoMoverFound) {
+			message += fmt.Sprintf(" - enabled movers: %v", mover.GetEnabledMoverList())
+		}
 		apimeta.SetStatusCondition(&inst.Status.Conditions, metav1.Condition{
 			Type:    volsyncv1alpha1.ConditionSynchronizing,
 			Status:  metav1.ConditionFalse,
 			Reason:  volsyncv1alpha1.SynchronizingReasonError,
-			Message: err.Error() + fmt.Sprintf(" - enabled movers: %v", mover.GetEnabledMoverList()),
+			Message: message,
 		})
 	}
 
@@ -148,6 +158,9 @@ func (r *ReplicationDestinationReconciler) Reconcile(ctx context.Context, req ct
 	if err == nil { // Don't mask previous error
 		err = statusErr
 	}
+	if statusErr == nil && rdm != nil {
+		rdm.sendNotifications()
+	}
 	return result, err
 }
 
@@ -182,12 +195,15 @@ func newRDMachine(rd *volsyncv1alpha1.ReplicationDestination, c client.Client,
 		"method":        dataMover.Name(),
 	})
 
+	metrics.setLastSuccessfulSync(rd.Status.LastSyncTime)
+
 	return &rdMachine{
-		rd:      rd,
-		client:  c,
-		logger:  l,
-		metrics: metrics,
-		mover:   dataMover,
+		rd:            rd,
+		client:        c,
+		logger:        l,
+		eventRecorder: er,
+		metrics:       metrics,
+		mover:         dataMover,
 	}, nil
 }
 
@@ -235,6 +251,7 @@ func (m *rdMachine) LastSyncTime() *metav1.Time {
 
 func (m *rdMachine) SetLastSyncTime(last *metav1.Time) {
 	m.rd.Status.LastSyncTime = last
+	m.metrics.setLastSuccessfulSync(last)
 }
 
 func (m *rdMachine) LastSyncDuration() *metav1.Duration {
@@ -249,6 +266,72 @@ func (m *rdMachine) Conditions() *[]metav1.Condition {
 	return &m.rd.Status.Conditions
 }
 
+func (m *rdMachine) CreationTime() metav1.Time {
+	return m.rd.CreationTimestamp
+}
+
+// Staleness is only reported for ReplicationSources
+func (m *rdMachine) MaxStaleness() *metav1.Duration {
+	return nil
+}
+
+func (m *rdMachine) SetStale(isStale bool) {
+	m.metrics.setStale(isStale)
+}
+
+func (m *rdMachine) RetryPolicy() *volsyncv1alpha1.RetryPolicy {
+	return m.rd.Spec.RetryPolicy
+}
+
+func (m *rdMachine) RetryStatus() *volsyncv1alpha1.RetryStatus {
+	return m.rd.Status.Retry
+}
+
+func (m *rdMachine) SetRetryStatus(status *volsyncv1alpha1.RetryStatus) {
+	m.rd.Status.Retry = status
+}
+
+func (m *rdMachine) SyncHistory() *[]volsyncv1alpha1.SyncHistoryEntry {
+	return &m.rd.Status.SyncHistory
+}
+
+func (m *rdMachine) SyncHistoryLimit() int {
+	if m.rd.Spec.SyncHistoryLimit == nil {
+		return volsyncv1alpha1.DefaultSyncHistoryLimit
+	}
+	return int(*m.rd.Spec.SyncHistoryLimit)
+}
+
+func (m *rdMachine) NewSyncHistoryEntry() volsyncv1alpha1.SyncHistoryEntry {
+	entry := volsyncv1alpha1.SyncHistoryEntry{
+		Mover: m.mover.Name(),
+		Image: m.rd.Status.LatestImage.DeepCopy(),
+	}
+	if ms := m.rd.Status.LatestMoverStatus; ms != nil {
+		entry.BytesTransferred = ms.BytesProcessed
+		entry.SnapshotID = ms.SnapshotID
+	}
+	return entry
+}
+
+func (m *rdMachine) Notify(event volsyncv1alpha1.NotificationEventType, failure *mover.AttemptFailure) {
+	if len(m.rd.Spec.Notifications) == 0 {
+		return
+	}
+	m.notifications = append(m.notifications,
+		notify.NewSyncEvent("ReplicationDestination", m.rd, event, failure, m.rd.Status.Retry,
+			m.rd.Status.LatestMoverStatus, m.rd.Status.LastSyncDuration))
+}
+
+// sendNotifications sends the events queued by Notify. It must only be called
+// once the status has been saved, so that a reconcile that fails to save it
+// doesn't send the events again when it is retried.
+func (m *rdMachine) sendNotifications() {
+	notify.NotifyInBackground(m.client, m.logger, m.eventRecorder, m.rd.DeepCopy(), m.rd.Spec.Notifications,
+		m.notifications)
+	m.notifications = nil
+}
+
 func (m *rdMachine) SetOutOfSync(isOutOfSync bool) {
 	if isOutOfSync {
 		m.metrics.OutOfSync.Set(1)
@@ -262,16 +345,19 @@ func (m *rdMachine) IncMissedIntervals() {
 }
 
 func (m *rdMachine) ObserveSyncDuration(duration time.Duration) {
-	m.metrics.SyncDurations.Observe(duration.Seconds())
+	m.metrics.observeSyncDuration(duration)
 }
 
 func (m *rdMachine) Synchronize(ctx context.Context) (mover.Result, error) {
 	result, err := m.mover.Synchronize(ctx)
+	if result.Completed || result.Failure != nil {
+		// The progress is only reported while the mover is running
+		m.rd.Status.Progress = nil
+	}
 
 	if result.Completed && result.Image != nil {
-		// Mark previous latestImage for cleanup if it was a snapshot
-		err = utils.MarkOldSnapshotForCleanup(ctx, m.client, m.logger, m.rd,
-			m.rd.Status.LatestImage, result.Image)
+		// Mark the previous images that aren't retained for cleanup
+		err = m.retainImages(ctx, result.Image)
 		if err != nil {
 			return mover.InProgress(), err
 		}
@@ -279,9 +365,55 @@ func (m *rdMachine) Synchronize(ctx context.Context) (mover.Result, error) {
 		m.rd.Status.LatestImage = result.Image
 	}
 
+	if err == nil {
+		m.metrics.observeSyncResult(result, m.rd.Status.LatestMoverStatus)
+	}
 	return result, err
 }
 
+// retainImages records the new latestImage in the retained images and marks
+// the snapshots that the snapshotRetention policy no longer retains for
+// cleanup. Without a policy, only the new latestImage is kept.
+func (m *rdMachine) retainImages(ctx context.Context, latestImage *corev1.TypedLocalObjectReference) error {
+	policy := utils.DestinationSnapshotRetention(&m.rd.Spec)
+	if !utils.IsSnapshot(latestImage) {
+		policy = nil
+	}
+
+	previous := m.rd.Status.RetainedImages
+	if len(previous) == 0 && m.rd.Status.LatestImage != nil {
+		// Nothing was retained yet, the previous latestImage is from the
+		// previous synchronization
+		syncTime := metav1.Now()
+		if m.rd.Status.LastSyncTime != nil {
+			syncTime = *m.rd.Status.LastSyncTime
+		}
+		previous = []volsyncv1alpha1.RetainedImage{{Image: *m.rd.Status.LatestImage, SyncTime: syncTime}}
+	}
+	images := []volsyncv1alpha1.RetainedImage{{Image: *latestImage, SyncTime: metav1.Now()}}
+	for _, image := range previous {
+		if image.Image.Name == latestImage.Name {
+			// Already recorded by a previous attempt to complete this sync
+			images[0].SyncTime = image.SyncTime
+			continue
+		}
+		images = append(images, image)
+	}
+
+	retained, pruned := utils.RetainImages(policy, images)
+	for i := range pruned {
+		err := utils.MarkOldSnapshotForCleanup(ctx, m.client, m.logger, m.rd, &pruned[i].Image, latestImage)
+		if err != nil {
+			return err
+		}
+	}
+	m.rd.Status.RetainedImages = nil
+	if policy != nil {
+		m.rd.Status.RetainedImages = retained
+	}
+	return nil
+}
+
 func (m *rdMachine) Cleanup(ctx context.Context) (mover.Result, error) {
 	return m.mover.Cleanup(ctx)
 }
@@ -47,6 +47,7 @@ import (
 
 	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
 	"github.com/backube/volsync/internal/controller/mover"
+	"github.com/backube/volsync/internal/controller/notify"
 	sm "github.com/backube/volsync/internal/controller/statemachine"
 	"github.com/backube/volsync/internal/controller/utils"
 )
@@ -64,11 +65,13 @@ type ReplicationSourceReconciler struct {
 }
 
 type rsMachine struct {
-	rs      *volsyncv1alpha1.ReplicationSource
-	client  client.Client
-	logger  logr.Logger
-	metrics volsyncMetrics
-	mover   mover.Mover
+	rs            *volsyncv1alpha1.ReplicationSource
+	client        client.Client
+	logger        logr.Logger
+	eventRecorder events.EventRecorder
+	metrics       volsyncMetrics
+	mover         mover.Mover
+	notifications []notify.Event
 }
 
 var _ sm.ReplicationMachine = &rsMachine{}
@@ -77,6 +80,7 @@ var _ sm.ReplicationMachine = &rsMachine{}
 //nolint:funlen
 //+kubebuilder:rbac:groups=volsync.backube,resources=replicationsources,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=volsync.backube,resources=replicationsources/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=volsync.backube,resources=notificationchannels,verbs=get;list;watch
 //+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete;deletecollection
 //+kubebuilder:rbac:groups=core,resources=events,verbs=create;update;patch
@@ -92,6 +96,10 @@ var _ sm.ReplicationMachine = &rsMachine{}
 //+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,resourceNames=volsync-privileged-mover,verbs=use
 //+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;update;patch;delete;deletecollection
+//+kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshotcontents,verbs=get;list;watch
+//+kubebuilder:rbac:groups=groupsnapshot.storage.k8s.io,resources=volumegroupsnapshots,verbs=get;list;watch;create;update;patch;delete;deletecollection
+//+kubebuilder:rbac:groups=groupsnapshot.storage.k8s.io,resources=volumegroupsnapshotcontents,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch
 
 //nolint:funlen
 func (r *ReplicationSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -134,14 +142,20 @@ func (r *ReplicationSourceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			Message: err.Error(),
 		})
 	}
-	// No method found
+	// No method found, or the mover can't use its copyMethod
 	if rsm == nil && inst.Spec.External == nil {
-		err = mover.ErrNoMoverFound
+		if err == nil {
+			err = mover.ErrNoMoverFound
+		}
+		message := err.Error()
+		if errors.Is(err, mover.ErrNoMoverFound) {
+			message += fmt.Sprintf(" - enabled movers: %v", mover.GetEnabledMoverList())
+		}
 		apimeta.SetStatusCondition(&inst.Status.Conditions, metav1.Condition{
 			Type:    volsyncv1alpha1.ConditionSynchronizing,
 			Status:  metav1.ConditionFalse,
 			Reason:  volsyncv1alpha1.SynchronizingReasonError,
-			Message: err.Error() + fmt.Sprintf(" - enabled movers: %v", mover.GetEnabledMoverList()),
+			Message: message,
 		})
 	}
 
@@ -155,6 +169,9 @@ func (r *ReplicationSourceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	if err == nil { // Don't mask previous error
 		err = statusErr
 	}
+	if statusErr == nil && rsm != nil {
+		rsm.sendNotifications()
+	}
 	return result, err
 }
 
@@ -272,12 +289,15 @@ func newRSMachine(rs *volsyncv1alpha1.ReplicationSource, c client.Client,
 		"method":        dataMover.Name(),
 	})
 
+	metrics.setLastSuccessfulSync(rs.Status.LastSyncTime)
+
 	return &rsMachine{
-		rs:      rs,
-		client:  c,
-		logger:  l,
-		metrics: metrics,
-		mover:   dataMover,
+		rs:            rs,
+		client:        c,
+		logger:        l,
+		eventRecorder: er,
+		metrics:       metrics,
+		mover:         dataMover,
 	}, nil
 }
 
@@ -325,6 +345,7 @@ func (m *rsMachine) LastSyncTime() *metav1.Time {
 
 func (m *rsMachine) SetLastSyncTime(last *metav1.Time) {
 	m.rs.Status.LastSyncTime = last
+	m.metrics.setLastSuccessfulSync(last)
 }
 
 func (m *rsMachine) LastSyncDuration() *metav1.Duration {
@@ -339,6 +360,70 @@ func (m *rsMachine) Conditions() *[]metav1.Condition {
 	return &m.rs.Status.Conditions
 }
 
+func (m *rsMachine) CreationTime() metav1.Time {
+	return m.rs.CreationTimestamp
+}
+
+func (m *rsMachine) MaxStaleness() *metav1.Duration {
+	return m.rs.Spec.MaxStaleness
+}
+
+func (m *rsMachine) SetStale(isStale bool) {
+	m.metrics.setStale(isStale)
+}
+
+func (m *rsMachine) RetryPolicy() *volsyncv1alpha1.RetryPolicy {
+	return m.rs.Spec.RetryPolicy
+}
+
+func (m *rsMachine) RetryStatus() *volsyncv1alpha1.RetryStatus {
+	return m.rs.Status.Retry
+}
+
+func (m *rsMachine) SetRetryStatus(status *volsyncv1alpha1.RetryStatus) {
+	m.rs.Status.Retry = status
+}
+
+func (m *rsMachine) SyncHistory() *[]volsyncv1alpha1.SyncHistoryEntry {
+	return &m.rs.Status.SyncHistory
+}
+
+func (m *rsMachine) SyncHistoryLimit() int {
+	if m.rs.Spec.SyncHistoryLimit == nil {
+		return volsyncv1alpha1.DefaultSyncHistoryLimit
+	}
+	return int(*m.rs.Spec.SyncHistoryLimit)
+}
+
+func (m *rsMachine) NewSyncHistoryEntry() volsyncv1alpha1.SyncHistoryEntry {
+	entry := volsyncv1alpha1.SyncHistoryEntry{
+		Mover: m.mover.Name(),
+	}
+	if ms := m.rs.Status.LatestMoverStatus; ms != nil {
+		entry.BytesTransferred = ms.BytesProcessed
+		entry.SnapshotID = ms.SnapshotID
+	}
+	return entry
+}
+
+func (m *rsMachine) Notify(event volsyncv1alpha1.NotificationEventType, failure *mover.AttemptFailure) {
+	if len(m.rs.Spec.Notifications) == 0 {
+		return
+	}
+	m.notifications = append(m.notifications,
+		notify.NewSyncEvent("ReplicationSource", m.rs, event, failure, m.rs.Status.Retry,
+			m.rs.Status.LatestMoverStatus, m.rs.Status.LastSyncDuration))
+}
+
+// sendNotifications sends the events queued by Notify. It must only be called
+// once the status has been saved, so that a reconcile that fails to save it
+// doesn't send the events again when it is retried.
+func (m *rsMachine) sendNotifications() {
+	notify.NotifyInBackground(m.client, m.logger, m.eventRecorder, m.rs.DeepCopy(), m.rs.Spec.Notifications,
+		m.notifications)
+	m.notifications = nil
+}
+
 func (m *rsMachine) SetOutOfSync(isOutOfSync bool) {
 	if isOutOfSync {
 		m.metrics.OutOfSync.Set(1)
@@ -352,11 +437,28 @@ func (m *rsMachine) IncMissedIntervals() {
 }
 
 func (m *rsMachine) ObserveSyncDuration(duration time.Duration) {
-	m.metrics.SyncDurations.Observe(duration.Seconds())
+	m.metrics.observeSyncDuration(duration)
 }
 
 func (m *rsMachine) Synchronize(ctx context.Context) (mover.Result, error) {
-	return m.mover.Synchronize(ctx)
+	result, err := m.mover.Synchronize(ctx)
+	if result.Completed || result.Failure != nil {
+		// The progress is only reported while the mover is running
+		m.rs.Status.Progress = nil
+	}
+	if result.Completed && err == nil {
+		// Make sure the after hook has run, e.g. with the Direct copyMethod
+		// where there's no copy to wait for. As with the other copy methods,
+		// a failing hook holds the sync until it succeeds unless its
+		// onFailure policy is Continue.
+		if err = utils.EnsureAfterHook(ctx, m.client, m.logger, m.eventRecorder, m.rs); err != nil {
+			return mover.InProgress(), err
+		}
+	}
+	if err == nil {
+		m.metrics.observeSyncResult(result, m.rs.Status.LatestMoverStatus)
+	}
+	return result, err
 }
 
 func (m *rsMachine) Cleanup(ctx context.Context) (mover.Result, error) {
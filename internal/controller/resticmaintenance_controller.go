@@ -0,0 +1,565 @@
+//go:build !disable_restic
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	cron "github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover/restic"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+const (
+	// resticMaintenanceLabel is set on the Jobs of a ResticMaintenance to its name
+	resticMaintenanceLabel = "volsync.backube/restic-maintenance"
+	// resticMaintenanceTypeLabel distinguishes manual from scheduled Jobs
+	resticMaintenanceTypeLabel = "volsync.backube/maintenance-type"
+
+	resticMaintenanceCacheMountPath = "/cache"
+
+	conditionMaintenanceHealthy = "MaintenanceHealthy"
+)
+
+// ResticMaintenanceReconciler reconciles a ResticMaintenance object
+type ResticMaintenanceReconciler struct {
+	client.Client
+	Scheme         *runtime.Scheme
+	Log            logr.Logger
+	EventRecorder  record.EventRecorder
+	containerImage string
+}
+
+// getContainerImage returns the container image to use for maintenance jobs,
+// which is the one configured for the restic mover
+func (r *ResticMaintenanceReconciler) getContainerImage() string {
+	if r.containerImage == "" {
+		return restic.ContainerImage()
+	}
+	return r.containerImage
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResticMaintenanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Watch the CronJobs and manual Jobs so that status follows their runs
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("resticmaintenance").
+		For(&volsyncv1alpha1.ResticMaintenance{}).
+		Owns(&batchv1.CronJob{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+//nolint:lll
+//+kubebuilder:rbac:groups=volsync.backube,resources=resticmaintenances,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=volsync.backube,resources=resticmaintenances/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=volsync.backube,resources=resticmaintenances/finalizers,verbs=update
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile keeps the CronJob, or the manual Job, of a ResticMaintenance in
+// sync with its spec
+func (r *ResticMaintenanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("resticmaintenance", req.NamespacedName)
+
+	rm := &volsyncv1alpha1.ResticMaintenance{}
+	if err := r.Get(ctx, req.NamespacedName, rm); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !rm.GetDeletionTimestamp().IsZero() {
+		// The CronJob, Jobs and cache PVC are removed with their owner
+		return ctrl.Result{}, nil
+	}
+
+	if err := rm.Validate(); err != nil {
+		logger.Error(err, "invalid ResticMaintenance")
+		r.EventRecorder.Event(rm, corev1.EventTypeWarning, "ValidationFailed", err.Error())
+		// Wait for the spec to be fixed
+		return ctrl.Result{}, r.updateStatus(ctx, rm, nil, err)
+	}
+
+	jobTemplate, err := r.buildJobTemplate(ctx, rm)
+	if err != nil {
+		logger.Error(err, "unable to build maintenance job")
+		return ctrl.Result{RequeueAfter: time.Minute}, r.updateStatus(ctx, rm, nil, err)
+	}
+
+	if err := r.cleanupManualJobs(ctx, rm); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if rm.GetManualTrigger() != "" {
+		if err := r.deleteCronJob(ctx, rm); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.handleManualTrigger(ctx, rm, jobTemplate, logger)
+	}
+
+	cronJob, err := r.ensureCronJob(ctx, rm, jobTemplate, logger)
+	if err != nil {
+		logger.Error(err, "unable to ensure maintenance CronJob")
+		r.EventRecorder.Event(rm, corev1.EventTypeWarning, "CronJobFailed",
+			fmt.Sprintf("unable to ensure maintenance CronJob: %v", err))
+		return ctrl.Result{RequeueAfter: time.Minute}, r.updateStatus(ctx, rm, nil, err)
+	}
+	return ctrl.Result{}, r.updateStatus(ctx, rm, cronJob, nil)
+}
+
+// handleManualTrigger runs a single maintenance Job for the current manual
+// trigger. The trigger is recorded in status.lastManualSync once the Job has
+// finished, whether it succeeded or not.
+func (r *ResticMaintenanceReconciler) handleManualTrigger(ctx context.Context, rm *volsyncv1alpha1.ResticMaintenance,
+	jobTemplate *batchv1.JobTemplateSpec, logger logr.Logger) error {
+	tag := rm.GetManualTrigger()
+	if rm.Status == nil {
+		rm.Status = &volsyncv1alpha1.ResticMaintenanceStatus{}
+	}
+	if rm.Status.LastManualSync == tag {
+		return r.updateStatus(ctx, rm, nil, nil)
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resticMaintenanceManualJobName(rm),
+			Namespace: rm.Namespace,
+		},
+	}
+	err := r.Get(ctx, client.ObjectKeyFromObject(job), job)
+	if kerrors.IsNotFound(err) {
+		job.Labels = map[string]string{resticMaintenanceTypeLabel: "manual"}
+		for k, v := range jobTemplate.Labels {
+			job.Labels[k] = v
+		}
+		job.Spec = jobTemplate.Spec
+		if err := ctrl.SetControllerReference(rm, job, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			return err
+		}
+		logger.Info("created manual maintenance job", "job", job.Name, "manual", tag)
+		r.EventRecorder.Event(rm, corev1.EventTypeNormal, "ManualMaintenanceStarted",
+			fmt.Sprintf("started manual maintenance job %s", job.Name))
+		return r.updateStatus(ctx, rm, nil, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	if finished, succeeded, _ := resticMaintenanceJobResult(job); finished {
+		rm.Status.LastManualSync = tag
+		if succeeded {
+			r.EventRecorder.Event(rm, corev1.EventTypeNormal, "ManualMaintenanceCompleted",
+				fmt.Sprintf("manual maintenance %s completed", tag))
+		} else {
+			r.EventRecorder.Event(rm, corev1.EventTypeWarning, "ManualMaintenanceFailed",
+				fmt.Sprintf("manual maintenance %s failed", tag))
+		}
+	}
+	return r.updateStatus(ctx, rm, nil, nil)
+}
+
+// ensureCronJob creates or updates the CronJob that runs the maintenance on
+// the schedule of the ResticMaintenance
+func (r *ResticMaintenanceReconciler) ensureCronJob(ctx context.Context, rm *volsyncv1alpha1.ResticMaintenance,
+	jobTemplate *batchv1.JobTemplateSpec, logger logr.Logger) (*batchv1.CronJob, error) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resticMaintenanceCronJobName(rm),
+			Namespace: rm.Namespace,
+		},
+	}
+	op, err := ctrlutil.CreateOrUpdate(ctx, r.Client, cronJob, func() error {
+		if err := ctrl.SetControllerReference(rm, cronJob, r.Scheme); err != nil {
+			return err
+		}
+		utils.SetOwnedByVolSync(cronJob)
+		cronJob.Spec.Schedule = rm.GetSchedule()
+		cronJob.Spec.ConcurrencyPolicy = batchv1.ForbidConcurrent
+		cronJob.Spec.Suspend = ptr.To(rm.IsSuspended())
+		cronJob.Spec.SuccessfulJobsHistoryLimit = rm.Spec.SuccessfulJobsHistoryLimit
+		cronJob.Spec.FailedJobsHistoryLimit = rm.Spec.FailedJobsHistoryLimit
+		cronJob.Spec.JobTemplate = *jobTemplate
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if op == ctrlutil.OperationResultCreated {
+		r.EventRecorder.Event(rm, corev1.EventTypeNormal, "CronJobCreated",
+			fmt.Sprintf("created maintenance CronJob %s", cronJob.Name))
+	}
+	logger.V(1).Info("maintenance CronJob reconciled", "cronJob", cronJob.Name, "operation", op)
+	return cronJob, nil
+}
+
+// deleteCronJob removes the CronJob when the ResticMaintenance switches to a
+// manual trigger
+func (r *ResticMaintenanceReconciler) deleteCronJob(ctx context.Context, rm *volsyncv1alpha1.ResticMaintenance) error {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resticMaintenanceCronJobName(rm),
+			Namespace: rm.Namespace,
+		},
+	}
+	err := r.Delete(ctx, cronJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return client.IgnoreNotFound(err)
+}
+
+// cleanupManualJobs removes the Jobs of previous manual triggers
+func (r *ResticMaintenanceReconciler) cleanupManualJobs(ctx context.Context, rm *volsyncv1alpha1.ResticMaintenance) error {
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(rm.Namespace), client.MatchingLabels{
+		resticMaintenanceLabel:     rm.Name,
+		resticMaintenanceTypeLabel: "manual",
+	}); err != nil {
+		return err
+	}
+	current := ""
+	if rm.GetManualTrigger() != "" {
+		current = resticMaintenanceManualJobName(rm)
+	}
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		if job.Name == current {
+			continue
+		}
+		err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildJobTemplate returns the template of the maintenance Jobs. It checks
+// that the repository Secret and custom CA exist and prepares the cache.
+//
+//nolint:funlen
+func (r *ResticMaintenanceReconciler) buildJobTemplate(ctx context.Context,
+	rm *volsyncv1alpha1.ResticMaintenance) (*batchv1.JobTemplateSpec, error) {
+	logger := r.Log.WithValues("resticmaintenance", client.ObjectKeyFromObject(rm))
+
+	repo := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rm.Spec.Repository,
+			Namespace: rm.Namespace,
+		},
+	}
+	if err := utils.GetAndValidateSecret(ctx, r.Client, logger, repo,
+		"RESTIC_REPOSITORY", "RESTIC_PASSWORD"); err != nil {
+		return nil, err
+	}
+
+	var customCASpec volsyncv1alpha1.CustomCASpec
+	if rm.Spec.CustomCA != nil {
+		customCASpec = volsyncv1alpha1.CustomCASpec(*rm.Spec.CustomCA)
+	}
+	customCAObj, err := utils.ValidateCustomCA(ctx, r.Client, logger, rm.Namespace, customCASpec)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheVolume, err := r.ensureCache(ctx, rm)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := []string{}
+	forgetOptions := ""
+	if rm.Spec.Retain != nil {
+		forgetOptions = restic.GenerateForgetOptions(rm.Spec.Retain)
+		actions = append(actions, "forget")
+	}
+	actions = append(actions, "prune")
+	readDataSubset := ""
+	if rm.Spec.Check != nil {
+		actions = append(actions, "check")
+		readDataSubset = ptr.Deref(rm.Spec.Check.ReadDataSubset, "")
+	}
+
+	envVars := []corev1.EnvVar{
+		{Name: "FORGET_OPTIONS", Value: forgetOptions},
+		{Name: "CHECK_READ_DATA_SUBSET", Value: readDataSubset},
+		{Name: "DATA_DIR", Value: "/data"}, // Not used for maintenance but entry.sh expects it
+		{Name: "PRIVILEGED_MOVER", Value: "0"},
+		{Name: "RESTIC_CACHE_DIR", Value: resticMaintenanceCacheMountPath},
+	}
+	envVars = append(envVars, restic.RepositoryEnvVars(repo)...)
+	envVars = utils.AppendEnvVarsForClusterWideProxy(envVars)
+
+	labels := map[string]string{resticMaintenanceLabel: rm.Name}
+	podLabels := map[string]string{resticMaintenanceLabel: rm.Name}
+	for k, v := range rm.Spec.MoverPodLabels {
+		podLabels[k] = v
+	}
+
+	podSecurityContext := rm.Spec.PodSecurityContext
+	if podSecurityContext == nil {
+		podSecurityContext = &corev1.PodSecurityContext{
+			RunAsNonRoot: ptr.To(true),
+			FSGroup:      ptr.To(int64(1000)),
+			RunAsUser:    ptr.To(int64(1000)),
+		}
+	}
+
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:      "restic",
+			Image:     r.getContainerImage(),
+			Command:   []string{"/mover-restic/entry.sh"},
+			Args:      actions,
+			Env:       envVars,
+			Resources: ptr.Deref(rm.Spec.Resources, corev1.ResourceRequirements{}),
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.To(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{"ALL"},
+				},
+				Privileged:             ptr.To(false),
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "cache", MountPath: resticMaintenanceCacheMountPath},
+				{Name: "tempdir", MountPath: "/tmp"},
+			},
+		}},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: ptr.Deref(rm.Spec.ServiceAccountName, "default"),
+		SecurityContext:    podSecurityContext,
+		NodeSelector:       rm.Spec.NodeSelector,
+		Tolerations:        rm.Spec.Tolerations,
+		Affinity:           rm.Spec.Affinity,
+		Volumes: []corev1.Volume{
+			{Name: "cache", VolumeSource: cacheVolume},
+			{Name: "tempdir", VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory,
+				}},
+			},
+		},
+	}
+	restic.MountRepositoryCredentials(&podSpec, repo, customCAObj)
+
+	return &batchv1.JobTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: labels},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(int32(2)),
+			ActiveDeadlineSeconds: ptr.To(rm.GetActiveDeadlineSeconds()),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec:       podSpec,
+			},
+		},
+	}, nil
+}
+
+// ensureCache returns the cache volume of the maintenance Jobs. A PVC is
+// created if a StorageClass or access modes are set for the cache, otherwise
+// an EmptyDir is used.
+func (r *ResticMaintenanceReconciler) ensureCache(ctx context.Context,
+	rm *volsyncv1alpha1.ResticMaintenance) (corev1.VolumeSource, error) {
+	if rm.Spec.CacheStorageClassName == nil && len(rm.Spec.CacheAccessModes) == 0 {
+		return corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: rm.Spec.CacheCapacity},
+		}, nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "volsync-restic-maintenance-" + rm.Name + "-cache",
+			Namespace: rm.Namespace,
+		},
+	}
+	err := r.Get(ctx, client.ObjectKeyFromObject(pvc), pvc)
+	if kerrors.IsNotFound(err) {
+		capacity := ptr.Deref(rm.Spec.CacheCapacity, resource.MustParse("1Gi"))
+		accessModes := rm.Spec.CacheAccessModes
+		if len(accessModes) == 0 {
+			accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		}
+		pvc.Spec = corev1.PersistentVolumeClaimSpec{
+			AccessModes: accessModes,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: capacity},
+			},
+			StorageClassName: rm.Spec.CacheStorageClassName,
+		}
+		utils.SetOwnedByVolSync(pvc)
+		if err = ctrl.SetControllerReference(rm, pvc, r.Scheme); err != nil {
+			return corev1.VolumeSource{}, err
+		}
+		err = r.Create(ctx, pvc)
+	}
+	if err != nil {
+		return corev1.VolumeSource{}, err
+	}
+	return corev1.VolumeSource{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name},
+	}, nil
+}
+
+// updateStatus records the state of the maintenance Jobs and the result of
+// the reconcile in the status of the ResticMaintenance
+func (r *ResticMaintenanceReconciler) updateStatus(ctx context.Context, rm *volsyncv1alpha1.ResticMaintenance,
+	cronJob *batchv1.CronJob, reconcileErr error) error {
+	if rm.Status == nil {
+		rm.Status = &volsyncv1alpha1.ResticMaintenanceStatus{}
+	}
+	rm.Status.ObservedGeneration = rm.Generation
+	rm.Status.ActiveCronJob = ""
+	rm.Status.NextScheduledMaintenance = nil
+	if cronJob != nil {
+		rm.Status.ActiveCronJob = cronJob.Name
+		if !rm.IsSuspended() {
+			if next, err := nextScheduledTime(rm.GetSchedule(), time.Now()); err == nil {
+				rm.Status.NextScheduledMaintenance = &metav1.Time{Time: next}
+			}
+		}
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(rm.Namespace),
+		client.MatchingLabels{resticMaintenanceLabel: rm.Name}); err != nil {
+		return err
+	}
+	var lastFinished *batchv1.Job
+	var lastFinishTime time.Time
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+		finished, succeeded, at := resticMaintenanceJobResult(job)
+		if !finished {
+			continue
+		}
+		if succeeded && (rm.Status.LastMaintenanceTime == nil || rm.Status.LastMaintenanceTime.Time.Before(at)) {
+			rm.Status.LastMaintenanceTime = &metav1.Time{Time: at}
+		}
+		if lastFinished == nil || lastFinishTime.Before(at) {
+			lastFinished = job
+			lastFinishTime = at
+		}
+	}
+
+	ready := metav1.Condition{
+		Type:               "Ready",
+		ObservedGeneration: rm.Generation,
+	}
+	switch {
+	case reconcileErr != nil:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "ReconcileFailed"
+		ready.Message = reconcileErr.Error()
+	case cronJob != nil:
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = "MaintenanceScheduled"
+		ready.Message = fmt.Sprintf("Maintenance CronJob %s is active for repository %s",
+			cronJob.Name, rm.Spec.Repository)
+	default:
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = "ManualTrigger"
+		ready.Message = fmt.Sprintf("Maintenance of repository %s is triggered manually", rm.Spec.Repository)
+	}
+	apimeta.SetStatusCondition(&rm.Status.Conditions, ready)
+
+	healthy := metav1.Condition{
+		Type:               conditionMaintenanceHealthy,
+		ObservedGeneration: rm.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             "OperatingNormally",
+		Message:            "Maintenance is operating normally",
+	}
+	if lastFinished != nil && lastFinished.Status.Succeeded == 0 {
+		healthy.Status = metav1.ConditionFalse
+		healthy.Reason = "MaintenanceFailed"
+		healthy.Message = fmt.Sprintf("Maintenance job %s failed, check its logs", lastFinished.Name)
+	}
+	apimeta.SetStatusCondition(&rm.Status.Conditions, healthy)
+
+	return r.Client.Status().Update(ctx, rm)
+}
+
+// resticMaintenanceJobResult returns whether the Job has finished, whether it
+// succeeded and when it finished
+func resticMaintenanceJobResult(job *batchv1.Job) (bool, bool, time.Time) {
+	if job.Status.Succeeded > 0 {
+		at := job.CreationTimestamp.Time
+		if job.Status.CompletionTime != nil {
+			at = job.Status.CompletionTime.Time
+		}
+		return true, true, at
+	}
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return true, false, c.LastTransitionTime.Time
+		}
+	}
+	return false, false, time.Time{}
+}
+
+// nextScheduledTime returns the time the cron schedule next fires after now
+func nextScheduledTime(schedule string, now time.Time) (time.Time, error) {
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	sched, err := parser.Parse(schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return sched.Next(now), nil
+}
+
+// resticMaintenanceCronJobName returns the name of the CronJob of rm, which
+// must fit the 52 characters allowed for CronJob names
+func resticMaintenanceCronJobName(rm *volsyncv1alpha1.ResticMaintenance) string {
+	hash := sha256.Sum256([]byte(types.NamespacedName{Namespace: rm.Namespace, Name: rm.Name}.String()))
+	name := rm.Name
+	if len(name) > 22 {
+		name = name[:22]
+	}
+	return fmt.Sprintf("restic-maint-%s-%x", name, hash[:8])
+}
+
+// resticMaintenanceManualJobName returns the name of the Job of the current
+// manual trigger of rm
+func resticMaintenanceManualJobName(rm *volsyncv1alpha1.ResticMaintenance) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", rm.Namespace, rm.Name, rm.GetManualTrigger())))
+	return fmt.Sprintf("restic-maint-manual-%x", hash[:8])
+}
@@ -0,0 +1,226 @@
+//go:build !disable_restic
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+func newResticMaintenanceFixture(t *testing.T, spec volsyncv1alpha1.ResticMaintenanceSpec) (client.Client,
+	*ResticMaintenanceReconciler, ctrl.Request) {
+	t.Helper()
+	s := scheme.Scheme
+	_ = volsyncv1alpha1.AddToScheme(s)
+
+	repo := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "restic-secret", Namespace: "test-ns"},
+		Data: map[string][]byte{
+			"RESTIC_REPOSITORY": []byte("s3:http://minio/bucket"),
+			"RESTIC_PASSWORD":   []byte("secret"),
+		},
+	}
+	rm := &volsyncv1alpha1.ResticMaintenance{
+		ObjectMeta: metav1.ObjectMeta{Name: "maint", Namespace: "test-ns", UID: "rm-uid"},
+		Spec:       spec,
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(repo, rm).
+		WithStatusSubresource(&volsyncv1alpha1.ResticMaintenance{}, &batchv1.Job{}).
+		Build()
+	r := &ResticMaintenanceReconciler{
+		Client:         c,
+		Scheme:         s,
+		Log:            logr.Discard(),
+		EventRecorder:  record.NewFakeRecorder(10),
+		containerImage: "restic:test",
+	}
+	return c, r, ctrl.Request{NamespacedName: types.NamespacedName{Name: "maint", Namespace: "test-ns"}}
+}
+
+func TestResticMaintenanceCronJob(t *testing.T) {
+	ctx := context.Background()
+	c, r, req := newResticMaintenanceFixture(t, volsyncv1alpha1.ResticMaintenanceSpec{
+		Repository: "restic-secret",
+		Retain:     &volsyncv1alpha1.ResticRetainPolicy{Daily: ptr.To(int32(7))},
+		Check:      &volsyncv1alpha1.ResticMaintenanceCheckSpec{ReadDataSubset: ptr.To("5%")},
+	})
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rm := &volsyncv1alpha1.ResticMaintenance{}
+	if err := c.Get(ctx, req.NamespacedName, rm); err != nil {
+		t.Fatal(err)
+	}
+	cronJob := &batchv1.CronJob{}
+	if err := c.Get(ctx, types.NamespacedName{Name: rm.Status.ActiveCronJob, Namespace: "test-ns"},
+		cronJob); err != nil {
+		t.Fatalf("expected the CronJob to be created: %v", err)
+	}
+	if cronJob.Spec.Schedule != "0 3 * * 0" || cronJob.Spec.ConcurrencyPolicy != batchv1.ForbidConcurrent {
+		t.Errorf("unexpected CronJob spec: %+v", cronJob.Spec)
+	}
+	container := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+	if !slices.Equal(container.Args, []string{"forget", "prune", "check"}) {
+		t.Errorf("unexpected actions: %v", container.Args)
+	}
+	env := map[string]string{}
+	for _, e := range container.Env {
+		env[e.Name] = e.Value
+	}
+	if env["FORGET_OPTIONS"] != " --keep-daily 7" || env["CHECK_READ_DATA_SUBSET"] != "5%" {
+		t.Errorf("unexpected env: %v", env)
+	}
+	if rm.Status.NextScheduledMaintenance == nil ||
+		!apimeta.IsStatusConditionTrue(rm.Status.Conditions, "Ready") {
+		t.Errorf("unexpected status: %+v", rm.Status)
+	}
+
+	// Suspending the maintenance suspends the CronJob
+	rm.Spec.Suspend = ptr.To(true)
+	if err := c.Update(ctx, rm); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(cronJob), cronJob); err != nil {
+		t.Fatal(err)
+	}
+	if !ptr.Deref(cronJob.Spec.Suspend, false) {
+		t.Error("expected the CronJob to be suspended")
+	}
+}
+
+func TestResticMaintenanceManualTrigger(t *testing.T) {
+	ctx := context.Background()
+	c, r, req := newResticMaintenanceFixture(t, volsyncv1alpha1.ResticMaintenanceSpec{
+		Repository: "restic-secret",
+		Trigger:    &volsyncv1alpha1.ResticMaintenanceTriggerSpec{Manual: "once"},
+	})
+
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rm := &volsyncv1alpha1.ResticMaintenance{}
+	if err := c.Get(ctx, req.NamespacedName, rm); err != nil {
+		t.Fatal(err)
+	}
+	job := &batchv1.Job{}
+	if err := c.Get(ctx, types.NamespacedName{Name: resticMaintenanceManualJobName(rm), Namespace: "test-ns"},
+		job); err != nil {
+		t.Fatalf("expected the manual Job to be created: %v", err)
+	}
+	if args := job.Spec.Template.Spec.Containers[0].Args; !slices.Equal(args, []string{"prune"}) {
+		t.Errorf("unexpected actions: %v", args)
+	}
+	if rm.Status.LastManualSync != "" || rm.Status.ActiveCronJob != "" {
+		t.Errorf("unexpected status: %+v", rm.Status)
+	}
+
+	// A failed Job completes the trigger but marks the maintenance unhealthy
+	job.Status.Failed = 1
+	job.Status.Conditions = []batchv1.JobCondition{{
+		Type:               batchv1.JobFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	}}
+	if err := c.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, rm); err != nil {
+		t.Fatal(err)
+	}
+	if rm.Status.LastManualSync != "once" || rm.Status.LastMaintenanceTime != nil ||
+		!apimeta.IsStatusConditionFalse(rm.Status.Conditions, conditionMaintenanceHealthy) {
+		t.Errorf("unexpected status: %+v", rm.Status)
+	}
+
+	// A new trigger replaces the Job of the previous one
+	rm.Spec.Trigger.Manual = "twice"
+	if err := c.Update(ctx, rm); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(job), job); err == nil {
+		t.Error("expected the Job of the previous trigger to be removed")
+	}
+	if err := c.Get(ctx, req.NamespacedName, rm); err != nil {
+		t.Fatal(err)
+	}
+	job = &batchv1.Job{}
+	if err := c.Get(ctx, types.NamespacedName{Name: resticMaintenanceManualJobName(rm), Namespace: "test-ns"},
+		job); err != nil {
+		t.Fatalf("expected a Job for the new trigger: %v", err)
+	}
+	job.Status.Succeeded = 1
+	job.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	if err := c.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, rm); err != nil {
+		t.Fatal(err)
+	}
+	if rm.Status.LastManualSync != "twice" || rm.Status.LastMaintenanceTime == nil ||
+		!apimeta.IsStatusConditionTrue(rm.Status.Conditions, conditionMaintenanceHealthy) {
+		t.Errorf("unexpected status: %+v", rm.Status)
+	}
+}
+
+func TestResticMaintenanceCronJobName(t *testing.T) {
+	rm := &volsyncv1alpha1.ResticMaintenance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "a-very-long-name-for-the-maintenance-of-a-restic-repository",
+			Namespace: "test-ns",
+		},
+	}
+	if name := resticMaintenanceCronJobName(rm); len(name) > 52 {
+		t.Errorf("CronJob name %q is longer than 52 characters", name)
+	}
+}
@@ -0,0 +1,573 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+	sm "github.com/backube/volsync/internal/controller/statemachine"
+	"github.com/backube/volsync/internal/controller/utils"
+	"github.com/backube/volsync/internal/controller/volumehandler"
+)
+
+const (
+	restoreTestNamePrefix        = "volsync-restoretest-"
+	restoreTestDefaultMountPath  = "/data"
+	restoreTestManualTagPrefix   = "restoretest-"
+	restoreTestCleanupRetryDelay = 5 * time.Second
+)
+
+// RestoreTestReconciler reconciles a RestoreTest object
+type RestoreTestReconciler struct {
+	client.Client
+	Log           logr.Logger
+	Scheme        *runtime.Scheme
+	EventRecorder record.EventRecorder
+}
+
+type rtMachine struct {
+	rt            *volsyncv1alpha1.RestoreTest
+	client        client.Client
+	logger        logr.Logger
+	eventRecorder events.EventRecorder
+	metrics       volsyncMetrics
+}
+
+var _ sm.ReplicationMachine = &rtMachine{}
+
+//nolint:lll
+//+kubebuilder:rbac:groups=volsync.backube,resources=restoretests,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=volsync.backube,resources=restoretests/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=volsync.backube,resources=replicationsources,verbs=get;list;watch
+//+kubebuilder:rbac:groups=volsync.backube,resources=replicationdestinations,verbs=get;list;watch;create;update;patch;delete;deletecollection
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete;deletecollection
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete;deletecollection
+//+kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;update;patch
+
+func (r *RestoreTestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Log.WithValues("restoretest", req.NamespacedName)
+	inst := &volsyncv1alpha1.RestoreTest{}
+	if err := r.Get(ctx, req.NamespacedName, inst); err != nil {
+		if !kerrors.IsNotFound(err) {
+			logger.Error(err, "Failed to get RestoreTest")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if inst.Status == nil {
+		inst.Status = &volsyncv1alpha1.RestoreTestStatus{}
+	}
+
+	if inst.Spec.Paused {
+		logger.V(1).Info("RestoreTest is paused")
+		return ctrl.Result{}, nil
+	}
+
+	rtm := newRTMachine(inst, r.Client, logger,
+		record.NewEventRecorderAdapter(mover.NewEventRecorderLogger(r.EventRecorder)))
+	result, err := sm.Run(ctx, rtm, logger)
+
+	// Update instance status
+	statusErr := r.Client.Status().Update(ctx, inst)
+	if err == nil { // Don't mask previous error
+		err = statusErr
+	}
+	return result, err
+}
+
+func (r *RestoreTestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&volsyncv1alpha1.RestoreTest{}).
+		Owns(&volsyncv1alpha1.ReplicationDestination{}).
+		Owns(&batchv1.Job{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Complete(r)
+}
+
+func newRTMachine(rt *volsyncv1alpha1.RestoreTest, c client.Client,
+	l logr.Logger, er events.EventRecorder) *rtMachine {
+	metrics := newVolSyncMetrics(prometheus.Labels{
+		"obj_name":      rt.Name,
+		"obj_namespace": rt.Namespace,
+		"role":          "restoretest",
+		"method":        "restoretest",
+	})
+
+	return &rtMachine{
+		rt:            rt,
+		client:        c,
+		logger:        l,
+		eventRecorder: er,
+		metrics:       metrics,
+	}
+}
+
+func (m *rtMachine) Cronspec() string {
+	if m.rt.Spec.Trigger != nil && m.rt.Spec.Trigger.Schedule != nil {
+		return *m.rt.Spec.Trigger.Schedule
+	}
+	return ""
+}
+
+func (m *rtMachine) ManualTag() string {
+	if m.rt.Spec.Trigger != nil {
+		return m.rt.Spec.Trigger.Manual
+	}
+	return ""
+}
+
+func (m *rtMachine) LastManualTag() string {
+	return m.rt.Status.LastManualTest
+}
+
+func (m *rtMachine) SetLastManualTag(tag string) {
+	m.rt.Status.LastManualTest = tag
+}
+
+func (m *rtMachine) NextSyncTime() *metav1.Time {
+	return m.rt.Status.NextTestTime
+}
+
+func (m *rtMachine) SetNextSyncTime(next *metav1.Time) {
+	m.rt.Status.NextTestTime = next
+}
+
+func (m *rtMachine) LastSyncStartTime() *metav1.Time {
+	return m.rt.Status.LastTestStartTime
+}
+
+func (m *rtMachine) SetLastSyncStartTime(last *metav1.Time) {
+	m.rt.Status.LastTestStartTime = last
+}
+
+func (m *rtMachine) LastSyncTime() *metav1.Time {
+	return m.rt.Status.LastTestTime
+}
+
+func (m *rtMachine) SetLastSyncTime(last *metav1.Time) {
+	m.rt.Status.LastTestTime = last
+}
+
+func (m *rtMachine) LastSyncDuration() *metav1.Duration {
+	return m.rt.Status.LastTestDuration
+}
+
+func (m *rtMachine) SetLastSyncDuration(duration *metav1.Duration) {
+	m.rt.Status.LastTestDuration = duration
+}
+
+func (m *rtMachine) Conditions() *[]metav1.Condition {
+	return &m.rt.Status.Conditions
+}
+
+func (m *rtMachine) CreationTime() metav1.Time {
+	return m.rt.CreationTimestamp
+}
+
+// RestoreTests report the age of their last test in their result
+func (m *rtMachine) MaxStaleness() *metav1.Duration {
+	return nil
+}
+
+func (m *rtMachine) SetStale(isStale bool) {
+	m.metrics.setStale(isStale)
+}
+
+// RestoreTests record failed tests in their result instead of retrying them,
+// so they have no retry policy
+func (m *rtMachine) RetryPolicy() *volsyncv1alpha1.RetryPolicy {
+	return nil
+}
+
+func (m *rtMachine) RetryStatus() *volsyncv1alpha1.RetryStatus {
+	return nil
+}
+
+func (m *rtMachine) SetRetryStatus(_ *volsyncv1alpha1.RetryStatus) {}
+
+// RestoreTests only keep the result of their last test, so they have no
+// history
+func (m *rtMachine) SyncHistory() *[]volsyncv1alpha1.SyncHistoryEntry {
+	return &[]volsyncv1alpha1.SyncHistoryEntry{}
+}
+
+func (m *rtMachine) SyncHistoryLimit() int {
+	return 0
+}
+
+func (m *rtMachine) NewSyncHistoryEntry() volsyncv1alpha1.SyncHistoryEntry {
+	return volsyncv1alpha1.SyncHistoryEntry{}
+}
+
+// RestoreTests report their results in their status and events instead
+func (m *rtMachine) Notify(_ volsyncv1alpha1.NotificationEventType, _ *mover.AttemptFailure) {
+}
+
+func (m *rtMachine) SetOutOfSync(isOutOfSync bool) {
+	if isOutOfSync {
+		m.metrics.OutOfSync.Set(1)
+	} else {
+		m.metrics.OutOfSync.Set(0)
+	}
+}
+
+func (m *rtMachine) IncMissedIntervals() {
+	m.metrics.MissedIntervals.Inc()
+}
+
+func (m *rtMachine) ObserveSyncDuration(duration time.Duration) {
+	m.metrics.observeSyncDuration(duration)
+}
+
+// Synchronize runs one restore test: the latest backup of the source is
+// restored into a temporary PVC by a temporary ReplicationDestination, then the
+// checker is run against the PVC. Failing to restore or check the data
+// completes the test with a failed result.
+func (m *rtMachine) Synchronize(ctx context.Context) (mover.Result, error) {
+	rs := &volsyncv1alpha1.ReplicationSource{}
+	rsName := types.NamespacedName{Name: m.rt.Spec.SourceName, Namespace: m.rt.Namespace}
+	if err := m.client.Get(ctx, rsName, rs); err != nil {
+		m.logger.Error(err, "unable to get ReplicationSource", "name", rsName)
+		return mover.InProgress(), err
+	}
+
+	pvc, err := m.ensureRestorePVC(ctx, rs)
+	if pvc == nil || err != nil {
+		return mover.InProgress(), err
+	}
+
+	rd, err := m.ensureReplicationDestination(ctx, rs, pvc)
+	if rd == nil || err != nil {
+		return mover.InProgress(), err
+	}
+	if rd.Status == nil || rd.Status.LatestMoverStatus == nil {
+		return mover.InProgress(), nil
+	}
+	if rd.Status.LatestMoverStatus.Result == volsyncv1alpha1.MoverResultFailed {
+		m.recordResult(volsyncv1alpha1.RestoreTestResultFailed, "",
+			"restore failed: "+rd.Status.LatestMoverStatus.Logs)
+		return mover.Complete(), nil
+	}
+	if rd.Status.LastManualSync != m.restoreTag() {
+		// The restore hasn't completed yet
+		return mover.InProgress(), nil
+	}
+	snapshotID := rd.Status.LatestMoverStatus.SnapshotID
+
+	job, err := m.ensureCheckerJob(ctx, pvc)
+	if job == nil || err != nil {
+		return mover.InProgress(), err
+	}
+	complete, failed := restoreTestJobState(job)
+	switch {
+	case failed != nil:
+		m.recordResult(volsyncv1alpha1.RestoreTestResultFailed, snapshotID,
+			fmt.Sprintf("checker failed: %s", failed.Message))
+		return mover.Complete(), nil
+	case complete:
+		m.recordResult(volsyncv1alpha1.RestoreTestResultPassed, snapshotID, "")
+		return mover.Complete(), nil
+	}
+	return mover.InProgress(), nil
+}
+
+// Cleanup deletes the temporary ReplicationDestination, PVC and checker Job,
+// and waits for them to be gone so the next test starts from scratch.
+func (m *rtMachine) Cleanup(ctx context.Context) (mover.Result, error) {
+	err := utils.CleanupObjects(ctx, m.client, m.logger, m.rt, []client.Object{
+		&batchv1.Job{},
+		&volsyncv1alpha1.ReplicationDestination{},
+		&corev1.PersistentVolumeClaim{},
+	})
+	if err != nil {
+		return mover.InProgress(), err
+	}
+
+	for _, obj := range []client.Object{
+		&volsyncv1alpha1.ReplicationDestination{},
+		&corev1.PersistentVolumeClaim{},
+	} {
+		err := m.client.Get(ctx, types.NamespacedName{Name: m.objectName(), Namespace: m.rt.Namespace}, obj)
+		if err == nil {
+			return mover.RetryAfter(restoreTestCleanupRetryDelay), nil
+		}
+		if !kerrors.IsNotFound(err) {
+			return mover.InProgress(), err
+		}
+	}
+	return mover.Complete(), nil
+}
+
+// objectName is the name of the temporary ReplicationDestination and PVC
+func (m *rtMachine) objectName() string {
+	return restoreTestNamePrefix + m.rt.Name
+}
+
+// restoreTag is the manual trigger used to run the restore of the current test
+func (m *rtMachine) restoreTag() string {
+	return restoreTestManualTagPrefix + strconv.FormatInt(m.rt.Status.LastTestStartTime.Unix(), 10)
+}
+
+// recordResult saves the result of the current test in the status
+func (m *rtMachine) recordResult(result volsyncv1alpha1.RestoreTestResultType, snapshotID, message string) {
+	now := metav1.Now()
+	m.rt.Status.LastResult = &volsyncv1alpha1.RestoreTestResult{
+		Result:         result,
+		SnapshotID:     snapshotID,
+		StartTime:      m.rt.Status.LastTestStartTime.DeepCopy(),
+		CompletionTime: &now,
+		Duration:       &metav1.Duration{Duration: now.Sub(m.rt.Status.LastTestStartTime.Time)},
+		Message:        message,
+	}
+
+	if result == volsyncv1alpha1.RestoreTestResultPassed {
+		m.logger.Info("restore test passed", "snapshotID", snapshotID)
+		m.eventRecorder.Eventf(m.rt, nil, corev1.EventTypeNormal,
+			volsyncv1alpha1.EvRRestoreTestPassed, volsyncv1alpha1.EvANone,
+			"restored snapshot %s and passed the checker", snapshotID)
+		return
+	}
+	m.logger.Info("restore test failed", "snapshotID", snapshotID, "message", message)
+	m.eventRecorder.Eventf(m.rt, nil, corev1.EventTypeWarning,
+		volsyncv1alpha1.EvRRestoreTestFailed, volsyncv1alpha1.EvANone,
+		"restore test failed: %s", message)
+}
+
+// ensureRestorePVC ensures the temporary PVC the backup is restored into. Its
+// size, StorageClass and access modes default to those of the source PVC.
+func (m *rtMachine) ensureRestorePVC(ctx context.Context,
+	rs *volsyncv1alpha1.ReplicationSource) (*corev1.PersistentVolumeClaim, error) {
+	capacity := m.rt.Spec.Capacity
+	storageClassName := m.rt.Spec.StorageClassName
+	accessModes := m.rt.Spec.AccessModes
+	if capacity == nil || storageClassName == nil || len(accessModes) == 0 {
+		srcPVC := &corev1.PersistentVolumeClaim{}
+		err := m.client.Get(ctx, types.NamespacedName{Name: rs.Spec.SourcePVC, Namespace: rs.Namespace}, srcPVC)
+		if err != nil {
+			m.logger.Error(err, "unable to get source PVC", "name", rs.Spec.SourcePVC)
+			return nil, err
+		}
+		if capacity == nil {
+			if size, ok := srcPVC.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+				capacity = &size
+			}
+		}
+		if storageClassName == nil {
+			storageClassName = srcPVC.Spec.StorageClassName
+		}
+		if len(accessModes) == 0 {
+			accessModes = srcPVC.Spec.AccessModes
+		}
+	}
+
+	vh, err := volumehandler.NewVolumeHandler(
+		volumehandler.WithClient(m.client),
+		volumehandler.WithRecorder(m.eventRecorder),
+		volumehandler.WithOwner(m.rt),
+		volumehandler.Capacity(capacity),
+		volumehandler.StorageClassName(storageClassName),
+		volumehandler.AccessModes(accessModes),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return vh.EnsureNewPVC(ctx, m.logger, m.objectName(), true)
+}
+
+// ensureReplicationDestination ensures the temporary ReplicationDestination
+// that restores the latest backup of the source into the PVC
+func (m *rtMachine) ensureReplicationDestination(ctx context.Context, rs *volsyncv1alpha1.ReplicationSource,
+	pvc *corev1.PersistentVolumeClaim) (*volsyncv1alpha1.ReplicationDestination, error) {
+	rd := &volsyncv1alpha1.ReplicationDestination{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.objectName(),
+			Namespace: m.rt.Namespace,
+		},
+	}
+	logger := m.logger.WithValues("replicationdestination", client.ObjectKeyFromObject(rd))
+
+	op, err := ctrlutil.CreateOrUpdate(ctx, m.client, rd, func() error {
+		if !rd.DeletionTimestamp.IsZero() {
+			return errors.New("ReplicationDestination of the previous test is still being deleted")
+		}
+		if err := ctrl.SetControllerReference(m.rt, rd, m.client.Scheme()); err != nil {
+			logger.Error(err, utils.ErrUnableToSetControllerRef)
+			return err
+		}
+		utils.SetOwnedByVolSync(rd)
+		utils.MarkForCleanup(m.rt, rd)
+		return restoreTestDestinationSpec(rs, pvc.Name, m.restoreTag(), &rd.Spec)
+	})
+	if err != nil {
+		logger.Error(err, "reconcile failed")
+		return nil, err
+	}
+	logger.V(1).Info("ReplicationDestination reconciled", "operation", op)
+	return rd, nil
+}
+
+// restoreTestDestinationSpec fills in the spec of a ReplicationDestination
+// that restores the latest backup of the source into the named PVC
+func restoreTestDestinationSpec(rs *volsyncv1alpha1.ReplicationSource, pvcName, tag string,
+	spec *volsyncv1alpha1.ReplicationDestinationSpec) error {
+	volumeOptions := volsyncv1alpha1.ReplicationDestinationVolumeOptions{
+		CopyMethod:     volsyncv1alpha1.CopyMethodDirect,
+		DestinationPVC: &pvcName,
+	}
+	spec.Trigger = &volsyncv1alpha1.ReplicationDestinationTriggerSpec{Manual: tag}
+
+	switch {
+	case rs.Spec.Kopia != nil:
+		src := rs.Spec.Kopia
+		spec.Kopia = &volsyncv1alpha1.ReplicationDestinationKopiaSpec{
+			ReplicationDestinationVolumeOptions: volumeOptions,
+			Repository:                          src.Repository,
+			CustomCA:                            volsyncv1alpha1.ReplicationDestinationKopiaCA(src.CustomCA),
+			CacheCapacity:                       src.CacheCapacity,
+			CacheStorageClassName:               src.CacheStorageClassName,
+			CacheAccessModes:                    src.CacheAccessModes,
+			CleanupCachePVC:                     true,
+			SourceIdentity: &volsyncv1alpha1.KopiaSourceIdentity{
+				SourceName:      rs.Name,
+				SourceNamespace: rs.Namespace,
+			},
+			Username:    src.Username,
+			Hostname:    src.Hostname,
+			MoverConfig: src.MoverConfig,
+		}
+	case rs.Spec.Restic != nil:
+		src := rs.Spec.Restic
+		spec.Restic = &volsyncv1alpha1.ReplicationDestinationResticSpec{
+			ReplicationDestinationVolumeOptions: volumeOptions,
+			Repository:                          src.Repository,
+			CustomCA:                            volsyncv1alpha1.ReplicationDestinationResticCA(src.CustomCA),
+			CacheCapacity:                       src.CacheCapacity,
+			CacheStorageClassName:               src.CacheStorageClassName,
+			CacheAccessModes:                    src.CacheAccessModes,
+			CleanupCachePVC:                     true,
+			MoverConfig:                         src.MoverConfig,
+		}
+	default:
+		return fmt.Errorf("ReplicationSource %s must use the restic or kopia mover to be tested", rs.Name)
+	}
+	return nil
+}
+
+// ensureCheckerJob ensures the Job running the checker against the restored
+// data
+func (m *rtMachine) ensureCheckerJob(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (*batchv1.Job, error) {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GetJobName(restoreTestNamePrefix, m.rt),
+			Namespace: m.rt.Namespace,
+		},
+	}
+	logger := m.logger.WithValues("job", client.ObjectKeyFromObject(job))
+
+	op, err := utils.CreateOrUpdateDeleteOnImmutableErr(ctx, m.client, job, logger, func() error {
+		if err := ctrl.SetControllerReference(m.rt, job, m.client.Scheme()); err != nil {
+			logger.Error(err, utils.ErrUnableToSetControllerRef)
+			return err
+		}
+		utils.SetOwnedByVolSync(job)
+		utils.MarkForCleanup(m.rt, job)
+		restoreTestCheckerJobSpec(&m.rt.Spec.Checker, pvc.Name, job)
+		return nil
+	})
+	if err != nil {
+		logger.Error(err, "reconcile failed")
+		return nil, err
+	}
+	logger.V(1).Info("Job reconciled", "operation", op)
+	return job, nil
+}
+
+// restoreTestCheckerJobSpec fills in the spec of the checker Job. The checker
+// isn't retried: a failing check is a failed test.
+func restoreTestCheckerJobSpec(checker *volsyncv1alpha1.RestoreTestCheckerSpec, pvcName string, job *batchv1.Job) {
+	mountPath := checker.MountPath
+	if mountPath == "" {
+		mountPath = restoreTestDefaultMountPath
+	}
+
+	job.Spec.BackoffLimit = ptr.To(int32(0))
+	utils.SetOwnedByVolSync(&job.Spec.Template)
+	podSpec := &job.Spec.Template.Spec
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+	podSpec.SecurityContext = checker.PodSecurityContext
+	podSpec.ServiceAccountName = ptr.Deref(checker.ServiceAccountName, "")
+	podSpec.Containers = []corev1.Container{{
+		Name:    "checker",
+		Image:   checker.Image,
+		Command: checker.Command,
+		Args:    checker.Args,
+		Env:     checker.Env,
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "data",
+			MountPath: mountPath,
+		}},
+	}}
+	if checker.Resources != nil {
+		podSpec.Containers[0].Resources = *checker.Resources
+	}
+	podSpec.Volumes = []corev1.Volume{{
+		Name: "data",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: pvcName,
+			},
+		},
+	}}
+}
+
+// restoreTestJobState returns whether the Job completed and, if it failed,
+// its JobFailed condition
+func restoreTestJobState(job *batchv1.Job) (complete bool, failed *batchv1.JobCondition) {
+	for i, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			complete = true
+		case batchv1.JobFailed:
+			failed = &job.Status.Conditions[i]
+		}
+	}
+	return complete, failed
+}
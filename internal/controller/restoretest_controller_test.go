@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRestoreTestDestinationSpec(t *testing.T) {
+	rs := &volsyncv1alpha1.ReplicationSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "test-ns"},
+		Spec: volsyncv1alpha1.ReplicationSourceSpec{
+			SourcePVC: "data",
+			Kopia: &volsyncv1alpha1.ReplicationSourceKopiaSpec{
+				Repository: "kopia-secret",
+				Username:   ptr.To("app-user"),
+				MoverConfig: volsyncv1alpha1.MoverConfig{
+					MoverServiceAccount: ptr.To("backup-sa"),
+				},
+			},
+		},
+	}
+
+	spec := volsyncv1alpha1.ReplicationDestinationSpec{}
+	if err := restoreTestDestinationSpec(rs, "restore-pvc", "tag-1", &spec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.Trigger == nil || spec.Trigger.Manual != "tag-1" {
+		t.Errorf("expected a manual trigger, got %+v", spec.Trigger)
+	}
+	kopia := spec.Kopia
+	if kopia == nil {
+		t.Fatal("expected a kopia spec")
+	}
+	if kopia.Repository != "kopia-secret" || kopia.CopyMethod != volsyncv1alpha1.CopyMethodDirect ||
+		ptr.Deref(kopia.DestinationPVC, "") != "restore-pvc" {
+		t.Errorf("unexpected kopia spec: %+v", kopia)
+	}
+	if kopia.SourceIdentity == nil || kopia.SourceIdentity.SourceName != "app" ||
+		kopia.SourceIdentity.SourceNamespace != "test-ns" {
+		t.Errorf("expected the identity of the source, got %+v", kopia.SourceIdentity)
+	}
+	if ptr.Deref(kopia.Username, "") != "app-user" || ptr.Deref(kopia.MoverServiceAccount, "") != "backup-sa" {
+		t.Errorf("expected the username and mover config of the source, got %+v", kopia)
+	}
+
+	rs.Spec.Kopia = nil
+	rs.Spec.Restic = &volsyncv1alpha1.ReplicationSourceResticSpec{Repository: "restic-secret"}
+	spec = volsyncv1alpha1.ReplicationDestinationSpec{}
+	if err := restoreTestDestinationSpec(rs, "restore-pvc", "tag-1", &spec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.Restic == nil || spec.Restic.Repository != "restic-secret" ||
+		ptr.Deref(spec.Restic.DestinationPVC, "") != "restore-pvc" {
+		t.Errorf("unexpected restic spec: %+v", spec.Restic)
+	}
+
+	rs.Spec.Restic = nil
+	rs.Spec.RsyncTLS = &volsyncv1alpha1.ReplicationSourceRsyncTLSSpec{}
+	if err := restoreTestDestinationSpec(rs, "restore-pvc", "tag-1", &spec); err == nil {
+		t.Error("expected an error for an unsupported mover")
+	}
+}
+
+func TestRestoreTestCheckerJobSpec(t *testing.T) {
+	job := &batchv1.Job{}
+	restoreTestCheckerJobSpec(&volsyncv1alpha1.RestoreTestCheckerSpec{
+		Image: "checker:latest",
+		Args:  []string{"--verify"},
+	}, "restore-pvc", job)
+
+	if ptr.Deref(job.Spec.BackoffLimit, -1) != 0 {
+		t.Errorf("expected the checker not to be retried, got backoffLimit %v", job.Spec.BackoffLimit)
+	}
+	podSpec := job.Spec.Template.Spec
+	if len(podSpec.Containers) != 1 || podSpec.Containers[0].Image != "checker:latest" {
+		t.Fatalf("unexpected containers: %+v", podSpec.Containers)
+	}
+	mounts := podSpec.Containers[0].VolumeMounts
+	if len(mounts) != 1 || mounts[0].MountPath != restoreTestDefaultMountPath {
+		t.Errorf("expected the PVC to be mounted at %s, got %+v", restoreTestDefaultMountPath, mounts)
+	}
+	if len(podSpec.Volumes) != 1 || podSpec.Volumes[0].PersistentVolumeClaim == nil ||
+		podSpec.Volumes[0].PersistentVolumeClaim.ClaimName != "restore-pvc" {
+		t.Errorf("unexpected volumes: %+v", podSpec.Volumes)
+	}
+}
+
+func newRestoreTestFixture(t *testing.T) (client.Client, *rtMachine) {
+	t.Helper()
+	s := scheme.Scheme
+	_ = volsyncv1alpha1.AddToScheme(s)
+
+	srcPVC := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "test-ns"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: ptr.To("fast"),
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+	}
+	rs := &volsyncv1alpha1.ReplicationSource{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "test-ns"},
+		Spec: volsyncv1alpha1.ReplicationSourceSpec{
+			SourcePVC: "data",
+			Kopia:     &volsyncv1alpha1.ReplicationSourceKopiaSpec{Repository: "kopia-secret"},
+		},
+	}
+	rt := &volsyncv1alpha1.RestoreTest{
+		ObjectMeta: metav1.ObjectMeta{Name: "drill", Namespace: "test-ns", UID: "rt-uid"},
+		Spec: volsyncv1alpha1.RestoreTestSpec{
+			SourceName: "app",
+			Checker:    volsyncv1alpha1.RestoreTestCheckerSpec{Image: "checker:latest"},
+		},
+		Status: &volsyncv1alpha1.RestoreTestStatus{
+			LastTestStartTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(srcPVC, rs, rt).
+		WithStatusSubresource(&volsyncv1alpha1.ReplicationDestination{}, &batchv1.Job{}).
+		Build()
+	return c, newRTMachine(rt, c, logr.Discard(), &events.FakeRecorder{})
+}
+
+func TestRTMachine_Synchronize(t *testing.T) {
+	ctx := context.Background()
+	c, m := newRestoreTestFixture(t)
+
+	result, err := m.Synchronize(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Completed {
+		t.Fatal("expected the test to wait for the restore")
+	}
+
+	key := types.NamespacedName{Name: m.objectName(), Namespace: "test-ns"}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := c.Get(ctx, key, pvc); err != nil {
+		t.Fatalf("expected the temporary PVC to be created: %v", err)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "5Gi" ||
+		ptr.Deref(pvc.Spec.StorageClassName, "") != "fast" {
+		t.Errorf("expected the PVC to default to the source PVC, got %+v", pvc.Spec)
+	}
+	rd := &volsyncv1alpha1.ReplicationDestination{}
+	if err := c.Get(ctx, key, rd); err != nil {
+		t.Fatalf("expected the ReplicationDestination to be created: %v", err)
+	}
+	if rd.Spec.Trigger == nil || rd.Spec.Trigger.Manual != m.restoreTag() {
+		t.Errorf("expected the restore to be triggered manually, got %+v", rd.Spec.Trigger)
+	}
+
+	// Complete the restore
+	rd.Status = &volsyncv1alpha1.ReplicationDestinationStatus{
+		LastManualSync: m.restoreTag(),
+		LatestMoverStatus: &volsyncv1alpha1.MoverStatus{
+			Result:     volsyncv1alpha1.MoverResultSuccessful,
+			Logs:       "Selected snapshot with id: k3f2a9c1",
+			SnapshotID: "k3f2a9c1",
+		},
+	}
+	if err := c.Status().Update(ctx, rd); err != nil {
+		t.Fatal(err)
+	}
+	result, err = m.Synchronize(ctx)
+	if err != nil || result.Completed {
+		t.Fatalf("expected the test to wait for the checker, got %+v, %v", result, err)
+	}
+
+	job := &batchv1.Job{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "volsync-restoretest-drill", Namespace: "test-ns"}, job); err != nil {
+		t.Fatalf("expected the checker job to be created: %v", err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := c.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	result, err = m.Synchronize(ctx)
+	if err != nil || !result.Completed {
+		t.Fatalf("expected the test to complete, got %+v, %v", result, err)
+	}
+
+	last := m.rt.Status.LastResult
+	if last == nil || last.Result != volsyncv1alpha1.RestoreTestResultPassed || last.SnapshotID != "k3f2a9c1" {
+		t.Fatalf("unexpected result: %+v", last)
+	}
+	if last.Duration == nil || last.Duration.Duration < time.Minute {
+		t.Errorf("expected the duration of the test, got %v", last.Duration)
+	}
+
+	result, err = m.Cleanup(ctx)
+	if err != nil || !result.Completed {
+		t.Fatalf("expected the cleanup to complete, got %+v, %v", result, err)
+	}
+	for _, obj := range []client.Object{&volsyncv1alpha1.ReplicationDestination{}, &corev1.PersistentVolumeClaim{}} {
+		if err := c.Get(ctx, key, obj); !kerrors.IsNotFound(err) {
+			t.Errorf("expected %T to be deleted, got %v", obj, err)
+		}
+	}
+}
+
+func TestRTMachine_SynchronizeRestoreFailed(t *testing.T) {
+	ctx := context.Background()
+	c, m := newRestoreTestFixture(t)
+
+	if _, err := m.Synchronize(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rd := &volsyncv1alpha1.ReplicationDestination{}
+	if err := c.Get(ctx, types.NamespacedName{Name: m.objectName(), Namespace: "test-ns"}, rd); err != nil {
+		t.Fatal(err)
+	}
+	rd.Status = &volsyncv1alpha1.ReplicationDestinationStatus{
+		LatestMoverStatus: &volsyncv1alpha1.MoverStatus{
+			Result: volsyncv1alpha1.MoverResultFailed,
+			Logs:   "ERROR: unable to connect to repository",
+		},
+	}
+	if err := c.Status().Update(ctx, rd); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := m.Synchronize(ctx)
+	if err != nil || !result.Completed {
+		t.Fatalf("expected the failed test to complete, got %+v, %v", result, err)
+	}
+	last := m.rt.Status.LastResult
+	if last == nil || last.Result != volsyncv1alpha1.RestoreTestResultFailed ||
+		!strings.Contains(last.Message, "unable to connect") {
+		t.Errorf("unexpected result: %+v", last)
+	}
+}
@@ -18,6 +18,9 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package statemachine
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/go-logr/logr"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -74,3 +77,40 @@ func setConditionCleanup(r ReplicationMachine, _ logr.Logger) {
 			Message: "Cleanup in-progress",
 		})
 }
+
+func setConditionRetryPending(r ReplicationMachine, _ logr.Logger, status *volsyncv1alpha1.RetryStatus) {
+	apimeta.SetStatusCondition(r.Conditions(),
+		metav1.Condition{
+			Type:   volsyncv1alpha1.ConditionSynchronizing,
+			Status: metav1.ConditionTrue,
+			Reason: volsyncv1alpha1.SynchronizingReasonRetry,
+			Message: fmt.Sprintf("Attempt %d failed (%s), retrying at %s", status.Attempts,
+				status.LastFailureClass, status.NextRetryTime.UTC().Format(time.RFC3339)),
+		})
+}
+
+func setConditionRetriesExhausted(r ReplicationMachine, _ logr.Logger, reason string, message string) {
+	apimeta.SetStatusCondition(r.Conditions(),
+		metav1.Condition{
+			Type:    volsyncv1alpha1.ConditionRetriesExhausted,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
+		})
+}
+
+// clearConditionRetriesExhausted sets the RetriesExhausted condition to False
+// once a synchronization succeeds. The condition is only added to objects
+// whose retries have been exhausted in the past.
+func clearConditionRetriesExhausted(r ReplicationMachine, _ logr.Logger) {
+	if apimeta.FindStatusCondition(*r.Conditions(), volsyncv1alpha1.ConditionRetriesExhausted) == nil {
+		return
+	}
+	apimeta.SetStatusCondition(r.Conditions(),
+		metav1.Condition{
+			Type:    volsyncv1alpha1.ConditionRetriesExhausted,
+			Status:  metav1.ConditionFalse,
+			Reason:  volsyncv1alpha1.RetriesExhaustedReasonSyncSucceeded,
+			Message: "Synchronization succeeded",
+		})
+}
@@ -23,6 +23,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
 	"github.com/backube/volsync/internal/controller/mover"
 )
 
@@ -37,6 +38,15 @@ type fakeMachine struct {
 	LST                 *metav1.Time
 	LSD                 *metav1.Duration
 	Cond                []metav1.Condition
+	Created             metav1.Time
+	MS                  *metav1.Duration
+	Stale               bool
+	RP                  *volsyncv1alpha1.RetryPolicy
+	RS                  *volsyncv1alpha1.RetryStatus
+	Notifications       []volsyncv1alpha1.NotificationEventType
+	History             []volsyncv1alpha1.SyncHistoryEntry
+	HistoryLimit        int
+	HistoryEntry        volsyncv1alpha1.SyncHistoryEntry
 	OOSync              bool
 	MissedIntervals     int
 	DurationObservation time.Duration
@@ -51,6 +61,8 @@ var _ ReplicationMachine = &fakeMachine{}
 func newFakeMachine() *fakeMachine {
 	return &fakeMachine{
 		TT:            noTrigger,
+		Created:       metav1.Now(),
+		HistoryLimit:  volsyncv1alpha1.DefaultSyncHistoryLimit,
 		SyncResult:    mover.Complete(),
 		CleanupResult: mover.Complete(),
 	}
@@ -69,6 +81,9 @@ func (f *fakeMachine) SetLastSyncTime(t *metav1.Time)         { f.LST = t }
 func (f *fakeMachine) LastSyncDuration() *metav1.Duration     { return f.LSD }
 func (f *fakeMachine) SetLastSyncDuration(d *metav1.Duration) { f.LSD = d }
 func (f *fakeMachine) Conditions() *[]metav1.Condition        { return &f.Cond }
+func (f *fakeMachine) CreationTime() metav1.Time              { return f.Created }
+func (f *fakeMachine) MaxStaleness() *metav1.Duration         { return f.MS }
+func (f *fakeMachine) SetStale(stale bool)                    { f.Stale = stale }
 func (f *fakeMachine) SetOutOfSync(oos bool)                  { f.OOSync = oos }
 func (f *fakeMachine) IncMissedIntervals()                    { f.MissedIntervals++ }
 func (f *fakeMachine) ObserveSyncDuration(t time.Duration)    { f.DurationObservation = t }
@@ -78,3 +93,24 @@ func (f *fakeMachine) Synchronize(_ context.Context) (mover.Result, error) {
 func (f *fakeMachine) Cleanup(_ context.Context) (mover.Result, error) {
 	return f.CleanupResult, f.CleanupError
 }
+func (f *fakeMachine) RetryPolicy() *volsyncv1alpha1.RetryPolicy {
+	return f.RP
+}
+func (f *fakeMachine) RetryStatus() *volsyncv1alpha1.RetryStatus {
+	return f.RS
+}
+func (f *fakeMachine) SetRetryStatus(s *volsyncv1alpha1.RetryStatus) {
+	f.RS = s
+}
+func (f *fakeMachine) SyncHistory() *[]volsyncv1alpha1.SyncHistoryEntry {
+	return &f.History
+}
+func (f *fakeMachine) SyncHistoryLimit() int {
+	return f.HistoryLimit
+}
+func (f *fakeMachine) NewSyncHistoryEntry() volsyncv1alpha1.SyncHistoryEntry {
+	return f.HistoryEntry
+}
+func (f *fakeMachine) Notify(e volsyncv1alpha1.NotificationEventType, _ *mover.AttemptFailure) {
+	f.Notifications = append(f.Notifications, e)
+}
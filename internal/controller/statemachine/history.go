@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package statemachine
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+)
+
+// maxHistoryErrorLength is the length beyond which the error of a history
+// entry is truncated
+const maxHistoryErrorLength = 256
+
+// recordSyncHistory adds the synchronization that just ended to the history,
+// dropping the oldest entries beyond the limit. The failure is only set for
+// failed attempts, which are each recorded whether or not they are retried.
+func recordSyncHistory(r ReplicationMachine, end metav1.Time, failure *mover.AttemptFailure) {
+	history := r.SyncHistory()
+	limit := r.SyncHistoryLimit()
+	if limit <= 0 {
+		*history = nil
+		return
+	}
+
+	details := r.NewSyncHistoryEntry()
+	entry := volsyncv1alpha1.SyncHistoryEntry{
+		StartTime: r.LastSyncStartTime(),
+		EndTime:   &end,
+		Result:    volsyncv1alpha1.MoverResultSuccessful,
+		Mover:     details.Mover,
+	}
+	if r.RetryStatus() != nil {
+		entry.Attempts = r.RetryStatus().Attempts
+	}
+	if failure == nil {
+		entry.BytesTransferred = details.BytesTransferred
+		entry.SnapshotID = details.SnapshotID
+		entry.Image = details.Image
+	} else {
+		entry.Result = volsyncv1alpha1.MoverResultFailed
+		entry.Error = shortError(failure)
+	}
+
+	*history = append(*history, entry)
+	if len(*history) > limit {
+		*history = append([]volsyncv1alpha1.SyncHistoryEntry(nil), (*history)[len(*history)-limit:]...)
+	}
+}
+
+// shortError describes a failure in at most maxHistoryErrorLength bytes
+func shortError(failure *mover.AttemptFailure) string {
+	msg := fmt.Sprintf("%s: %s", failure.Class, failure.Message)
+	if len(msg) > maxHistoryErrorLength {
+		msg = msg[:maxHistoryErrorLength-3] + "..."
+	}
+	return msg
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package statemachine
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+)
+
+var _ = Describe("Synchronization history", func() {
+	var m *fakeMachine
+	// Runs a whole synchronization, from the end of the previous cleanup
+	runSync := func() {
+		for range 2 {
+			_, err := Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	}
+
+	BeforeEach(func() {
+		m = newFakeMachine()
+		m.HistoryEntry = volsyncv1alpha1.SyncHistoryEntry{
+			Mover:            "restic",
+			BytesTransferred: ptr.To(int64(1024)),
+			SnapshotID:       "1a2b3c4d",
+		}
+		// To Sync
+		_, err := Run(ctx, m, logger)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("records successful synchronizations", func() {
+		_, err := Run(ctx, m, logger)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(currentState(m)).To(Equal(cleaningUpState))
+		Expect(m.History).To(HaveLen(1))
+		entry := m.History[0]
+		Expect(entry.Result).To(Equal(volsyncv1alpha1.MoverResultSuccessful))
+		Expect(entry.Mover).To(Equal("restic"))
+		Expect(*entry.BytesTransferred).To(Equal(int64(1024)))
+		Expect(entry.SnapshotID).To(Equal("1a2b3c4d"))
+		Expect(entry.StartTime).NotTo(BeNil())
+		Expect(entry.EndTime.Time).To(Equal(m.LST.Time))
+		Expect(entry.Error).To(BeEmpty())
+	})
+
+	It("records each failed attempt without a retry policy", func() {
+		m.SyncResult = mover.Failed(mover.AttemptFailure{
+			Class:   volsyncv1alpha1.FailureClassTransient,
+			Message: "mover job failed",
+		})
+		for range 2 {
+			_, err := Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(currentState(m)).To(Equal(synchronizingState))
+		}
+		Expect(m.History).To(HaveLen(2))
+		for i, entry := range m.History {
+			Expect(entry.Result).To(Equal(volsyncv1alpha1.MoverResultFailed))
+			Expect(entry.Attempts).To(Equal(int32(i + 1)))
+			Expect(entry.Error).To(Equal("Transient: mover job failed"))
+		}
+
+		m.SyncResult = mover.Complete()
+		_, err := Run(ctx, m, logger)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(m.History).To(HaveLen(3))
+		Expect(m.History[2].Result).To(Equal(volsyncv1alpha1.MoverResultSuccessful))
+		Expect(m.History[2].Attempts).To(Equal(int32(2)))
+	})
+
+	It("records abandoned synchronizations once", func() {
+		m.RP = &volsyncv1alpha1.RetryPolicy{MaxAttempts: ptr.To(int32(1))}
+		m.SyncResult = mover.Failed(mover.AttemptFailure{
+			Class:   volsyncv1alpha1.FailureClassAuth,
+			Message: "mover job failed: " + strings.Repeat("x", 300),
+		})
+		_, err := Run(ctx, m, logger)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(currentState(m)).To(Equal(cleaningUpState))
+		Expect(m.History).To(HaveLen(1))
+		entry := m.History[0]
+		Expect(entry.Result).To(Equal(volsyncv1alpha1.MoverResultFailed))
+		Expect(entry.Mover).To(Equal("restic"))
+		Expect(entry.Attempts).To(Equal(int32(1)))
+		Expect(entry.SnapshotID).To(BeEmpty())
+		Expect(entry.Error).To(HavePrefix("Auth: mover job failed: xxx"))
+		Expect(entry.Error).To(HaveLen(maxHistoryErrorLength))
+	})
+
+	It("keeps the most recent synchronizations", func() {
+		m.HistoryLimit = 3
+		_, err := Run(ctx, m, logger)
+		Expect(err).ToNot(HaveOccurred())
+		for i := range 4 {
+			m.HistoryEntry.SnapshotID = string(rune('a' + i))
+			runSync()
+		}
+		Expect(m.History).To(HaveLen(3))
+		Expect(m.History[0].SnapshotID).To(Equal("b"))
+		Expect(m.History[2].SnapshotID).To(Equal("d"))
+
+		m.HistoryLimit = 0
+		runSync()
+		Expect(m.History).To(BeEmpty())
+	})
+})
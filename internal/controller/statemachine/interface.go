@@ -23,6 +23,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
 	"github.com/backube/volsync/internal/controller/mover"
 )
 
@@ -49,10 +50,33 @@ type ReplicationMachine interface {
 
 	Conditions() *[]metav1.Condition
 
+	// CreationTime is when the object was created, which is when a
+	// never-synchronized object starts becoming stale
+	CreationTime() metav1.Time
+	// MaxStaleness is how old the last successful synchronization may be, or
+	// nil if staleness isn't reported
+	MaxStaleness() *metav1.Duration
+	SetStale(bool)
+
+	RetryPolicy() *volsyncv1alpha1.RetryPolicy
+	RetryStatus() *volsyncv1alpha1.RetryStatus
+	SetRetryStatus(*volsyncv1alpha1.RetryStatus)
+
+	SyncHistory() *[]volsyncv1alpha1.SyncHistoryEntry
+	SyncHistoryLimit() int
+	// NewSyncHistoryEntry returns a history entry with the details reported
+	// by the mover of the synchronization that just ended
+	NewSyncHistoryEntry() volsyncv1alpha1.SyncHistoryEntry
+
 	SetOutOfSync(bool)
 	IncMissedIntervals()
 	ObserveSyncDuration(time.Duration)
 
+	// Notify queues a synchronization event for the notification channels. The
+	// event is sent once the status has been saved, so it isn't repeated if
+	// saving it fails. The failure is only set for failure events.
+	Notify(event volsyncv1alpha1.NotificationEventType, failure *mover.AttemptFailure)
+
 	Synchronize(ctx context.Context) (mover.Result, error)
 	Cleanup(ctx context.Context) (mover.Result, error)
 }
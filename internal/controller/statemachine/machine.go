@@ -23,8 +23,11 @@ import (
 
 	"github.com/go-logr/logr"
 	cron "github.com/robfig/cron/v3"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
 )
 
 // replicationState is the different states that replication object can be in
@@ -77,7 +80,7 @@ func Run(ctx context.Context, r ReplicationMachine, l logr.Logger) (ctrl.Result,
 	if err != nil {
 		setConditionError(r, l, err)
 	}
-	return result, err
+	return updateStaleness(r, l, result), err
 }
 
 func getTrigger(r ReplicationMachine) triggerType {
@@ -101,10 +104,19 @@ func doInitialState(_ context.Context, r ReplicationMachine, l logr.Logger) (ctr
 }
 
 func doSynchronizingState(ctx context.Context, r ReplicationMachine, l logr.Logger) (ctrl.Result, error) {
+	// Don't start the next attempt of a failed sync until its backoff expires
+	if wait := timeToNextRetry(r); wait != nil {
+		setConditionRetryPending(r, l, r.RetryStatus())
+		return ctrl.Result{RequeueAfter: *wait}, nil
+	}
+
 	result, err := r.Synchronize(ctx)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	if result.Failure != nil {
+		return handleSyncFailure(r, l, result)
+	}
 	if result.Completed {
 		// Just finished a sync, so we're in-sync
 		r.SetOutOfSync(false)
@@ -158,9 +170,9 @@ func doCleanupState(ctx context.Context, r ReplicationMachine, l logr.Logger) (c
 
 // Determine which state we're in by looking at the CR
 func currentState(r ReplicationMachine) replicationState {
-	// If we've never completed a sync and we're not trying to sync, we must be
-	// in the initial state
-	if r.LastSyncTime().IsZero() && r.LastSyncStartTime().IsZero() {
+	// If we've never completed (or given up on) a sync and we're not trying to
+	// sync, we must be in the initial state
+	if r.LastSyncTime().IsZero() && r.LastSyncStartTime().IsZero() && lastRetriesExhaustedTime(r).IsZero() {
 		return initialState
 	}
 	// If we're trying to sync, then we're in the synchronizing state
@@ -176,6 +188,8 @@ func transitionToSynchronizing(r ReplicationMachine, l logr.Logger) error {
 	l.V(1).Info("transitioning to synchronization state")
 	now := metav1.Now()
 	r.SetLastSyncStartTime(&now)
+	// This is a new sync, so it gets a fresh set of attempts
+	r.SetRetryStatus(nil)
 	setConditionSyncing(r, l)
 	return nil
 }
@@ -183,6 +197,15 @@ func transitionToSynchronizing(r ReplicationMachine, l logr.Logger) error {
 func transitionToCleaningUp(r ReplicationMachine, l logr.Logger) error {
 	l.V(1).Info("transitioning to cleanup state")
 
+	// A sync that succeeds after failed attempts, or after a sync that was
+	// abandoned, is a recovery
+	recovered := (r.RetryStatus() != nil && r.RetryStatus().Attempts > 0) ||
+		apimeta.IsStatusConditionTrue(*r.Conditions(), volsyncv1alpha1.ConditionRetriesExhausted)
+	now := metav1.Now()
+	recordSyncHistory(r, now, nil)
+	r.SetRetryStatus(nil)
+	clearConditionRetriesExhausted(r, l)
+
 	// If we took too long, update the miss count. We update here since
 	// we only want to count each miss once (ideally), so we do the
 	// update only when we try to transition.
@@ -195,7 +218,6 @@ func transitionToCleaningUp(r ReplicationMachine, l logr.Logger) error {
 	}
 
 	// Record the synchronization end time
-	now := metav1.Now()
 	r.SetLastSyncTime(&now)
 
 	// Calculate how long the synchronization took
@@ -218,6 +240,12 @@ func transitionToCleaningUp(r ReplicationMachine, l logr.Logger) error {
 	// Set condition to indicate cleanup is in progress (not synchronizing)
 	setConditionCleanup(r, l)
 
+	if recovered {
+		r.Notify(volsyncv1alpha1.NotificationEventRecovery, nil)
+	} else {
+		r.Notify(volsyncv1alpha1.NotificationEventSuccess, nil)
+	}
+
 	return nil
 }
 
@@ -280,7 +308,13 @@ func missedDeadline(r ReplicationMachine) (bool, error) {
 }
 
 func updateNextSyncStartTime(r ReplicationMachine, l logr.Logger) error {
+	// The schedule continues from the most recent sync, whether it succeeded
+	// or was abandoned after exhausting its retries
 	lastSync := r.LastSyncTime()
+	if exhausted := lastRetriesExhaustedTime(r); !exhausted.IsZero() &&
+		(lastSync.IsZero() || exhausted.After(lastSync.Time)) {
+		lastSync = exhausted
+	}
 
 	switch getTrigger(r) {
 	case scheduleTrigger:
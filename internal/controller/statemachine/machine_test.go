@@ -79,6 +79,9 @@ var _ = Describe("State transitions", func() {
 		Expect(apimeta.IsStatusConditionFalse(m.Cond, volsyncv1alpha1.ConditionSynchronizing)).To(BeTrue())
 		// Just finished a sync, so we are in-sync
 		Expect(m.OOSync).To(BeFalse())
+		Expect(m.Notifications).To(Equal([]volsyncv1alpha1.NotificationEventType{
+			volsyncv1alpha1.NotificationEventSuccess,
+		}))
 	})
 	It("will cleanup until complete", func() {
 		m := newFakeMachine()
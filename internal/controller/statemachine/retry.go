@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package statemachine
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+)
+
+const (
+	defaultInitialBackoff = 1 * time.Minute
+	defaultMaxBackoff     = 1 * time.Hour
+)
+
+// handleSyncFailure records a failed synchronization attempt and, according
+// to the retry policy, either schedules the next attempt or abandons the sync.
+// Without a retry policy, the attempt is only recorded and the sync is retried
+// as requested by the mover.
+func handleSyncFailure(r ReplicationMachine, l logr.Logger, result mover.Result) (ctrl.Result, error) {
+	failure := result.Failure
+	status := &volsyncv1alpha1.RetryStatus{}
+	if r.RetryStatus() != nil {
+		status = r.RetryStatus().DeepCopy()
+	}
+	now := metav1.Now()
+	status.Attempts++
+	status.LastFailureTime = &now
+	status.LastFailureClass = failure.Class
+	status.NextRetryTime = nil
+	l.Info("synchronization attempt failed", "attempt", status.Attempts,
+		"class", failure.Class, "message", failure.Message)
+	r.SetRetryStatus(status)
+	recordSyncHistory(r, now, failure)
+	if status.Attempts == 1 {
+		// Only notify the first failure of a sync. If it is retried, the
+		// Recovery event reports that it eventually succeeded.
+		r.Notify(volsyncv1alpha1.NotificationEventFailure, failure)
+	}
+
+	policy := r.RetryPolicy()
+	if policy == nil {
+		setConditionSyncing(r, l)
+		return result.ReconcileResult(), nil
+	}
+
+	if !isRetryable(policy, failure.Class) {
+		return abandonSync(r, l, volsyncv1alpha1.RetriesExhaustedReasonNotRetryable,
+			fmt.Sprintf("Synchronization failed with a %s failure, which is not retried: %s",
+				failure.Class, failure.Message))
+	}
+	if policy.MaxAttempts != nil && status.Attempts >= *policy.MaxAttempts {
+		return abandonSync(r, l, volsyncv1alpha1.RetriesExhaustedReasonMaxAttempts,
+			fmt.Sprintf("Synchronization failed after %d attempts, last failure (%s): %s",
+				status.Attempts, failure.Class, failure.Message))
+	}
+
+	backoff := retryBackoff(policy, status.Attempts)
+	status.NextRetryTime = &metav1.Time{Time: now.Add(backoff)}
+	r.SetRetryStatus(status)
+	setConditionRetryPending(r, l, status)
+	return ctrl.Result{RequeueAfter: backoff}, nil
+}
+
+// abandonSync gives up on the current synchronization after its retries have
+// been exhausted. The object moves to the cleanup state without recording a
+// successful sync, and waits for its next trigger.
+//
+//nolint:unparam
+func abandonSync(r ReplicationMachine, l logr.Logger, reason string, message string) (ctrl.Result, error) {
+	l.Info("retries exhausted; abandoning synchronization", "reason", reason)
+	status := r.RetryStatus().DeepCopy()
+	status.RetriesExhaustedTime = status.LastFailureTime
+	r.SetRetryStatus(status)
+	setConditionRetriesExhausted(r, l, reason, message)
+
+	if err := updateNextSyncStartTime(r, l); err != nil {
+		return ctrl.Result{}, err
+	}
+	// Don't re-run a manual sync that has been abandoned
+	r.SetLastManualTag(r.ManualTag())
+	r.SetLastSyncStartTime(nil)
+	setConditionCleanup(r, l)
+	return ctrl.Result{}, nil
+}
+
+// isRetryable returns true if failures of the given class are retried
+func isRetryable(policy *volsyncv1alpha1.RetryPolicy, class volsyncv1alpha1.FailureClass) bool {
+	return len(policy.RetryOn) == 0 || slices.Contains(policy.RetryOn, class)
+}
+
+// retryBackoff returns the time to wait after the given number of failed
+// attempts. The backoff starts at initialBackoff and doubles after each
+// failure, up to maxBackoff.
+func retryBackoff(policy *volsyncv1alpha1.RetryPolicy, attempts int32) time.Duration {
+	backoff := defaultInitialBackoff
+	if policy.InitialBackoff != nil {
+		backoff = policy.InitialBackoff.Duration
+	}
+	maxBackoff := defaultMaxBackoff
+	if policy.MaxBackoff != nil {
+		maxBackoff = policy.MaxBackoff.Duration
+	}
+	for i := int32(1); i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// timeToNextRetry returns how long until the next attempt of a failed sync
+// may start, or nil if it may start now
+func timeToNextRetry(r ReplicationMachine) *time.Duration {
+	status := r.RetryStatus()
+	if status == nil || status.NextRetryTime.IsZero() {
+		return nil
+	}
+	wait := time.Until(status.NextRetryTime.Time)
+	if wait <= 0 {
+		return nil
+	}
+	return &wait
+}
+
+// lastRetriesExhaustedTime returns the time the last sync was abandoned, or nil
+func lastRetriesExhaustedTime(r ReplicationMachine) *metav1.Time {
+	if r.RetryStatus() == nil {
+		return nil
+	}
+	return r.RetryStatus().RetriesExhaustedTime
+}
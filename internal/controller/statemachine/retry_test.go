@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package statemachine
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+)
+
+var _ = Describe("Retrying failed synchronizations", func() {
+	var m *fakeMachine
+	transientFailure := mover.Failed(mover.AttemptFailure{
+		Class:   volsyncv1alpha1.FailureClassTransient,
+		Message: "mover job failed",
+	})
+	// Lets the backoff of the last failure expire
+	expireBackoff := func() {
+		m.RS.NextRetryTime = &metav1.Time{Time: time.Now().Add(-time.Second)}
+	}
+
+	BeforeEach(func() {
+		m = newFakeMachine()
+		m.MT = "1"
+		// To Sync
+		_, err := Run(ctx, m, logger)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(currentState(m)).To(Equal(synchronizingState))
+		m.SyncResult = transientFailure
+	})
+
+	When("there is no retryPolicy", func() {
+		It("keeps retrying every minute", func() {
+			for i := 1; i <= 3; i++ {
+				result, err := Run(ctx, m, logger)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(time.Minute))
+				Expect(currentState(m)).To(Equal(synchronizingState))
+				Expect(m.RS.Attempts).To(Equal(int32(i)))
+				Expect(m.RS.NextRetryTime).To(BeNil())
+			}
+			Expect(apimeta.FindStatusCondition(m.Cond, volsyncv1alpha1.ConditionRetriesExhausted)).To(BeNil())
+		})
+	})
+
+	When("there is a retryPolicy", func() {
+		BeforeEach(func() {
+			m.RP = &volsyncv1alpha1.RetryPolicy{
+				MaxAttempts:    ptr.To(int32(3)),
+				InitialBackoff: &metav1.Duration{Duration: 10 * time.Second},
+				MaxBackoff:     &metav1.Duration{Duration: 15 * time.Second},
+			}
+		})
+
+		It("waits for the backoff before the next attempt", func() {
+			result, err := Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(10 * time.Second))
+			Expect(m.RS.Attempts).To(Equal(int32(1)))
+			Expect(m.RS.LastFailureClass).To(Equal(volsyncv1alpha1.FailureClassTransient))
+			Expect(m.RS.NextRetryTime).NotTo(BeNil())
+
+			// The mover isn't called again until the backoff expires
+			m.SyncResult = mover.Complete()
+			result, err = Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(currentState(m)).To(Equal(synchronizingState))
+			c := apimeta.FindStatusCondition(m.Cond, volsyncv1alpha1.ConditionSynchronizing)
+			Expect(c.Status).To(Equal(metav1.ConditionTrue))
+			Expect(c.Reason).To(Equal(volsyncv1alpha1.SynchronizingReasonRetry))
+
+			// Succeeding clears the failed attempts
+			expireBackoff()
+			_, err = Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(currentState(m)).To(Equal(cleaningUpState))
+			Expect(m.RS).To(BeNil())
+			Expect(m.LST).NotTo(BeNil())
+			Expect(m.Notifications).To(Equal([]volsyncv1alpha1.NotificationEventType{
+				volsyncv1alpha1.NotificationEventFailure,
+				volsyncv1alpha1.NotificationEventRecovery,
+			}))
+		})
+
+		It("gives up once maxAttempts is reached", func() {
+			for i := 1; i < 3; i++ {
+				_, err := Run(ctx, m, logger)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(currentState(m)).To(Equal(synchronizingState))
+				expireBackoff()
+			}
+			_, err := Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m.RS.Attempts).To(Equal(int32(3)))
+			Expect(m.RS.RetriesExhaustedTime).NotTo(BeNil())
+			// Only the first failed attempt is notified
+			Expect(m.Notifications).To(Equal([]volsyncv1alpha1.NotificationEventType{
+				volsyncv1alpha1.NotificationEventFailure,
+			}))
+			c := apimeta.FindStatusCondition(m.Cond, volsyncv1alpha1.ConditionRetriesExhausted)
+			Expect(c).NotTo(BeNil())
+			Expect(c.Status).To(Equal(metav1.ConditionTrue))
+			Expect(c.Reason).To(Equal(volsyncv1alpha1.RetriesExhaustedReasonMaxAttempts))
+
+			// The sync is abandoned without being recorded as successful, and
+			// we wait for the next trigger
+			Expect(currentState(m)).To(Equal(cleaningUpState))
+			Expect(m.LST).To(BeNil())
+			Expect(m.LMT).To(Equal("1"))
+			_, err = Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(currentState(m)).To(Equal(cleaningUpState))
+
+			// The next sync gets a fresh set of attempts
+			m.MT = "2"
+			_, err = Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(currentState(m)).To(Equal(synchronizingState))
+			Expect(m.RS).To(BeNil())
+
+			m.SyncResult = mover.Complete()
+			_, err = Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(currentState(m)).To(Equal(cleaningUpState))
+			Expect(apimeta.IsStatusConditionFalse(m.Cond, volsyncv1alpha1.ConditionRetriesExhausted)).To(BeTrue())
+			// The sync after the abandoned one is a recovery
+			Expect(m.Notifications).To(HaveLen(2))
+			Expect(m.Notifications[1]).To(Equal(volsyncv1alpha1.NotificationEventRecovery))
+		})
+
+		It("gives up on failures that aren't retried", func() {
+			m.RP.RetryOn = []volsyncv1alpha1.FailureClass{volsyncv1alpha1.FailureClassTransient}
+			m.SyncResult = mover.Failed(mover.AttemptFailure{Class: volsyncv1alpha1.FailureClassAuth})
+			_, err := Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(currentState(m)).To(Equal(cleaningUpState))
+			Expect(m.RS.Attempts).To(Equal(int32(1)))
+			c := apimeta.FindStatusCondition(m.Cond, volsyncv1alpha1.ConditionRetriesExhausted)
+			Expect(c).NotTo(BeNil())
+			Expect(c.Reason).To(Equal(volsyncv1alpha1.RetriesExhaustedReasonNotRetryable))
+		})
+
+		It("schedules the next sync from the time it gave up", func() {
+			m.MT = ""
+			m.CS = "0 * * * *"
+			m.RP.MaxAttempts = ptr.To(int32(1))
+			_, err := Run(ctx, m, logger)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(currentState(m)).To(Equal(cleaningUpState))
+			Expect(m.NST).NotTo(BeNil())
+			Expect(m.NST.Time).To(BeTemporally(">", m.RS.RetriesExhaustedTime.Time))
+			Expect(m.NST.Time).To(BeTemporally("<=", m.RS.RetriesExhaustedTime.Add(time.Hour)))
+		})
+	})
+})
+
+var _ = DescribeTable("Retry backoff",
+	func(initial time.Duration, maxBackoff time.Duration, attempts int32, expected time.Duration) {
+		policy := &volsyncv1alpha1.RetryPolicy{
+			InitialBackoff: &metav1.Duration{Duration: initial},
+			MaxBackoff:     &metav1.Duration{Duration: maxBackoff},
+		}
+		Expect(retryBackoff(policy, attempts)).To(Equal(expected))
+	},
+	Entry("first failure", time.Minute, time.Hour, int32(1), time.Minute),
+	Entry("doubles", time.Minute, time.Hour, int32(4), 8*time.Minute),
+	Entry("is capped", time.Minute, time.Hour, int32(10), time.Hour),
+	Entry("doesn't overflow", time.Minute, time.Hour, int32(1000), time.Hour),
+	Entry("initial above max", time.Hour, time.Minute, int32(1), time.Minute),
+)
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package statemachine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+// updateStaleness reports whether the last successful synchronization is
+// older than the maxStaleness, whatever the trigger and state of the object.
+// While the object isn't stale, the result is requeued for when it would
+// become stale.
+func updateStaleness(r ReplicationMachine, l logr.Logger, result ctrl.Result) ctrl.Result {
+	maxStaleness := r.MaxStaleness()
+	if maxStaleness == nil {
+		apimeta.RemoveStatusCondition(r.Conditions(), volsyncv1alpha1.ConditionStale)
+		r.SetStale(false)
+		return result
+	}
+
+	lastSync := r.LastSyncTime()
+	staleAt := r.CreationTime().Add(maxStaleness.Duration)
+	if !lastSync.IsZero() {
+		staleAt = lastSync.Add(maxStaleness.Duration)
+	}
+	untilStale := time.Until(staleAt)
+	if untilStale <= 0 {
+		r.SetStale(true)
+		setConditionStale(r, l, lastSync, maxStaleness)
+		return result
+	}
+
+	r.SetStale(false)
+	setConditionNotStale(r, l, lastSync, maxStaleness)
+	if result.RequeueAfter == 0 || untilStale < result.RequeueAfter {
+		result.RequeueAfter = untilStale
+	}
+	return result
+}
+
+func setConditionStale(r ReplicationMachine, _ logr.Logger, lastSync *metav1.Time, maxStaleness *metav1.Duration) {
+	condition := metav1.Condition{
+		Type:   volsyncv1alpha1.ConditionStale,
+		Status: metav1.ConditionTrue,
+		Reason: volsyncv1alpha1.StaleReasonSyncTooOld,
+		Message: fmt.Sprintf("Last successful synchronization at %s is older than %s",
+			lastSync.UTC().Format(time.RFC3339), maxStaleness.Duration),
+	}
+	if lastSync.IsZero() {
+		condition.Reason = volsyncv1alpha1.StaleReasonNeverSynced
+		condition.Message = fmt.Sprintf("No successful synchronization within %s", maxStaleness.Duration)
+	}
+	apimeta.SetStatusCondition(r.Conditions(), condition)
+}
+
+func setConditionNotStale(r ReplicationMachine, _ logr.Logger, lastSync *metav1.Time, maxStaleness *metav1.Duration) {
+	message := fmt.Sprintf("Last successful synchronization is within %s", maxStaleness.Duration)
+	if lastSync.IsZero() {
+		message = fmt.Sprintf("Waiting for the first synchronization, which is expected within %s",
+			maxStaleness.Duration)
+	}
+	apimeta.SetStatusCondition(r.Conditions(),
+		metav1.Condition{
+			Type:    volsyncv1alpha1.ConditionStale,
+			Status:  metav1.ConditionFalse,
+			Reason:  volsyncv1alpha1.StaleReasonSyncRecent,
+			Message: message,
+		})
+}
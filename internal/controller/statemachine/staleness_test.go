@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package statemachine
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var _ = Describe("Staleness", func() {
+	var m *fakeMachine
+	staleCondition := func() *metav1.Condition {
+		return apimeta.FindStatusCondition(*m.Conditions(), volsyncv1alpha1.ConditionStale)
+	}
+
+	BeforeEach(func() {
+		m = newFakeMachine()
+		m.MS = &metav1.Duration{Duration: time.Hour}
+	})
+
+	It("is not reported without a maxStaleness", func() {
+		m.MS = nil
+		m.Stale = true
+		apimeta.SetStatusCondition(m.Conditions(), metav1.Condition{
+			Type:   volsyncv1alpha1.ConditionStale,
+			Status: metav1.ConditionTrue,
+			Reason: volsyncv1alpha1.StaleReasonSyncTooOld,
+		})
+		result := updateStaleness(m, logger, ctrl.Result{})
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(m.Stale).To(BeFalse())
+		Expect(staleCondition()).To(BeNil())
+	})
+
+	It("requeues for when a recent sync becomes stale", func() {
+		m.LST = &metav1.Time{Time: time.Now().Add(-15 * time.Minute)}
+		result := updateStaleness(m, logger, ctrl.Result{RequeueAfter: 2 * time.Hour})
+		Expect(result.RequeueAfter).To(BeNumerically("~", 45*time.Minute, time.Minute))
+		Expect(m.Stale).To(BeFalse())
+		Expect(staleCondition().Status).To(Equal(metav1.ConditionFalse))
+		Expect(staleCondition().Reason).To(Equal(volsyncv1alpha1.StaleReasonSyncRecent))
+
+		// An earlier requeue is kept
+		result = updateStaleness(m, logger, ctrl.Result{RequeueAfter: time.Minute})
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+	})
+
+	It("reports a sync older than the maxStaleness", func() {
+		m.LST = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+		result := updateStaleness(m, logger, ctrl.Result{})
+		Expect(result).To(Equal(ctrl.Result{}))
+		Expect(m.Stale).To(BeTrue())
+		Expect(staleCondition().Status).To(Equal(metav1.ConditionTrue))
+		Expect(staleCondition().Reason).To(Equal(volsyncv1alpha1.StaleReasonSyncTooOld))
+	})
+
+	It("measures from the creation time until the first sync", func() {
+		m.Created = metav1.NewTime(time.Now().Add(-30 * time.Minute))
+		updateStaleness(m, logger, ctrl.Result{})
+		Expect(m.Stale).To(BeFalse())
+
+		m.Created = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		updateStaleness(m, logger, ctrl.Result{})
+		Expect(m.Stale).To(BeTrue())
+		Expect(staleCondition().Reason).To(Equal(volsyncv1alpha1.StaleReasonNeverSynced))
+	})
+})
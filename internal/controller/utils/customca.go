@@ -3,6 +3,7 @@ package utils
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -55,6 +56,23 @@ func (c *CustomCAObjectConfigMap) GetVolumeSource(path string) corev1.VolumeSour
 	}
 }
 
+// ValidateCustomCASpec checks that a CustomCASpec is self-consistent without
+// looking up the referenced Secret or ConfigMap
+func ValidateCustomCASpec(customCA volsyncv1alpha1.CustomCASpec) error {
+	if customCA.SecretName != "" && customCA.ConfigMapName != "" {
+		return fmt.Errorf("customCA: only one of secretName or configMapName may be specified")
+	}
+	hasSource := customCA.SecretName != "" || customCA.ConfigMapName != ""
+	if customCA.Key != "" && !hasSource {
+		return fmt.Errorf("customCA: key %q is set but neither secretName nor configMapName is specified",
+			customCA.Key)
+	}
+	if customCA.Key == "" && hasSource {
+		return fmt.Errorf("customCA: key is required when secretName or configMapName is specified")
+	}
+	return nil
+}
+
 func ValidateCustomCA(ctx context.Context, cl client.Client, l logr.Logger,
 	namespace string, customCA volsyncv1alpha1.CustomCASpec) (CustomCAObject, error) {
 	if customCA.Key == "" {
@@ -220,3 +220,32 @@ var _ = Describe("customca tests", func() {
 	})
 
 })
+
+var _ = Describe("ValidateCustomCASpec", func() {
+	It("Should accept an empty spec", func() {
+		Expect(utils.ValidateCustomCASpec(volsyncv1alpha1.CustomCASpec{})).To(Succeed())
+	})
+	It("Should accept a secret with a key", func() {
+		Expect(utils.ValidateCustomCASpec(volsyncv1alpha1.CustomCASpec{
+			SecretName: "ca-secret",
+			Key:        "ca.crt",
+		})).To(Succeed())
+	})
+	It("Should reject both a secret and a configmap", func() {
+		Expect(utils.ValidateCustomCASpec(volsyncv1alpha1.CustomCASpec{
+			SecretName:    "ca-secret",
+			ConfigMapName: "ca-cm",
+			Key:           "ca.crt",
+		})).To(MatchError(ContainSubstring("only one of secretName or configMapName")))
+	})
+	It("Should reject a key without a secret or configmap", func() {
+		Expect(utils.ValidateCustomCASpec(volsyncv1alpha1.CustomCASpec{
+			Key: "ca.crt",
+		})).NotTo(Succeed())
+	})
+	It("Should reject a configmap without a key", func() {
+		Expect(utils.ValidateCustomCASpec(volsyncv1alpha1.CustomCASpec{
+			ConfigMapName: "ca-cm",
+		})).To(MatchError(ContainSubstring("key is required")))
+	})
+})
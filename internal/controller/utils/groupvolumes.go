@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// MountGroupDataVolumes replaces the data volume of a mover pod with the PVCs
+// restored from a group snapshot. The pvcs are keyed by the name of their
+// source PVC, and each is mounted in a subdirectory named after its source PVC
+// of the path the data volume was mounted at.
+func MountGroupDataVolumes(podSpec *corev1.PodSpec, dataVolumeName string,
+	pvcs map[string]*corev1.PersistentVolumeClaim) {
+	srcNames := slices.Sorted(maps.Keys(pvcs))
+	volumeName := func(i int) string {
+		return fmt.Sprintf("%s-%d", dataVolumeName, i)
+	}
+
+	volumes := make([]corev1.Volume, 0, len(podSpec.Volumes)+len(srcNames))
+	for _, v := range podSpec.Volumes {
+		if v.Name != dataVolumeName {
+			volumes = append(volumes, v)
+		}
+	}
+	for i, srcName := range srcNames {
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName(i),
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvcs[srcName].GetName(),
+					ReadOnly:  PvcIsReadOnly(pvcs[srcName]),
+				},
+			},
+		})
+	}
+	podSpec.Volumes = volumes
+
+	for c := range podSpec.Containers {
+		container := &podSpec.Containers[c]
+		mounts := make([]corev1.VolumeMount, 0, len(container.VolumeMounts)+len(srcNames))
+		for _, vm := range container.VolumeMounts {
+			if vm.Name != dataVolumeName {
+				mounts = append(mounts, vm)
+				continue
+			}
+			for i, srcName := range srcNames {
+				mounts = append(mounts, corev1.VolumeMount{
+					Name:      volumeName(i),
+					MountPath: path.Join(vm.MountPath, srcName),
+					ReadOnly:  vm.ReadOnly || PvcIsReadOnly(pvcs[srcName]),
+				})
+			}
+		}
+		container.VolumeMounts = mounts
+	}
+}
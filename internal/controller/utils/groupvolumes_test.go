@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+var _ = Describe("MountGroupDataVolumes", func() {
+	It("should mount each group PVC in a subdirectory of the data mount", func() {
+		podSpec := &corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name: "mover",
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "data", MountPath: "/data", ReadOnly: true},
+					{Name: "cache", MountPath: "/cache"},
+				},
+			}},
+			Volumes: []corev1.Volume{
+				{Name: "data", VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "src"},
+				}},
+				{Name: "cache", VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "cache"},
+				}},
+			},
+		}
+		pvcs := map[string]*corev1.PersistentVolumeClaim{
+			"wal": {ObjectMeta: metav1.ObjectMeta{Name: "volsync-rs-src-wal"}},
+			"db":  {ObjectMeta: metav1.ObjectMeta{Name: "volsync-rs-src-db"}},
+		}
+
+		utils.MountGroupDataVolumes(podSpec, "data", pvcs)
+
+		Expect(podSpec.Volumes).To(HaveLen(3))
+		Expect(podSpec.Volumes[0].Name).To(Equal("cache"))
+		Expect(podSpec.Volumes[1].Name).To(Equal("data-0"))
+		Expect(podSpec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal("volsync-rs-src-db"))
+		Expect(podSpec.Volumes[2].Name).To(Equal("data-1"))
+		Expect(podSpec.Volumes[2].PersistentVolumeClaim.ClaimName).To(Equal("volsync-rs-src-wal"))
+
+		Expect(podSpec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+			{Name: "data-0", MountPath: "/data/db", ReadOnly: true},
+			{Name: "data-1", MountPath: "/data/wal", ReadOnly: true},
+			{Name: "cache", MountPath: "/cache"},
+		}))
+	})
+})
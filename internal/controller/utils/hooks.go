@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/tools/remotecommand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+const (
+	defaultHookTimeout = 30 * time.Second
+	// Max bytes of a hook's output kept in the status
+	hookOutputMaxBytes = 256
+)
+
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
+
+var podExecConfig *rest.Config
+
+// InitPodExecClient sets the configuration used to exec hooks in application
+// pods. The clientset is shared with the pod logs client, so
+// InitPodLogsClient() must be called as well.
+func InitPodExecClient(cfg *rest.Config) {
+	podExecConfig = cfg
+}
+
+// PodExecFunc execs command in a container of a pod and returns its combined
+// output
+type PodExecFunc func(ctx context.Context, pod *corev1.Pod, container string, command []string) (string, error)
+
+// PodExec is used to exec hooks in the application pods. It can be replaced in
+// tests.
+var PodExec PodExecFunc = execInPod
+
+func execInPod(ctx context.Context, pod *corev1.Pod, container string, command []string) (string, error) {
+	if podExecConfig == nil || clientset == nil {
+		return "", errors.New("pod exec client has not been initialized")
+	}
+
+	request := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.GetNamespace()).
+		Name(pod.GetName()).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(podExecConfig, "POST", request.URL())
+	if err != nil {
+		return "", err
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	return stdout.String() + stderr.String(), err
+}
+
+// EnsureBeforeHook runs the before hook of a ReplicationSource once per
+// synchronization. It is a no-op for other owners or when no before hook is
+// configured. If the hook fails and its failure policy is Fail, the after hook
+// is run (without being recorded) so the application isn't left quiesced
+// while the synchronization is retried.
+func EnsureBeforeHook(ctx context.Context, c client.Client, logger logr.Logger,
+	er events.EventRecorder, owner client.Object) error {
+	rs, ok := owner.(*volsyncv1alpha1.ReplicationSource)
+	if !ok || rs.Spec.Hooks == nil || rs.Spec.Hooks.Before == nil {
+		return nil
+	}
+	hooks := rs.Spec.Hooks
+	status := hooksStatus(rs)
+	if hookDoneThisSync(rs, hooks.Before, status.Before) {
+		return nil
+	}
+
+	result, err := runHook(ctx, c, logger, er, rs, "before", hooks.Before)
+	if result != nil {
+		status.Before = result
+	}
+	if err != nil && hooks.After != nil {
+		_, _ = runHook(ctx, c, logger, er, rs, "after", hooks.After)
+	}
+	return err
+}
+
+// EnsureAfterHook runs the after hook of a ReplicationSource once per
+// synchronization. It is a no-op for other owners or when no after hook is
+// configured.
+func EnsureAfterHook(ctx context.Context, c client.Client, logger logr.Logger,
+	er events.EventRecorder, owner client.Object) error {
+	rs, ok := owner.(*volsyncv1alpha1.ReplicationSource)
+	if !ok || rs.Spec.Hooks == nil || rs.Spec.Hooks.After == nil {
+		return nil
+	}
+	status := hooksStatus(rs)
+	if hookDoneThisSync(rs, rs.Spec.Hooks.After, status.After) {
+		return nil
+	}
+
+	result, err := runHook(ctx, c, logger, er, rs, "after", rs.Spec.Hooks.After)
+	if result != nil {
+		status.After = result
+	}
+	return err
+}
+
+func hooksStatus(rs *volsyncv1alpha1.ReplicationSource) *volsyncv1alpha1.ReplicationSourceHooksStatus {
+	if rs.Status == nil {
+		rs.Status = &volsyncv1alpha1.ReplicationSourceStatus{}
+	}
+	if rs.Status.Hooks == nil {
+		rs.Status.Hooks = &volsyncv1alpha1.ReplicationSourceHooksStatus{}
+	}
+	return rs.Status.Hooks
+}
+
+// hookDoneThisSync returns true if the hook has already been run during the
+// current synchronization, and doesn't need to be retried
+func hookDoneThisSync(rs *volsyncv1alpha1.ReplicationSource, hook *volsyncv1alpha1.ReplicationSourceHook,
+	result *volsyncv1alpha1.HookResult) bool {
+	if result == nil || result.Time.IsZero() || rs.Status.LastSyncStartTime.IsZero() {
+		return false
+	}
+	if result.Time.Before(rs.Status.LastSyncStartTime) {
+		return false
+	}
+	return result.Succeeded || hook.OnFailure == volsyncv1alpha1.HookFailurePolicyContinue
+}
+
+// runHook execs the hook command in all the running pods selected by the
+// hooks' podSelector. The result is nil if the pods couldn't be listed.
+func runHook(ctx context.Context, c client.Client, logger logr.Logger, er events.EventRecorder,
+	rs *volsyncv1alpha1.ReplicationSource, name string,
+	hook *volsyncv1alpha1.ReplicationSourceHook) (*volsyncv1alpha1.HookResult, error) {
+	l := logger.WithValues("hook", name)
+
+	selector, err := metav1.LabelSelectorAsSelector(&rs.Spec.Hooks.PodSelector)
+	if err != nil {
+		l.Error(err, "invalid podSelector")
+		return nil, err
+	}
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.InNamespace(rs.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		l.Error(err, "unable to list pods for hook")
+		return nil, err
+	}
+
+	timeout := defaultHookTimeout
+	if hook.Timeout != nil {
+		timeout = hook.Timeout.Duration
+	}
+
+	var failures []string
+	var ran int
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		ran++
+		execCtx, cancel := context.WithTimeout(ctx, timeout)
+		output, err := PodExec(execCtx, pod, rs.Spec.Hooks.ContainerName, hook.Command)
+		cancel()
+		if err != nil {
+			l.Error(err, "hook failed", "pod", pod.GetName(), "output", output)
+			failures = append(failures, fmt.Sprintf("pod %s: %v: %s", pod.GetName(), err,
+				TruncateString(strings.TrimSpace(output), hookOutputMaxBytes)))
+			continue
+		}
+		l.V(1).Info("hook succeeded", "pod", pod.GetName(), "output", output)
+	}
+	if ran == 0 {
+		failures = append(failures, "no running pods match the podSelector")
+	}
+
+	now := metav1.Now()
+	result := &volsyncv1alpha1.HookResult{
+		Time:      &now,
+		Succeeded: len(failures) == 0,
+		Message:   strings.Join(failures, "; "),
+	}
+	if result.Succeeded {
+		l.Info("hook succeeded", "pods", ran)
+		er.Eventf(rs, nil, corev1.EventTypeNormal, volsyncv1alpha1.EvRHookSucceeded, volsyncv1alpha1.EvAExecHook,
+			"%s hook succeeded in %d pod(s)", name, ran)
+		return result, nil
+	}
+
+	er.Eventf(rs, nil, corev1.EventTypeWarning, volsyncv1alpha1.EvRHookFailed, volsyncv1alpha1.EvAExecHook,
+		"%s hook failed: %s", name, result.Message)
+	if hook.OnFailure == volsyncv1alpha1.HookFailurePolicyContinue {
+		return result, nil
+	}
+	return result, fmt.Errorf("%s hook failed: %s", name, result.Message)
+}
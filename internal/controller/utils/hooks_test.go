@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+var _ = Describe("ReplicationSource hooks", func() {
+	logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
+	recorder := &events.FakeRecorder{}
+
+	var ns *corev1.Namespace
+	var rs *volsyncv1alpha1.ReplicationSource
+	var execs []string
+	var execErr error
+	var origPodExec utils.PodExecFunc
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "hooks-",
+			},
+		}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+		execs = []string{}
+		execErr = nil
+		origPodExec = utils.PodExec
+		utils.PodExec = func(_ context.Context, pod *corev1.Pod, container string, command []string) (string, error) {
+			Expect(container).To(Equal("app"))
+			execs = append(execs, pod.GetName()+":"+command[0])
+			return "output", execErr
+		}
+
+		rs = &volsyncv1alpha1.ReplicationSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "rs",
+				Namespace: ns.GetName(),
+			},
+			Spec: volsyncv1alpha1.ReplicationSourceSpec{
+				SourcePVC: "data",
+				Hooks: &volsyncv1alpha1.ReplicationSourceHooksSpec{
+					PodSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "db"},
+					},
+					ContainerName: "app",
+					Before: &volsyncv1alpha1.ReplicationSourceHook{
+						Command: []string{"freeze"},
+					},
+					After: &volsyncv1alpha1.ReplicationSourceHook{
+						Command: []string{"thaw"},
+					},
+				},
+			},
+			Status: &volsyncv1alpha1.ReplicationSourceStatus{
+				LastSyncStartTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			},
+		}
+	})
+	AfterEach(func() {
+		utils.PodExec = origPodExec
+		Expect(k8sClient.Delete(ctx, ns)).To(Succeed())
+	})
+
+	When("no pods match the selector", func() {
+		It("should fail the before hook", func() {
+			err := utils.EnsureBeforeHook(ctx, k8sClient, logger, recorder, rs)
+			Expect(err).To(HaveOccurred())
+			Expect(rs.Status.Hooks.Before).NotTo(BeNil())
+			Expect(rs.Status.Hooks.Before.Succeeded).To(BeFalse())
+			Expect(rs.Status.Hooks.Before.Message).To(ContainSubstring("no running pods"))
+			Expect(execs).To(BeEmpty())
+		})
+	})
+
+	When("pods match the selector", func() {
+		BeforeEach(func() {
+			createHookTestPod("db-0", ns.GetName(), "db", corev1.PodRunning)
+			createHookTestPod("db-1", ns.GetName(), "db", corev1.PodPending)
+			createHookTestPod("web-0", ns.GetName(), "web", corev1.PodRunning)
+		})
+
+		It("should run each hook once per sync in the running pods", func() {
+			Expect(utils.EnsureBeforeHook(ctx, k8sClient, logger, recorder, rs)).To(Succeed())
+			Expect(execs).To(Equal([]string{"db-0:freeze"}))
+			Expect(rs.Status.Hooks.Before.Succeeded).To(BeTrue())
+
+			// Already done for this sync
+			Expect(utils.EnsureBeforeHook(ctx, k8sClient, logger, recorder, rs)).To(Succeed())
+			Expect(utils.EnsureAfterHook(ctx, k8sClient, logger, recorder, rs)).To(Succeed())
+			Expect(utils.EnsureAfterHook(ctx, k8sClient, logger, recorder, rs)).To(Succeed())
+			Expect(execs).To(Equal([]string{"db-0:freeze", "db-0:thaw"}))
+			Expect(rs.Status.Hooks.After.Succeeded).To(BeTrue())
+
+			// A new sync runs the hooks again
+			rs.Status.LastSyncStartTime = &metav1.Time{Time: time.Now().Add(time.Second)}
+			Expect(utils.EnsureBeforeHook(ctx, k8sClient, logger, recorder, rs)).To(Succeed())
+			Expect(execs).To(HaveLen(3))
+		})
+
+		It("should retry a failed hook with the Fail policy", func() {
+			execErr = errors.New("command terminated with exit code 1")
+			Expect(utils.EnsureBeforeHook(ctx, k8sClient, logger, recorder, rs)).NotTo(Succeed())
+			// The after hook is run to undo the before hook, but not recorded
+			Expect(execs).To(Equal([]string{"db-0:freeze", "db-0:thaw"}))
+			Expect(rs.Status.Hooks.After).To(BeNil())
+
+			execErr = nil
+			Expect(utils.EnsureBeforeHook(ctx, k8sClient, logger, recorder, rs)).To(Succeed())
+			Expect(execs).To(HaveLen(3))
+		})
+
+		It("should not block the sync with the Continue policy", func() {
+			execErr = errors.New("command terminated with exit code 1")
+			rs.Spec.Hooks.Before.OnFailure = volsyncv1alpha1.HookFailurePolicyContinue
+			Expect(utils.EnsureBeforeHook(ctx, k8sClient, logger, recorder, rs)).To(Succeed())
+			Expect(rs.Status.Hooks.Before.Succeeded).To(BeFalse())
+			Expect(rs.Status.Hooks.Before.Message).To(ContainSubstring("exit code 1"))
+
+			// Not retried during the same sync
+			Expect(utils.EnsureBeforeHook(ctx, k8sClient, logger, recorder, rs)).To(Succeed())
+			Expect(execs).To(Equal([]string{"db-0:freeze"}))
+		})
+	})
+
+	It("should ignore owners other than ReplicationSources", func() {
+		rd := &volsyncv1alpha1.ReplicationDestination{}
+		Expect(utils.EnsureBeforeHook(ctx, k8sClient, logger, recorder, rd)).To(Succeed())
+		Expect(utils.EnsureAfterHook(ctx, k8sClient, logger, recorder, rd)).To(Succeed())
+		Expect(execs).To(BeEmpty())
+	})
+})
+
+func createHookTestPod(name, namespace, app string, desiredPhase corev1.PodPhase) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app": app,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Image: "someimagepath:here",
+			}},
+		},
+	}
+	Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+
+	pod.Status.Phase = desiredPhase
+	Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+}
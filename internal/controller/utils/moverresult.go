@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+// MoverResultVersion is the version of the MoverResult written by the movers
+const MoverResultVersion = 1
+
+// MoverResult is the machine-readable result that a mover writes to the
+// termination message of its container (/dev/termination-log) when it exits.
+// Kubernetes limits the message to 4096 bytes, so movers drop the details
+// that can grow with the repository, and set Truncated, when they don't fit.
+type MoverResult struct {
+	Version         int      `json:"version"`
+	SnapshotID      string   `json:"snapshotID,omitempty"`
+	BytesProcessed  *int64   `json:"bytesProcessed,omitempty"`
+	FilesProcessed  *int64   `json:"filesProcessed,omitempty"`
+	DurationSeconds *int64   `json:"durationSeconds,omitempty"`
+	Errors          []string `json:"errors,omitempty"`
+	Truncated       bool     `json:"truncated,omitempty"`
+	// SourceCapacity is the capacity of the source volume, in bytes
+	SourceCapacity *int64 `json:"sourceCapacity,omitempty"`
+	// Details are the results specific to the mover
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+// ParseMoverResult decodes the termination message of a mover container. It
+// returns nil if the message is empty.
+func ParseMoverResult(message string) (*MoverResult, error) {
+	if message == "" {
+		return nil, nil
+	}
+	result := &MoverResult{}
+	if err := json.Unmarshal([]byte(message), result); err != nil {
+		return nil, fmt.Errorf("unable to decode mover result: %w", err)
+	}
+	if result.Version < 1 {
+		return nil, fmt.Errorf("mover result has no version")
+	}
+	return result, nil
+}
+
+// DecodeDetails decodes the mover-specific details of the result into v. It
+// leaves v unchanged if the result has no details.
+func (r *MoverResult) DecodeDetails(v any) error {
+	if r == nil || len(r.Details) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(r.Details, v); err != nil {
+		return fmt.Errorf("unable to decode mover result details: %w", err)
+	}
+	return nil
+}
+
+// moverResultForPod returns the result written by the mover container of the
+// pod, or nil if it hasn't written one
+func moverResultForPod(pod *corev1.Pod) (*MoverResult, error) {
+	for _, cs := range pod.Status.ContainerStatuses {
+		terminated := cs.State.Terminated
+		if terminated == nil {
+			terminated = cs.LastTerminationState.Terminated
+		}
+		if terminated != nil && terminated.Message != "" {
+			return ParseMoverResult(terminated.Message)
+		}
+	}
+	return nil, nil
+}
+
+// applyMoverResult records the generic fields of the result in the mover
+// status
+func applyMoverResult(moverStatus *volsyncv1alpha1.MoverStatus, result *MoverResult) {
+	moverStatus.SnapshotID = result.SnapshotID
+	moverStatus.BytesProcessed = result.BytesProcessed
+	moverStatus.FilesProcessed = result.FilesProcessed
+	moverStatus.Errors = result.Errors
+	if result.DurationSeconds != nil {
+		moverStatus.Duration = &metav1.Duration{Duration: time.Duration(*result.DurationSeconds) * time.Second}
+	}
+	if result.SourceCapacity != nil {
+		moverStatus.SourceCapacity = resource.NewQuantity(*result.SourceCapacity, resource.BinarySI)
+	}
+}
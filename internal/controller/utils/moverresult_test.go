@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+var _ = Describe("Mover results", func() {
+	It("should decode the result written by the mover", func() {
+		result, err := utils.ParseMoverResult(`{"version":1,"snapshotID":"k1234","bytesProcessed":2048,` +
+			`"filesProcessed":3,"durationSeconds":62,"sourceCapacity":1073741824,"errors":["oops"],` +
+			`"details":{"check":"Passed"}}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.SnapshotID).To(Equal("k1234"))
+		Expect(result.BytesProcessed).To(HaveValue(Equal(int64(2048))))
+		Expect(result.FilesProcessed).To(HaveValue(Equal(int64(3))))
+		Expect(result.DurationSeconds).To(HaveValue(Equal(int64(62))))
+		Expect(result.SourceCapacity).To(HaveValue(Equal(int64(1073741824))))
+		Expect(result.Errors).To(Equal([]string{"oops"}))
+
+		details := struct {
+			Check string `json:"check"`
+		}{}
+		Expect(result.DecodeDetails(&details)).To(Succeed())
+		Expect(details.Check).To(Equal("Passed"))
+	})
+
+	It("should ignore an empty message", func() {
+		result, err := utils.ParseMoverResult("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+
+		var details struct{}
+		Expect(result.DecodeDetails(&details)).To(Succeed())
+	})
+
+	It("should reject messages that aren't a mover result", func() {
+		_, err := utils.ParseMoverResult("Error: failed to create snapshot")
+		Expect(err).To(HaveOccurred())
+		_, err = utils.ParseMoverResult(`{"snapshotID":"k1234"}`)
+		Expect(err).To(MatchError(ContainSubstring("no version")))
+	})
+})
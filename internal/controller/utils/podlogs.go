@@ -21,6 +21,7 @@ package utils
 import (
 	"bufio"
 	"context"
+	"fmt"
 	"io"
 	"strings"
 
@@ -107,7 +108,18 @@ func IsMoverLogDebug() bool {
 }
 
 func getPodLogs(ctx context.Context, logger logr.Logger, podName, podNamespace string,
-	lineFilter func(line string) *string) (string, error) {
+	tailLines int64, lineFilter func(line string) *string) (string, error) {
+	stream, err := streamPodLogs(ctx, logger, podName, podNamespace, tailLines)
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	return FilterLogs(stream, lineFilter)
+}
+
+func streamPodLogs(ctx context.Context, logger logr.Logger, podName, podNamespace string,
+	tailLines int64) (io.ReadCloser, error) {
 	l := logger.WithValues("podName", podName, "podNamespace", podNamespace)
 
 	podLogOptions := &corev1.PodLogOptions{
@@ -115,7 +127,6 @@ func getPodLogs(ctx context.Context, logger logr.Logger, podName, podNamespace s
 		Follow: false,
 	}
 
-	tailLines := GetMoverLogTailLines()
 	if tailLines >= 0 {
 		podLogOptions.TailLines = &tailLines
 	}
@@ -124,11 +135,9 @@ func getPodLogs(ctx context.Context, logger logr.Logger, podName, podNamespace s
 	stream, err := request.Stream(ctx)
 	if err != nil {
 		l.Error(err, "Error streaming logs from pod")
-		return "", err
+		return nil, err
 	}
-	defer stream.Close()
-
-	return FilterLogs(stream, lineFilter)
+	return stream, nil
 }
 
 // Appies lineFilter to each line
@@ -163,19 +172,27 @@ func UpdateMoverStatusFailed(moverStatus *volsyncv1alpha1.MoverStatus, errMessag
 	moverStatus.Logs = errMessage
 }
 
+// UpdateMoverStatusForFailedJob records the logs and the result of a failed
+// mover job in the mover status. It returns the result written by the mover,
+// if any, so that mover-specific details can be decoded from it.
 func UpdateMoverStatusForFailedJob(ctx context.Context, logger logr.Logger,
-	moverStatus *volsyncv1alpha1.MoverStatus, jobName, jobNamespace string, logLineFilter func(string) *string) {
-	updateMoverStatusForJob(ctx, logger, moverStatus, jobName, jobNamespace, true, logLineFilter)
+	moverStatus *volsyncv1alpha1.MoverStatus, jobName, jobNamespace string,
+	logLineFilter func(string) *string) *MoverResult {
+	return updateMoverStatusForJob(ctx, logger, moverStatus, jobName, jobNamespace, true, logLineFilter)
 }
 
+// UpdateMoverStatusForSuccessfulJob records the logs and the result of a
+// successful mover job in the mover status. It returns the result written by
+// the mover, if any, so that mover-specific details can be decoded from it.
 func UpdateMoverStatusForSuccessfulJob(ctx context.Context, logger logr.Logger,
-	moverStatus *volsyncv1alpha1.MoverStatus, jobName, jobNamespace string, logLineFilter func(string) *string) {
-	updateMoverStatusForJob(ctx, logger, moverStatus, jobName, jobNamespace, false, logLineFilter)
+	moverStatus *volsyncv1alpha1.MoverStatus, jobName, jobNamespace string,
+	logLineFilter func(string) *string) *MoverResult {
+	return updateMoverStatusForJob(ctx, logger, moverStatus, jobName, jobNamespace, false, logLineFilter)
 }
 
 // Does not throw error to avoid breaking movers from proceeding if logs can't be gathered
 func updateMoverStatusForJob(ctx context.Context, logger logr.Logger, moverStatus *volsyncv1alpha1.MoverStatus,
-	jobName, jobNamespace string, jobFailed bool, logLineFilter func(string) *string) {
+	jobName, jobNamespace string, jobFailed bool, logLineFilter func(string) *string) *MoverResult {
 	l := logger.WithValues("jobName", jobName)
 
 	if logLineFilter == nil {
@@ -183,9 +200,10 @@ func updateMoverStatusForJob(ctx context.Context, logger logr.Logger, moverStatu
 		logLineFilter = AllLines
 	}
 
-	moverStatus.Logs = "" // clear out logs in case we can't get new ones
-
-	moverStatus.Result = volsyncv1alpha1.MoverResultSuccessful
+	// clear out logs and results in case we can't get new ones
+	*moverStatus = volsyncv1alpha1.MoverStatus{
+		Result: volsyncv1alpha1.MoverResultSuccessful,
+	}
 	if jobFailed {
 		moverStatus.Result = volsyncv1alpha1.MoverResultFailed
 	}
@@ -193,21 +211,58 @@ func updateMoverStatusForJob(ctx context.Context, logger logr.Logger, moverStatu
 	pod, err := GetNewestPodForJob(ctx, logger, jobName, jobNamespace, jobFailed)
 	if err != nil {
 		l.Error(err, "Unable to get pod for job to get mover logs")
-		return
+		return nil
 	}
 
 	if pod == nil {
 		l.Info("No mover pods found to get logs from")
-		return
+		return nil
+	}
+
+	result, err := moverResultForPod(pod)
+	if err != nil {
+		l.Error(err, "Unable to get the result of the mover", "podName", pod.GetName())
+	}
+	if result != nil {
+		applyMoverResult(moverStatus, result)
 	}
 
 	l.Info("Getting logs for pod", "podName", pod.GetName(), "pod", pod)
-	filteredLogs, err := getPodLogs(ctx, l, pod.GetName(), jobNamespace, logLineFilter)
+	filteredLogs, err := getPodLogs(ctx, l, pod.GetName(), jobNamespace, GetMoverLogTailLines(), logLineFilter)
 	if err != nil {
 		l.Error(err, "Error getting logs from pod")
 	}
 
 	moverStatus.Logs = truncateMoverLog(filteredLogs)
+	return result
+}
+
+// GetResultForSuccessfulJob returns the newest successful pod of a job and the
+// result written by its mover, which is nil if it didn't write one
+func GetResultForSuccessfulJob(ctx context.Context, logger logr.Logger,
+	jobName, jobNamespace string) (*corev1.Pod, *MoverResult, error) {
+	pod, err := GetNewestPodForJob(ctx, logger, jobName, jobNamespace, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pod == nil {
+		return nil, nil, fmt.Errorf("no successful pod found for job %s", jobName)
+	}
+	result, err := moverResultForPod(pod)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pod, result, nil
+}
+
+// GetPodLogs returns the last tailLines lines of the logs of a pod, filtered
+// by logLineFilter. Unlike the logs saved to the mover status, these aren't
+// truncated, so they can be used to pass results that don't fit in the mover
+// result from a job back to the controller. The caller bounds how much is
+// read with tailLines.
+func GetPodLogs(ctx context.Context, logger logr.Logger, pod *corev1.Pod,
+	tailLines int64, logLineFilter func(string) *string) (string, error) {
+	return getPodLogs(ctx, logger, pod.GetName(), pod.GetNamespace(), tailLines, logLineFilter)
 }
 
 func truncateMoverLog(moverLog string) string {
@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+const (
+	// Minimum time between reads of the progress of a running mover
+	progressUpdateInterval = 30 * time.Second
+	// Number of lines read from the end of the mover logs to find the latest
+	// progress report
+	progressTailLines int64 = 100
+)
+
+// MoverProgress is the progress reported by a mover in a line of its logs.
+// Values that the line doesn't report are zero.
+type MoverProgress struct {
+	BytesDone      int64
+	BytesTotal     int64
+	FilesDone      int64
+	FilesTotal     int64
+	BytesPerSecond int64
+	// Remaining is the time the mover expects to need to finish, if it
+	// estimates it
+	Remaining *time.Duration
+}
+
+// ProgressParser extracts the progress from a line of mover logs. It returns
+// nil if the line doesn't report progress.
+type ProgressParser func(line string) *MoverProgress
+
+// LatestProgress returns the last progress reported in the logs, or nil if
+// there is none
+func LatestProgress(reader io.Reader, parser ProgressParser) (*MoverProgress, error) {
+	var latest *MoverProgress
+	lineScanner := bufio.NewScanner(reader)
+	for lineScanner.Scan() {
+		if p := parser(lineScanner.Text()); p != nil {
+			latest = p
+		}
+	}
+	return latest, lineScanner.Err()
+}
+
+// NewSyncProgress converts the progress reported by a mover at now into its
+// status. When the mover doesn't report them, the throughput is calculated
+// from the previous progress and the completion time from the throughput.
+func NewSyncProgress(previous *volsyncv1alpha1.SyncProgress, p *MoverProgress,
+	now time.Time) *volsyncv1alpha1.SyncProgress {
+	progress := &volsyncv1alpha1.SyncProgress{
+		BytesDone:      p.BytesDone,
+		BytesTotal:     p.BytesTotal,
+		FilesDone:      p.FilesDone,
+		FilesTotal:     p.FilesTotal,
+		BytesPerSecond: p.BytesPerSecond,
+		LastUpdateTime: &metav1.Time{Time: now},
+	}
+	if progress.BytesPerSecond == 0 && previous != nil && previous.LastUpdateTime != nil &&
+		p.BytesDone >= previous.BytesDone {
+		if elapsed := now.Sub(previous.LastUpdateTime.Time).Seconds(); elapsed > 0 {
+			progress.BytesPerSecond = int64(float64(p.BytesDone-previous.BytesDone) / elapsed)
+		}
+	}
+
+	switch {
+	case p.Remaining != nil:
+		progress.EstimatedCompletionTime = &metav1.Time{Time: now.Add(*p.Remaining)}
+	case p.BytesTotal > p.BytesDone && progress.BytesPerSecond > 0:
+		remaining := time.Duration(float64(p.BytesTotal-p.BytesDone) / float64(progress.BytesPerSecond) *
+			float64(time.Second))
+		progress.EstimatedCompletionTime = &metav1.Time{Time: now.Add(remaining)}
+	}
+	return progress
+}
+
+// UpdateSyncProgress reads the latest progress from the logs of the running
+// pod of a mover job and records it in the status of owner. The logs are read
+// at most every progressUpdateInterval. Errors are only logged since the
+// progress is informational. It is only called by the movers whose logs have a
+// progress parser (Restic and Kopia).
+func UpdateSyncProgress(ctx context.Context, logger logr.Logger, owner client.Object,
+	jobName, jobNamespace string, parser ProgressParser) {
+	status := syncProgressStatus(owner)
+	if status == nil {
+		return
+	}
+	now := time.Now()
+	if *status != nil && (*status).LastUpdateTime != nil &&
+		now.Sub((*status).LastUpdateTime.Time) < progressUpdateInterval {
+		return
+	}
+
+	l := logger.WithValues("jobName", jobName)
+	runningPods, _, _, err := GetPodsForJob(ctx, l, jobName, jobNamespace)
+	if err != nil {
+		return
+	}
+	pod := getNewestPod(runningPods)
+	if pod == nil {
+		return
+	}
+	stream, err := streamPodLogs(ctx, l, pod.GetName(), jobNamespace, progressTailLines)
+	if err != nil {
+		return
+	}
+	defer stream.Close()
+
+	latest, err := LatestProgress(stream, parser)
+	if err != nil {
+		l.Error(err, "unable to read the progress of the mover")
+	}
+	if latest != nil {
+		*status = NewSyncProgress(*status, latest, now)
+	}
+}
+
+// syncProgressStatus returns the progress field of the status of owner, or
+// nil if owner doesn't have one
+func syncProgressStatus(owner client.Object) **volsyncv1alpha1.SyncProgress {
+	switch o := owner.(type) {
+	case *volsyncv1alpha1.ReplicationSource:
+		if o.Status != nil {
+			return &o.Status.Progress
+		}
+	case *volsyncv1alpha1.ReplicationDestination:
+		if o.Status != nil {
+			return &o.Status.Progress
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+var _ = Describe("Sync progress", func() {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	It("should return the last progress in the logs", func() {
+		parser := func(line string) *utils.MoverProgress {
+			if done, found := strings.CutPrefix(line, "done "); found {
+				return &utils.MoverProgress{BytesDone: int64(len(done))}
+			}
+			return nil
+		}
+		p, err := utils.LatestProgress(strings.NewReader("done x\nother\ndone xyz\nother\n"), parser)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(Equal(&utils.MoverProgress{BytesDone: 3}))
+
+		p, err = utils.LatestProgress(strings.NewReader("other\n"), parser)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p).To(BeNil())
+	})
+
+	It("should use the estimate of the mover", func() {
+		progress := utils.NewSyncProgress(nil, &utils.MoverProgress{
+			BytesDone: 100, BytesTotal: 400, BytesPerSecond: 10, Remaining: ptr.To(time.Minute),
+		}, now)
+		Expect(progress.BytesPerSecond).To(Equal(int64(10)))
+		Expect(progress.EstimatedCompletionTime.Time).To(Equal(now.Add(time.Minute)))
+		Expect(progress.LastUpdateTime.Time).To(Equal(now))
+	})
+
+	It("should estimate the throughput and completion from the previous progress", func() {
+		previous := &volsyncv1alpha1.SyncProgress{
+			BytesDone:      100,
+			LastUpdateTime: &metav1.Time{Time: now.Add(-30 * time.Second)},
+		}
+		progress := utils.NewSyncProgress(previous, &utils.MoverProgress{BytesDone: 400, BytesTotal: 1000}, now)
+		Expect(progress.BytesPerSecond).To(Equal(int64(10)))
+		Expect(progress.EstimatedCompletionTime.Time).To(Equal(now.Add(time.Minute)))
+
+		// No estimate without a previous progress
+		progress = utils.NewSyncProgress(nil, &utils.MoverProgress{BytesDone: 400, BytesTotal: 1000}, now)
+		Expect(progress.BytesPerSecond).To(BeZero())
+		Expect(progress.EstimatedCompletionTime).To(BeNil())
+	})
+})
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"slices"
+	"time"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+// DestinationVolumeOptions returns the volume options of each mover that is
+// set in the spec
+func DestinationVolumeOptions(
+	spec *volsyncv1alpha1.ReplicationDestinationSpec) []*volsyncv1alpha1.ReplicationDestinationVolumeOptions {
+	opts := []*volsyncv1alpha1.ReplicationDestinationVolumeOptions{}
+	if spec.Rsync != nil {
+		opts = append(opts, &spec.Rsync.ReplicationDestinationVolumeOptions)
+	}
+	if spec.RsyncTLS != nil {
+		opts = append(opts, &spec.RsyncTLS.ReplicationDestinationVolumeOptions)
+	}
+	if spec.Rclone != nil {
+		opts = append(opts, &spec.Rclone.ReplicationDestinationVolumeOptions)
+	}
+	if spec.Restic != nil {
+		opts = append(opts, &spec.Restic.ReplicationDestinationVolumeOptions)
+	}
+	if spec.Kopia != nil {
+		opts = append(opts, &spec.Kopia.ReplicationDestinationVolumeOptions)
+	}
+	return opts
+}
+
+// DestinationSnapshotRetention returns the snapshotRetention policy of the
+// mover of a ReplicationDestination, or nil if it has none
+func DestinationSnapshotRetention(
+	spec *volsyncv1alpha1.ReplicationDestinationSpec) *volsyncv1alpha1.SnapshotRetentionPolicy {
+	for _, opts := range DestinationVolumeOptions(spec) {
+		if opts.SnapshotRetention != nil {
+			return opts.SnapshotRetention
+		}
+	}
+	return nil
+}
+
+// RetainImages splits images into those retained by the policy and those to
+// prune. Both are returned most recent first. Without a policy, only the most
+// recent image is retained. The hourly and daily buckets are in UTC.
+func RetainImages(policy *volsyncv1alpha1.SnapshotRetentionPolicy,
+	images []volsyncv1alpha1.RetainedImage) ([]volsyncv1alpha1.RetainedImage, []volsyncv1alpha1.RetainedImage) {
+	last, hourly, daily := 1, 0, 0
+	if policy != nil {
+		if policy.Last != nil {
+			last = int(*policy.Last)
+		}
+		if policy.Hourly != nil {
+			hourly = int(*policy.Hourly)
+		}
+		if policy.Daily != nil {
+			daily = int(*policy.Daily)
+		}
+	}
+
+	sorted := slices.Clone(images)
+	slices.SortStableFunc(sorted, func(a, b volsyncv1alpha1.RetainedImage) int {
+		return b.SyncTime.Compare(a.SyncTime.Time)
+	})
+
+	// The first image of each bucket is the most recent one of the bucket
+	hours := map[time.Time]bool{}
+	days := map[time.Time]bool{}
+	keep := []volsyncv1alpha1.RetainedImage{}
+	prune := []volsyncv1alpha1.RetainedImage{}
+	for i, image := range sorted {
+		t := image.SyncTime.UTC()
+		hour := t.Truncate(time.Hour)
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+		retained := i < last
+		if !hours[hour] && len(hours) < hourly {
+			hours[hour] = true
+			retained = true
+		}
+		if !days[day] && len(days) < daily {
+			days[day] = true
+			retained = true
+		}
+		if retained {
+			keep = append(keep, image)
+		} else {
+			prune = append(prune, image)
+		}
+	}
+	return keep, prune
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+var _ = Describe("Snapshot retention", func() {
+	var images []volsyncv1alpha1.RetainedImage
+	names := func(images []volsyncv1alpha1.RetainedImage) []string {
+		n := []string{}
+		for _, image := range images {
+			n = append(n, image.Image.Name)
+		}
+		return n
+	}
+
+	BeforeEach(func() {
+		// A snapshot every 20 minutes for 2 days, oldest first
+		start := time.Date(2026, 1, 1, 0, 10, 0, 0, time.UTC)
+		images = nil
+		for t := start; t.Before(start.Add(48 * time.Hour)); t = t.Add(20 * time.Minute) {
+			images = append(images, volsyncv1alpha1.RetainedImage{
+				Image: corev1.TypedLocalObjectReference{
+					Kind: "VolumeSnapshot",
+					Name: "snap-" + t.Format("0102-1504"),
+				},
+				SyncTime: metav1.NewTime(t),
+			})
+		}
+	})
+
+	It("should only keep the most recent image without a policy", func() {
+		keep, prune := utils.RetainImages(nil, images)
+		Expect(names(keep)).To(Equal([]string{"snap-0102-2350"}))
+		Expect(prune).To(HaveLen(len(images) - 1))
+		Expect(prune[0].Image.Name).To(Equal("snap-0102-2330"))
+	})
+
+	It("should keep the last images", func() {
+		keep, _ := utils.RetainImages(&volsyncv1alpha1.SnapshotRetentionPolicy{
+			Last: ptr.To(int32(3)),
+		}, images)
+		Expect(names(keep)).To(Equal([]string{"snap-0102-2350", "snap-0102-2330", "snap-0102-2310"}))
+	})
+
+	It("should keep the most recent image of each hour and day", func() {
+		keep, prune := utils.RetainImages(&volsyncv1alpha1.SnapshotRetentionPolicy{
+			Hourly: ptr.To(int32(3)),
+			Daily:  ptr.To(int32(2)),
+		}, images)
+		Expect(names(keep)).To(Equal([]string{
+			"snap-0102-2350", // Last, hourly and daily
+			"snap-0102-2250", // Hourly
+			"snap-0102-2150", // Hourly
+			"snap-0101-2350", // Daily
+		}))
+		Expect(prune).To(HaveLen(len(images) - 4))
+	})
+
+	It("should find the policy of the mover", func() {
+		spec := &volsyncv1alpha1.ReplicationDestinationSpec{
+			Restic: &volsyncv1alpha1.ReplicationDestinationResticSpec{},
+		}
+		Expect(utils.DestinationSnapshotRetention(spec)).To(BeNil())
+		policy := &volsyncv1alpha1.SnapshotRetentionPolicy{Last: ptr.To(int32(2))}
+		spec.Restic.SnapshotRetention = policy
+		Expect(utils.DestinationSnapshotRetention(spec)).To(Equal(policy))
+	})
+})
@@ -0,0 +1,216 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+const minutesPerDay = 24 * 60
+
+// TransferLimits are the bandwidth limits of a data mover, in bytes per
+// second. A limit of zero means unlimited.
+type TransferLimits struct {
+	Upload   int64
+	Download int64
+}
+
+// UploadKiB returns the upload limit in KiB per second, rounded up
+func (l TransferLimits) UploadKiB() int64 {
+	return (l.Upload + 1023) / 1024
+}
+
+// DownloadKiB returns the download limit in KiB per second, rounded up
+func (l TransferLimits) DownloadKiB() int64 {
+	return (l.Download + 1023) / 1024
+}
+
+// ValidateTransferPolicy checks that the limits of a transfer policy are
+// positive and that its windows can be parsed and don't overlap
+func ValidateTransferPolicy(policy *volsyncv1alpha1.TransferPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if err := validateTransferLimits(policy.UploadLimit, policy.DownloadLimit); err != nil {
+		return err
+	}
+	covered := make([]bool, minutesPerDay)
+	for i, w := range policy.Windows {
+		start, end, err := parseTransferWindow(w)
+		if err != nil {
+			return fmt.Errorf("transfer window %d: %w", i, err)
+		}
+		if start == end {
+			return fmt.Errorf("transfer window %d: start and end cannot be the same", i)
+		}
+		if err := validateTransferLimits(w.UploadLimit, w.DownloadLimit); err != nil {
+			return fmt.Errorf("transfer window %d: %w", i, err)
+		}
+		for m := start; m != end; m = (m + 1) % minutesPerDay {
+			if covered[m] {
+				return fmt.Errorf("transfer window %d overlaps another window", i)
+			}
+			covered[m] = true
+		}
+	}
+	return nil
+}
+
+func validateTransferLimits(limits ...*resource.Quantity) error {
+	for _, l := range limits {
+		if l != nil && l.Sign() <= 0 {
+			return fmt.Errorf("transfer limit must be greater than zero: %s", l.String())
+		}
+	}
+	return nil
+}
+
+// parseTransferWindow returns the start and end of the window in minutes
+// since midnight
+func parseTransferWindow(w volsyncv1alpha1.TransferWindow) (int, int, error) {
+	start, err := parseTransferTime(w.Start)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseTransferTime(w.End)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+func parseTransferTime(hhmm string) (int, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, must be HH:MM", hhmm)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// TransferLimitsAt returns the limits of the transfer policy that apply at t
+func TransferLimitsAt(policy *volsyncv1alpha1.TransferPolicy, t time.Time) TransferLimits {
+	if policy == nil {
+		return TransferLimits{}
+	}
+	t = t.UTC()
+	now := t.Hour()*60 + t.Minute()
+	for _, w := range policy.Windows {
+		start, end, err := parseTransferWindow(w)
+		if err != nil {
+			continue
+		}
+		inWindow := start <= now && now < end
+		if start > end {
+			// Spans midnight
+			inWindow = now >= start || now < end
+		}
+		if inWindow {
+			return transferLimits(w.UploadLimit, w.DownloadLimit)
+		}
+	}
+	return transferLimits(policy.UploadLimit, policy.DownloadLimit)
+}
+
+// SyncTransferLimits returns the limits of the transfer policy that apply to
+// the current synchronization of owner. They are taken at the time the
+// synchronization started so that they don't change, causing the mover Job to
+// be recreated, while the synchronization is running.
+func SyncTransferLimits(owner client.Object, policy *volsyncv1alpha1.TransferPolicy) TransferLimits {
+	syncStart := time.Now()
+	switch o := owner.(type) {
+	case *volsyncv1alpha1.ReplicationSource:
+		if o.Status != nil && !o.Status.LastSyncStartTime.IsZero() {
+			syncStart = o.Status.LastSyncStartTime.Time
+		}
+	case *volsyncv1alpha1.ReplicationDestination:
+		if o.Status != nil && !o.Status.LastSyncStartTime.IsZero() {
+			syncStart = o.Status.LastSyncStartTime.Time
+		}
+	}
+	return TransferLimitsAt(policy, syncStart)
+}
+
+func transferLimits(upload, download *resource.Quantity) TransferLimits {
+	limits := TransferLimits{}
+	if upload != nil {
+		limits.Upload = upload.Value()
+	}
+	if download != nil {
+		limits.Download = download.Value()
+	}
+	return limits
+}
+
+// RcloneBandwidthLimit returns the value of rclone's --bwlimit option for the
+// transfer policy. The windows are turned into an rclone timetable so that
+// the limits change as the windows start and end. It returns "" if the policy
+// doesn't limit transfers.
+func RcloneBandwidthLimit(policy *volsyncv1alpha1.TransferPolicy) string {
+	if policy == nil {
+		return ""
+	}
+	defaults := transferLimits(policy.UploadLimit, policy.DownloadLimit)
+	if len(policy.Windows) == 0 {
+		if defaults == (TransferLimits{}) {
+			return ""
+		}
+		return rcloneLimits(defaults)
+	}
+
+	// The default limits apply from the end of each window, unless another
+	// window starts at the same time
+	changes := map[int]TransferLimits{}
+	for _, w := range policy.Windows {
+		_, end, err := parseTransferWindow(w)
+		if err == nil {
+			changes[end] = defaults
+		}
+	}
+	for _, w := range policy.Windows {
+		start, _, err := parseTransferWindow(w)
+		if err == nil {
+			changes[start] = transferLimits(w.UploadLimit, w.DownloadLimit)
+		}
+	}
+
+	entries := make([]string, 0, len(changes))
+	for _, m := range slices.Sorted(maps.Keys(changes)) {
+		entries = append(entries, fmt.Sprintf("%02d:%02d,%s", m/60, m%60, rcloneLimits(changes[m])))
+	}
+	return strings.Join(entries, " ")
+}
+
+// rcloneLimits formats limits as rclone's UP:DOWN bandwidth
+func rcloneLimits(limits TransferLimits) string {
+	rate := func(kib int64) string {
+		if kib == 0 {
+			return "off"
+		}
+		return fmt.Sprintf("%dK", kib)
+	}
+	return rate(limits.UploadKiB()) + ":" + rate(limits.DownloadKiB())
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package utils_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+var _ = Describe("Transfer policies", func() {
+	var policy *volsyncv1alpha1.TransferPolicy
+	at := func(hhmm string) time.Time {
+		t, err := time.Parse("15:04", hhmm)
+		Expect(err).NotTo(HaveOccurred())
+		return t
+	}
+
+	BeforeEach(func() {
+		// 1MiB/s up and 2MiB/s down, unlimited at night, 4MiB/s up at lunch
+		policy = &volsyncv1alpha1.TransferPolicy{
+			UploadLimit:   ptr.To(resource.MustParse("1Mi")),
+			DownloadLimit: ptr.To(resource.MustParse("2Mi")),
+			Windows: []volsyncv1alpha1.TransferWindow{
+				{Start: "22:00", End: "06:00"},
+				{Start: "12:00", End: "13:00", UploadLimit: ptr.To(resource.MustParse("4Mi"))},
+			},
+		}
+	})
+
+	It("should be valid", func() {
+		Expect(utils.ValidateTransferPolicy(policy)).To(Succeed())
+		Expect(utils.ValidateTransferPolicy(nil)).To(Succeed())
+	})
+
+	It("should reject overlapping and empty windows", func() {
+		policy.Windows[1].End = "23:00"
+		Expect(utils.ValidateTransferPolicy(policy)).To(MatchError(ContainSubstring("overlaps")))
+		policy.Windows[1].End = policy.Windows[1].Start
+		Expect(utils.ValidateTransferPolicy(policy)).To(MatchError(ContainSubstring("cannot be the same")))
+	})
+
+	It("should pick the limits of the window in effect", func() {
+		Expect(utils.TransferLimitsAt(policy, at("09:00"))).To(Equal(utils.TransferLimits{
+			Upload: 1024 * 1024, Download: 2 * 1024 * 1024}))
+		Expect(utils.TransferLimitsAt(policy, at("12:30"))).To(Equal(utils.TransferLimits{
+			Upload: 4 * 1024 * 1024}))
+		Expect(utils.TransferLimitsAt(policy, at("23:30"))).To(Equal(utils.TransferLimits{}))
+		Expect(utils.TransferLimitsAt(policy, at("05:59"))).To(Equal(utils.TransferLimits{}))
+		Expect(utils.TransferLimitsAt(policy, at("06:00")).Upload).To(Equal(int64(1024 * 1024)))
+		Expect(utils.TransferLimitsAt(nil, at("09:00"))).To(Equal(utils.TransferLimits{}))
+	})
+
+	It("should use the limits of the time the sync started", func() {
+		rs := &volsyncv1alpha1.ReplicationSource{
+			Status: &volsyncv1alpha1.ReplicationSourceStatus{
+				LastSyncStartTime: &metav1.Time{Time: at("23:00")},
+			},
+		}
+		Expect(utils.SyncTransferLimits(rs, policy)).To(Equal(utils.TransferLimits{}))
+	})
+
+	It("should round limits up to KiB", func() {
+		limits := utils.TransferLimits{Upload: 1500, Download: 1024}
+		Expect(limits.UploadKiB()).To(Equal(int64(2)))
+		Expect(limits.DownloadKiB()).To(Equal(int64(1)))
+	})
+
+	It("should build an rclone timetable", func() {
+		Expect(utils.RcloneBandwidthLimit(policy)).To(Equal(
+			"06:00,1024K:2048K 12:00,4096K:off 13:00,1024K:2048K 22:00,off:off"))
+
+		policy.Windows = nil
+		Expect(utils.RcloneBandwidthLimit(policy)).To(Equal("1024K:2048K"))
+		Expect(utils.RcloneBandwidthLimit(&volsyncv1alpha1.TransferPolicy{})).To(BeEmpty())
+	})
+})
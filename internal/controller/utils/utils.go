@@ -24,6 +24,7 @@ import (
 	"hash/crc32"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -181,6 +182,16 @@ func PvcIsBlockMode(pvc *corev1.PersistentVolumeClaim) bool {
 	return pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == corev1.PersistentVolumeBlock
 }
 
+// CapacityEnvVar returns an environment variable with the requested capacity
+// of the PVC, in bytes. Movers use it to report the capacity of the source
+// volume to the destination.
+func CapacityEnvVar(name string, pvc *corev1.PersistentVolumeClaim) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name:  name,
+		Value: strconv.FormatInt(pvc.Spec.Resources.Requests.Storage().Value(), 10),
+	}
+}
+
 func AppendEnvVarsForClusterWideProxy(envVars []corev1.EnvVar) []corev1.EnvVar {
 	httpProxy, ok := os.LookupEnv("HTTP_PROXY")
 	if ok {
@@ -333,19 +344,66 @@ func UpdatePodTemplateSpecWithMoverVolumes(ctx context.Context, c client.Client,
 	return nil
 }
 
+// validateMoverVolumeMountPath checks that the mount path of a moverVolume is
+// a single, safe path element
+func validateMoverVolumeMountPath(mountPath string) error {
+	if mountPath == "" {
+		return fmt.Errorf("moverVolume mount path cannot be empty")
+	}
+	if strings.Contains(mountPath, "/") {
+		return fmt.Errorf("moverVolume mount path cannot contain path separators: %s", mountPath)
+	}
+	if strings.Contains(mountPath, "..") {
+		return fmt.Errorf("moverVolume mount path cannot contain path traversal: %s", mountPath)
+	}
+	return nil
+}
+
+// ValidateMoverVolumeSpecs performs the moverVolume checks of the admission
+// webhooks that do not require looking up the referenced PVCs or Secrets. They
+// are stricter than ValidateMoverVolumes so that objects created before the
+// webhooks were enabled keep reconciling.
+func ValidateMoverVolumeSpecs(moverVolumes []volsyncv1alpha1.MoverVolume) error {
+	mountPaths := map[string]bool{}
+	for _, mv := range moverVolumes {
+		if err := validateMoverVolumeMountPath(mv.MountPath); err != nil {
+			return err
+		}
+		if mountPaths[mv.MountPath] {
+			return fmt.Errorf("moverVolume mount path must be unique: %s", mv.MountPath)
+		}
+		mountPaths[mv.MountPath] = true
+
+		sources := 0
+		if mv.VolumeSource.PersistentVolumeClaim != nil {
+			if mv.VolumeSource.PersistentVolumeClaim.ClaimName == "" {
+				return fmt.Errorf("moverVolume %s: persistentVolumeClaim.claimName cannot be empty", mv.MountPath)
+			}
+			sources++
+		}
+		if mv.VolumeSource.Secret != nil {
+			if mv.VolumeSource.Secret.SecretName == "" {
+				return fmt.Errorf("moverVolume %s: secret.secretName cannot be empty", mv.MountPath)
+			}
+			sources++
+		}
+		if mv.VolumeSource.NFS != nil {
+			sources++
+		}
+		if sources != 1 {
+			return fmt.Errorf("moverVolume %s: exactly one volumeSource must be specified", mv.MountPath)
+		}
+	}
+	return nil
+}
+
 func ValidateMoverVolumes(ctx context.Context, c client.Client, logger logr.Logger,
 	namespace string, moverVolumes []volsyncv1alpha1.MoverVolume,
 ) error {
 	for _, mv := range moverVolumes {
 		// Validate mount path is safe
-		if mv.MountPath == "" {
-			return fmt.Errorf("moverVolume mount path cannot be empty")
-		}
-		if strings.Contains(mv.MountPath, "/") {
-			return fmt.Errorf("moverVolume mount path cannot contain path separators: %s", mv.MountPath)
-		}
-		if strings.Contains(mv.MountPath, "..") {
-			return fmt.Errorf("moverVolume mount path cannot contain path traversal: %s", mv.MountPath)
+		if err := validateMoverVolumeMountPath(mv.MountPath); err != nil {
+			return err
 		}
 
 		if mv.VolumeSource.PersistentVolumeClaim != nil {
@@ -711,6 +711,31 @@ var _ = Describe("utils tests", func() {
 			})
 		})
 
+		Describe("ValidateMoverVolumeSpecs", func() {
+			It("should reject a moverVolume without a volume source", func() {
+				Expect(utils.ValidateMoverVolumeSpecs([]volsyncv1alpha1.MoverVolume{
+					{MountPath: "empty"},
+				})).To(MatchError(ContainSubstring("exactly one volumeSource")))
+			})
+
+			It("should reject duplicate mount paths", func() {
+				Expect(utils.ValidateMoverVolumeSpecs([]volsyncv1alpha1.MoverVolume{
+					{
+						MountPath: "dup",
+						VolumeSource: volsyncv1alpha1.MoverVolumeSource{
+							Secret: &corev1.SecretVolumeSource{SecretName: "sec1"},
+						},
+					},
+					{
+						MountPath: "dup",
+						VolumeSource: volsyncv1alpha1.MoverVolumeSource{
+							Secret: &corev1.SecretVolumeSource{SecretName: "sec2"},
+						},
+					},
+				})).To(MatchError(ContainSubstring("must be unique")))
+			})
+		})
+
 		Describe("UpdatePodTemplateSpecWithMoverVolumes", func() {
 			When("no pod template spec", func() {
 				It("should not fail", func() {
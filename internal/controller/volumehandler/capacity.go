@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package volumehandler
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+// FollowsSourceCapacity returns true if the volumes are expanded as the
+// source volume grows
+func (vh *VolumeHandler) FollowsSourceCapacity() bool {
+	return vh.capacityPolicy == volsyncv1alpha1.CapacityPolicyFollowSource
+}
+
+// sourceCapacity returns the capacity of the source volume, as reported by
+// the latest mover of the owning ReplicationDestination
+func (vh *VolumeHandler) sourceCapacity() *resource.Quantity {
+	rd, ok := vh.owner.(*volsyncv1alpha1.ReplicationDestination)
+	if !ok || rd.Status == nil || rd.Status.LatestMoverStatus == nil {
+		return nil
+	}
+	return rd.Status.LatestMoverStatus.SourceCapacity
+}
+
+// targetCapacity returns the capacity that a volume of the given capacity
+// should have. With the FollowSource capacityPolicy, the data volume grows to
+// the capacity of the source, and the volumes scaled with it (such as caches)
+// grow by the same factor.
+func (vh *VolumeHandler) targetCapacity(capacity resource.Quantity) resource.Quantity {
+	source := vh.sourceCapacity()
+	if !vh.FollowsSourceCapacity() || source == nil {
+		return capacity
+	}
+	if !vh.scaledWithData {
+		if source.Cmp(capacity) > 0 {
+			return source.DeepCopy()
+		}
+		return capacity
+	}
+	if vh.dataCapacity == nil || vh.dataCapacity.Sign() <= 0 || source.Cmp(*vh.dataCapacity) <= 0 {
+		return capacity
+	}
+	scaled := math.Ceil(float64(capacity.Value()) * float64(source.Value()) / float64(vh.dataCapacity.Value()))
+	return *resource.NewQuantity(int64(scaled), resource.BinarySI)
+}
+
+// expandedCapacity returns the capacity to request for an existing PVC. It is
+// the target capacity if the PVC needs to grow and its StorageClass allows
+// it. Otherwise the PVC keeps its capacity, since PVCs can't shrink.
+func (vh *VolumeHandler) expandedCapacity(ctx context.Context, logger logr.Logger,
+	pvc *corev1.PersistentVolumeClaim, target resource.Quantity) resource.Quantity {
+	current := pvc.Spec.Resources.Requests.Storage().DeepCopy()
+	if vh.sourceCapacity() == nil {
+		vh.setCapacityCondition(metav1.ConditionUnknown, volsyncv1alpha1.CapacityReasonSourceCapacityUnknown,
+			"Waiting for a mover to report the capacity of the source volume")
+		return current
+	}
+	if target.Cmp(current) <= 0 {
+		vh.setCapacityCondition(metav1.ConditionFalse, volsyncv1alpha1.CapacityReasonCapacitySufficient,
+			"The destination volume is at least as large as the source volume")
+		return current
+	}
+
+	if err := vh.checkExpansionAllowed(ctx, pvc); err != nil {
+		logger.Info("unable to expand PVC", "PVC", pvc.GetName(), "reason", err.Error())
+		vh.eventRecorder.Eventf(vh.owner, pvc, corev1.EventTypeWarning,
+			volsyncv1alpha1.EvRPVCExpansionNotAllowed, volsyncv1alpha1.EvAExpandPVC,
+			"unable to expand %s to %s: %s", utils.KindAndName(vh.client.Scheme(), pvc), target.String(), err)
+		vh.setCapacityCondition(metav1.ConditionTrue, volsyncv1alpha1.CapacityReasonExpansionNotAllowed,
+			fmt.Sprintf("Unable to expand PVC %s from %s to %s: %s", pvc.GetName(), current.String(),
+				target.String(), err))
+		return current
+	}
+
+	logger.Info("expanding PVC", "PVC", pvc.GetName(), "from", current.String(), "to", target.String())
+	vh.eventRecorder.Eventf(vh.owner, pvc, corev1.EventTypeNormal,
+		volsyncv1alpha1.EvRPVCExpanded, volsyncv1alpha1.EvAExpandPVC,
+		"expanding %s from %s to %s to follow the source volume",
+		utils.KindAndName(vh.client.Scheme(), pvc), current.String(), target.String())
+	vh.setCapacityCondition(metav1.ConditionFalse, volsyncv1alpha1.CapacityReasonCapacitySufficient,
+		fmt.Sprintf("Expanding PVC %s to %s", pvc.GetName(), target.String()))
+	return target
+}
+
+// checkExpansionAllowed returns an error if the PVC can't be expanded.
+// Kubernetes only expands dynamically provisioned PVCs whose StorageClass
+// allows volume expansion.
+func (vh *VolumeHandler) checkExpansionAllowed(ctx context.Context, pvc *corev1.PersistentVolumeClaim) error {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return fmt.Errorf("the PVC has no StorageClass")
+	}
+	sc := &storagev1.StorageClass{}
+	if err := vh.client.Get(ctx, client.ObjectKey{Name: *pvc.Spec.StorageClassName}, sc); err != nil {
+		return fmt.Errorf("unable to get StorageClass %s: %w", *pvc.Spec.StorageClassName, err)
+	}
+	if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+		return fmt.Errorf("StorageClass %s doesn't allow volume expansion", sc.GetName())
+	}
+	return nil
+}
+
+// expandProvidedPVC expands a PVC that VolSync didn't provision to the
+// capacity of the source
+func (vh *VolumeHandler) expandProvidedPVC(ctx context.Context, logger logr.Logger,
+	pvc *corev1.PersistentVolumeClaim) error {
+	current := pvc.Spec.Resources.Requests.Storage().DeepCopy()
+	capacity := vh.expandedCapacity(ctx, logger, pvc, vh.targetCapacity(current))
+	if capacity.Cmp(current) == 0 {
+		return nil
+	}
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = capacity
+	return vh.client.Update(ctx, pvc)
+}
+
+// setCapacityCondition reports whether the data volume follows the capacity
+// of the source in the status of the owning ReplicationDestination. Volumes
+// scaled with the data volume only report failures as events.
+func (vh *VolumeHandler) setCapacityCondition(status metav1.ConditionStatus, reason, message string) {
+	rd, ok := vh.owner.(*volsyncv1alpha1.ReplicationDestination)
+	if !ok || rd.Status == nil || vh.scaledWithData {
+		return
+	}
+	apimeta.SetStatusCondition(&rd.Status.Conditions, metav1.Condition{
+		Type:    volsyncv1alpha1.ConditionCapacityExpansionFailed,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// removeCapacityCondition removes the condition when the capacity doesn't
+// follow the source
+func (vh *VolumeHandler) removeCapacityCondition() {
+	rd, ok := vh.owner.(*volsyncv1alpha1.ReplicationDestination)
+	if !ok || rd.Status == nil || vh.scaledWithData {
+		return
+	}
+	apimeta.RemoveStatusCondition(&rd.Status.Conditions, volsyncv1alpha1.ConditionCapacityExpansionFailed)
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package volumehandler
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var _ = Describe("Volumehandler capacityPolicy", func() {
+	var ctx = context.TODO()
+	var ns *corev1.Namespace
+	var rd *volsyncv1alpha1.ReplicationDestination
+	logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
+
+	newVH := func(options ...VHOption) *VolumeHandler {
+		vh, err := NewVolumeHandler(append([]VHOption{
+			WithClient(k8sClient),
+			WithOwner(rd),
+			FromDestination(&rd.Spec.Rsync.ReplicationDestinationVolumeOptions),
+		}, options...)...)
+		Expect(err).NotTo(HaveOccurred())
+		return vh
+	}
+	capacityCondition := func() *metav1.Condition {
+		return apimeta.FindStatusCondition(rd.Status.Conditions, volsyncv1alpha1.ConditionCapacityExpansionFailed)
+	}
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "vh-capacity-",
+			},
+		}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+		rd = &volsyncv1alpha1.ReplicationDestination{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mydest",
+				Namespace: ns.Name,
+			},
+			Spec: volsyncv1alpha1.ReplicationDestinationSpec{
+				Rsync: &volsyncv1alpha1.ReplicationDestinationRsyncSpec{
+					ReplicationDestinationVolumeOptions: volsyncv1alpha1.ReplicationDestinationVolumeOptions{
+						CopyMethod:     volsyncv1alpha1.CopyMethodDirect,
+						Capacity:       ptr.To(resource.MustParse("2Gi")),
+						AccessModes:    []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						CapacityPolicy: volsyncv1alpha1.CapacityPolicyFollowSource,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, rd)).To(Succeed())
+		rd.Status = &volsyncv1alpha1.ReplicationDestinationStatus{
+			LatestMoverStatus: &volsyncv1alpha1.MoverStatus{
+				SourceCapacity: ptr.To(resource.MustParse("5Gi")),
+			},
+		}
+	})
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, ns)).To(Succeed())
+	})
+
+	It("should follow the capacity of the source", func() {
+		vh := newVH()
+		Expect(vh.targetCapacity(resource.MustParse("2Gi"))).To(Equal(resource.MustParse("5Gi")))
+		// Volumes never shrink to the source
+		Expect(vh.targetCapacity(resource.MustParse("8Gi"))).To(Equal(resource.MustParse("8Gi")))
+	})
+
+	It("should scale caches by the growth of the data volume", func() {
+		vh := newVH(Capacity(ptr.To(resource.MustParse("1Gi"))), ScaledWithData())
+		target := vh.targetCapacity(resource.MustParse("1Gi"))
+		Expect(target.Value()).To(Equal(int64(2560 * 1024 * 1024)))
+	})
+
+	It("should not change the capacity with the Fixed policy", func() {
+		rd.Spec.Rsync.CapacityPolicy = volsyncv1alpha1.CapacityPolicyFixed
+		vh := newVH()
+		Expect(vh.targetCapacity(resource.MustParse("2Gi"))).To(Equal(resource.MustParse("2Gi")))
+	})
+
+	It("should create new PVCs with the capacity of the source", func() {
+		pvc, err := newVH().EnsureNewPVC(ctx, logger, "data", false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pvc).NotTo(BeNil())
+		Expect(*pvc.Spec.Resources.Requests.Storage()).To(Equal(resource.MustParse("5Gi")))
+	})
+
+	When("a provided PVC has no StorageClass", func() {
+		BeforeEach(func() {
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "provided",
+					Namespace: ns.Name,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					StorageClassName: ptr.To(""),
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("2Gi"),
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, pvc)).To(Succeed())
+		})
+
+		It("should report that it can't be expanded", func() {
+			pvc, err := newVH().UseProvidedPVC(ctx, "provided")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*pvc.Spec.Resources.Requests.Storage()).To(Equal(resource.MustParse("2Gi")))
+
+			cond := capacityCondition()
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal(volsyncv1alpha1.CapacityReasonExpansionNotAllowed))
+		})
+
+		It("should wait for the capacity of the source", func() {
+			rd.Status.LatestMoverStatus = nil
+			_, err := newVH().UseProvidedPVC(ctx, "provided")
+			Expect(err).NotTo(HaveOccurred())
+
+			cond := capacityCondition()
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionUnknown))
+		})
+
+		It("should remove the condition with the Fixed policy", func() {
+			rd.Status.Conditions = []metav1.Condition{{
+				Type:   volsyncv1alpha1.ConditionCapacityExpansionFailed,
+				Status: metav1.ConditionTrue,
+				Reason: volsyncv1alpha1.CapacityReasonExpansionNotAllowed,
+			}}
+			rd.Spec.Rsync.CapacityPolicy = volsyncv1alpha1.CapacityPolicyFixed
+			_, err := newVH().UseProvidedPVC(ctx, "provided")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capacityCondition()).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package volumehandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	vgsv1beta2 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta2"
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+// EnsurePVCsFromGroupSnapshot ensures the presence of a VolumeGroupSnapshot of
+// the PVCs matching selector and of a PVC restored from each of its member
+// snapshots. The PVCs are returned keyed by the name of the source PVC they are
+// a copy of. Note: it's possible to return nil, nil. In this case, the
+// operation should be retried.
+func (vh *VolumeHandler) EnsurePVCsFromGroupSnapshot(ctx context.Context, log logr.Logger,
+	selector *metav1.LabelSelector, name string,
+	isTemporary bool) (map[string]*corev1.PersistentVolumeClaim, error) {
+	if vh.copyMethod != volsyncv1alpha1.CopyMethodGroupSnapshot {
+		return nil, fmt.Errorf("unsupported copyMethod: %v -- must be GroupSnapshot with a PVC selector",
+			vh.copyMethod)
+	}
+
+	srcPVCs, err := vh.listGroupSourcePVCs(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	vgs, err := vh.ensureGroupSnapshot(ctx, log, selector, name, isTemporary)
+	if vgs == nil || err != nil {
+		return nil, err
+	}
+
+	members, err := vh.groupSnapshotMembers(ctx, log, vgs, srcPVCs)
+	if members == nil || err != nil {
+		return nil, err
+	}
+
+	// Group snapshots are only supported for Filesystem volumes
+	vh.volumeMode = &defaultVolumeMode
+	pvcs := make(map[string]*corev1.PersistentVolumeClaim, len(members))
+	for i := range srcPVCs {
+		src := &srcPVCs[i]
+		snap, ok := members[src.Name]
+		if !ok {
+			// PVC was labeled after the group snapshot was taken
+			continue
+		}
+		pvc, err := vh.pvcFromSnapshot(ctx, log, snap, src, groupMemberPVCName(name, src.Name), isTemporary)
+		if pvc == nil || err != nil {
+			return nil, err
+		}
+		pvcs[src.Name] = pvc
+	}
+	return pvcs, nil
+}
+
+// listGroupSourcePVCs returns the PVCs in the owner's namespace that match the
+// selector
+func (vh *VolumeHandler) listGroupSourcePVCs(ctx context.Context,
+	selector *metav1.LabelSelector) ([]corev1.PersistentVolumeClaim, error) {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := vh.client.List(ctx, pvcList, client.InNamespace(vh.owner.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return nil, err
+	}
+	if len(pvcList.Items) == 0 {
+		return nil, errors.New("no PVCs match the sourcePVCSelector")
+	}
+	for i := range pvcList.Items {
+		if utils.PvcIsBlockMode(&pvcList.Items[i]) {
+			return nil, fmt.Errorf("PVC %s has volumeMode Block -- GroupSnapshot only supports Filesystem volumes",
+				pvcList.Items[i].GetName())
+		}
+	}
+	return pvcList.Items, nil
+}
+
+// nolint: funlen
+func (vh *VolumeHandler) ensureGroupSnapshot(ctx context.Context, log logr.Logger,
+	selector *metav1.LabelSelector, name string, isTemporary bool) (*vgsv1beta2.VolumeGroupSnapshot, error) {
+	vgs := &vgsv1beta2.VolumeGroupSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: vh.owner.GetNamespace(),
+		},
+	}
+	logger := log.WithValues("groupSnapshot", client.ObjectKeyFromObject(vgs))
+
+	// See if the group snapshot exists
+	err := vh.client.Get(ctx, client.ObjectKeyFromObject(vgs), vgs)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		// Run the before hook (e.g. to quiesce the application) right before
+		// taking the copy
+		if err := utils.EnsureBeforeHook(ctx, vh.client, log, vh.eventRecorder, vh.owner); err != nil {
+			return nil, err
+		}
+	}
+
+	op, err := ctrlutil.CreateOrUpdate(ctx, vh.client, vgs, func() error {
+		if err := ctrl.SetControllerReference(vh.owner, vgs, vh.client.Scheme()); err != nil {
+			logger.Error(err, utils.ErrUnableToSetControllerRef)
+			return err
+		}
+		utils.SetOwnedByVolSync(vgs)
+		if isTemporary {
+			utils.MarkForCleanup(vh.owner, vgs)
+		}
+		if vgs.CreationTimestamp.IsZero() {
+			vgs.Spec.Source.Selector = selector.DeepCopy()
+			vgs.Spec.VolumeGroupSnapshotClassName = vh.volumeGroupSnapshotClassName
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error(err, "reconcile failed")
+		return nil, err
+	}
+	if !vgs.DeletionTimestamp.IsZero() {
+		logger.V(1).Info("group snapshot is being deleted-- need to wait")
+		return nil, nil
+	}
+	if op == ctrlutil.OperationResultCreated {
+		vh.eventRecorder.Eventf(vh.owner, vgs, corev1.EventTypeNormal,
+			volsyncv1alpha1.EvRSnapCreated, volsyncv1alpha1.EvACreateSnap,
+			"created %s", utils.KindAndName(vh.client.Scheme(), vgs))
+	}
+	// The group snapshot has been cut once its creationTime is set, so
+	// release the application then instead of waiting for it to be ready
+	if vgs.Status != nil && vgs.Status.CreationTime != nil {
+		if err = utils.EnsureAfterHook(ctx, vh.client, log, vh.eventRecorder, vh.owner); err != nil {
+			return nil, err
+		}
+	}
+	if vgs.Status == nil || vgs.Status.BoundVolumeGroupSnapshotContentName == nil ||
+		vgs.Status.ReadyToUse == nil || !*vgs.Status.ReadyToUse {
+		logger.V(1).Info("waiting for group snapshot to be ready")
+		if vgs.CreationTimestamp.Add(mover.SnapshotBindTimeout).Before(time.Now()) {
+			vh.eventRecorder.Eventf(vh.owner, vgs, corev1.EventTypeWarning,
+				volsyncv1alpha1.EvRSnapNotBound, volsyncv1alpha1.EvANone,
+				"waiting for %s to be ready; check VolumeGroupSnapshotClass name and ensure CSI driver "+
+					"supports volume group snapshots", utils.KindAndName(vh.client.Scheme(), vgs))
+		}
+		return nil, nil
+	}
+
+	logger.V(1).Info("temporary group snapshot reconciled", "operation", op)
+	return vgs, nil
+}
+
+// groupSnapshotMembers returns the VolumeSnapshots that make up the group
+// snapshot, keyed by the name of the source PVC each of them is a snapshot of.
+// Snapshots of PVCs that no longer match the selector are left out.
+// The member snapshots are created by the snapshot controller and don't refer
+// to their source PVC, so the CSI volume handles recorded in the
+// VolumeGroupSnapshotContent are used to match them with the source PVCs.
+//
+//nolint:funlen
+func (vh *VolumeHandler) groupSnapshotMembers(ctx context.Context, log logr.Logger,
+	vgs *vgsv1beta2.VolumeGroupSnapshot,
+	srcPVCs []corev1.PersistentVolumeClaim) (map[string]*snapv1.VolumeSnapshot, error) {
+	logger := log.WithValues("groupSnapshot", client.ObjectKeyFromObject(vgs))
+
+	snapList := &snapv1.VolumeSnapshotList{}
+	if err := vh.client.List(ctx, snapList, client.InNamespace(vgs.GetNamespace())); err != nil {
+		return nil, err
+	}
+	snaps := []*snapv1.VolumeSnapshot{}
+	for i := range snapList.Items {
+		if isGroupSnapshotMember(&snapList.Items[i], vgs) {
+			snaps = append(snaps, &snapList.Items[i])
+		}
+	}
+	if len(snaps) == 0 {
+		logger.V(1).Info("waiting for the member snapshots of the group snapshot")
+		return nil, nil
+	}
+
+	vgsc := &vgsv1beta2.VolumeGroupSnapshotContent{}
+	if err := vh.client.Get(ctx, client.ObjectKey{Name: *vgs.Status.BoundVolumeGroupSnapshotContentName},
+		vgsc); err != nil {
+		return nil, err
+	}
+	volumeHandles := map[string]string{} // snapshot handle -> volume handle
+	if vgsc.Status != nil {
+		for _, info := range vgsc.Status.VolumeSnapshotInfoList {
+			volumeHandles[info.SnapshotHandle] = info.VolumeHandle
+		}
+	}
+
+	srcByVolumeHandle := map[string]string{} // volume handle -> source PVC name
+	for i := range srcPVCs {
+		if srcPVCs[i].Spec.VolumeName == "" {
+			continue
+		}
+		pv := &corev1.PersistentVolume{}
+		if err := vh.client.Get(ctx, client.ObjectKey{Name: srcPVCs[i].Spec.VolumeName}, pv); err != nil {
+			return nil, err
+		}
+		if pv.Spec.CSI != nil {
+			srcByVolumeHandle[pv.Spec.CSI.VolumeHandle] = srcPVCs[i].GetName()
+		}
+	}
+
+	members := make(map[string]*snapv1.VolumeSnapshot, len(snaps))
+	for _, snap := range snaps {
+		if snap.Status == nil || snap.Status.BoundVolumeSnapshotContentName == nil {
+			logger.V(1).Info("waiting for member snapshot to be bound", "snapshot", snap.GetName())
+			return nil, nil
+		}
+		vsc := &snapv1.VolumeSnapshotContent{}
+		if err := vh.client.Get(ctx, client.ObjectKey{Name: *snap.Status.BoundVolumeSnapshotContentName},
+			vsc); err != nil {
+			return nil, err
+		}
+		if vsc.Status == nil || vsc.Status.SnapshotHandle == nil {
+			logger.V(1).Info("waiting for member snapshot handle", "snapshot", snap.GetName())
+			return nil, nil
+		}
+		volumeHandle, ok := volumeHandles[*vsc.Status.SnapshotHandle]
+		if !ok {
+			logger.V(1).Info("waiting for the volume of member snapshot", "snapshot", snap.GetName())
+			return nil, nil
+		}
+		srcName, ok := srcByVolumeHandle[volumeHandle]
+		if !ok {
+			// The source PVC was deleted or unlabeled after the group
+			// snapshot was taken, so it is no longer backed up
+			logger.Info("skipping member snapshot of a PVC that is no longer selected",
+				"snapshot", snap.GetName())
+			continue
+		}
+		members[srcName] = snap
+	}
+	return members, nil
+}
+
+func isGroupSnapshotMember(snap *snapv1.VolumeSnapshot, vgs *vgsv1beta2.VolumeGroupSnapshot) bool {
+	if snap.Status != nil && snap.Status.VolumeGroupSnapshotName != nil &&
+		*snap.Status.VolumeGroupSnapshotName == vgs.GetName() {
+		return true
+	}
+	for _, ref := range snap.GetOwnerReferences() {
+		if ref.UID == vgs.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+// groupMemberPVCName returns the name of the PVC restored from the snapshot of
+// srcName in the group snapshot called name
+func groupMemberPVCName(name, srcName string) string {
+	pvcName := name + "-" + srcName
+	if len(pvcName) > validation.DNS1123SubdomainMaxLength {
+		return name + "-" + utils.GetHashedName(srcName)
+	}
+	return pvcName
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package volumehandler
+
+import (
+	"context"
+
+	vgsv1beta2 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumegroupsnapshot/v1beta2"
+	snapv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var _ = Describe("Volumehandler group snapshots", func() {
+	var ctx = context.TODO()
+	var ns *corev1.Namespace
+	var rs *volsyncv1alpha1.ReplicationSource
+	var vh *VolumeHandler
+	logger := zap.New(zap.UseDevMode(true), zap.WriteTo(GinkgoWriter))
+	const groupName = "mygroup"
+
+	BeforeEach(func() {
+		ns = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "vh-group-",
+			},
+		}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+
+		rs = &volsyncv1alpha1.ReplicationSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mysrc",
+				Namespace: ns.Name,
+			},
+			Spec: volsyncv1alpha1.ReplicationSourceSpec{
+				SourcePVCSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "db"},
+				},
+				Restic: &volsyncv1alpha1.ReplicationSourceResticSpec{
+					ReplicationSourceVolumeOptions: volsyncv1alpha1.ReplicationSourceVolumeOptions{
+						CopyMethod:                   volsyncv1alpha1.CopyMethodGroupSnapshot,
+						VolumeGroupSnapshotClassName: ptr.To("groupclass"),
+					},
+					Repository: "restic-secret",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, rs)).To(Succeed())
+
+		var err error
+		vh, err = NewVolumeHandler(
+			WithClient(k8sClient),
+			WithOwner(rs),
+			FromSource(&rs.Spec.Restic.ReplicationSourceVolumeOptions),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+	AfterEach(func() {
+		Expect(k8sClient.Delete(ctx, ns)).To(Succeed())
+	})
+
+	When("no PVCs match the selector", func() {
+		It("should return an error", func() {
+			pvcs, err := vh.EnsurePVCsFromGroupSnapshot(ctx, logger, rs.Spec.SourcePVCSelector, groupName, true)
+			Expect(err).To(MatchError(ContainSubstring("no PVCs match")))
+			Expect(pvcs).To(BeNil())
+		})
+	})
+
+	When("the copyMethod isn't GroupSnapshot", func() {
+		It("should return an error", func() {
+			rs.Spec.Restic.CopyMethod = volsyncv1alpha1.CopyMethodSnapshot
+			vh, err := NewVolumeHandler(
+				WithClient(k8sClient),
+				WithOwner(rs),
+				FromSource(&rs.Spec.Restic.ReplicationSourceVolumeOptions),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = vh.EnsurePVCsFromGroupSnapshot(ctx, logger, rs.Spec.SourcePVCSelector, groupName, true)
+			Expect(err).To(MatchError(ContainSubstring("unsupported copyMethod")))
+		})
+	})
+
+	When("PVCs match the selector", func() {
+		srcNames := []string{"data", "wal"}
+
+		BeforeEach(func() {
+			for _, srcName := range srcNames {
+				createGroupTestPV(ctx, ns.Name+"-"+srcName, "handle-"+srcName)
+				pvc := &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      srcName,
+						Namespace: ns.Name,
+						Labels:    map[string]string{"app": "db"},
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("1Gi"),
+							},
+						},
+						VolumeName: ns.Name + "-" + srcName,
+					},
+				}
+				Expect(k8sClient.Create(ctx, pvc)).To(Succeed())
+			}
+			// Not part of the group
+			other := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "other",
+					Namespace: ns.Name,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, other)).To(Succeed())
+		})
+
+		It("should restore a PVC from the snapshot of each of them", func() {
+			// 1st try creates the group snapshot, which isn't ready yet
+			pvcs, err := vh.EnsurePVCsFromGroupSnapshot(ctx, logger, rs.Spec.SourcePVCSelector, groupName, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pvcs).To(BeNil())
+
+			vgs := &vgsv1beta2.VolumeGroupSnapshot{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: groupName, Namespace: ns.Name}, vgs)).To(Succeed())
+			Expect(vgs.Spec.Source.Selector.MatchLabels).To(Equal(map[string]string{"app": "db"}))
+			Expect(*vgs.Spec.VolumeGroupSnapshotClassName).To(Equal("groupclass"))
+			Expect(vgs.Labels).To(HaveKey("volsync.backube/cleanup"))
+
+			// Make the group snapshot ready, as the snapshot controller would
+			vgscName := ns.Name + "-vgsc"
+			vgsc := &vgsv1beta2.VolumeGroupSnapshotContent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: vgscName,
+				},
+				Spec: vgsv1beta2.VolumeGroupSnapshotContentSpec{
+					VolumeGroupSnapshotRef: corev1.ObjectReference{
+						Name:      groupName,
+						Namespace: ns.Name,
+					},
+					DeletionPolicy: snapv1.VolumeSnapshotContentDelete,
+					Driver:         "csi.example.com",
+					Source: vgsv1beta2.VolumeGroupSnapshotContentSource{
+						VolumeHandles: []string{"handle-data", "handle-wal"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, vgsc)).To(Succeed())
+			vgsc.Status = &vgsv1beta2.VolumeGroupSnapshotContentStatus{}
+			for _, srcName := range srcNames {
+				vgsc.Status.VolumeSnapshotInfoList = append(vgsc.Status.VolumeSnapshotInfoList,
+					vgsv1beta2.VolumeSnapshotInfo{
+						VolumeHandle:   "handle-" + srcName,
+						SnapshotHandle: "snaphandle-" + srcName,
+					})
+			}
+			Expect(k8sClient.Status().Update(ctx, vgsc)).To(Succeed())
+			vgs.Status = &vgsv1beta2.VolumeGroupSnapshotStatus{
+				BoundVolumeGroupSnapshotContentName: &vgscName,
+				ReadyToUse:                          ptr.To(true),
+			}
+			Expect(k8sClient.Status().Update(ctx, vgs)).To(Succeed())
+
+			// The member snapshots don't exist yet
+			pvcs, err = vh.EnsurePVCsFromGroupSnapshot(ctx, logger, rs.Spec.SourcePVCSelector, groupName, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pvcs).To(BeNil())
+
+			// Member snapshots are named after their snapshot handles so they
+			// don't give away their source PVC
+			for _, srcName := range srcNames {
+				createGroupTestMember(ctx, vgs, "snaphandle-"+srcName)
+			}
+
+			pvcs, err = vh.EnsurePVCsFromGroupSnapshot(ctx, logger, rs.Spec.SourcePVCSelector, groupName, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pvcs).To(HaveLen(2))
+			for _, srcName := range srcNames {
+				Expect(pvcs).To(HaveKey(srcName))
+				pvc := pvcs[srcName]
+				Expect(pvc.Name).To(Equal(groupName + "-" + srcName))
+				Expect(pvc.Spec.DataSource.Kind).To(Equal("VolumeSnapshot"))
+				Expect(pvc.Spec.DataSource.Name).To(Equal("member-snaphandle-" + srcName))
+				Expect(*pvc.Spec.Resources.Requests.Storage()).To(Equal(resource.MustParse("1Gi")))
+				Expect(pvc.Labels).To(HaveKey("volsync.backube/cleanup"))
+			}
+		})
+	})
+})
+
+func createGroupTestPV(ctx context.Context, name, volumeHandle string) {
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("1Gi"),
+			},
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			PersistentVolumeSource: corev1.PersistentVolumeSource{
+				CSI: &corev1.CSIPersistentVolumeSource{
+					Driver:       "csi.example.com",
+					VolumeHandle: volumeHandle,
+				},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, pv)).To(Succeed())
+	DeferCleanup(func() {
+		Expect(k8sClient.Delete(ctx, pv)).To(Succeed())
+	})
+}
+
+// createGroupTestMember creates a bound member snapshot of the group snapshot,
+// as the snapshot controller would
+func createGroupTestMember(ctx context.Context, vgs *vgsv1beta2.VolumeGroupSnapshot, snapshotHandle string) {
+	vscName := vgs.Namespace + "-" + snapshotHandle
+	vsc := &snapv1.VolumeSnapshotContent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: vscName,
+		},
+		Spec: snapv1.VolumeSnapshotContentSpec{
+			VolumeSnapshotRef: corev1.ObjectReference{
+				Name:      "member-" + snapshotHandle,
+				Namespace: vgs.Namespace,
+			},
+			DeletionPolicy: snapv1.VolumeSnapshotContentDelete,
+			Driver:         "csi.example.com",
+			Source: snapv1.VolumeSnapshotContentSource{
+				SnapshotHandle: ptr.To(snapshotHandle),
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, vsc)).To(Succeed())
+	DeferCleanup(func() {
+		Expect(k8sClient.Delete(ctx, vsc)).To(Succeed())
+	})
+	vsc.Status = &snapv1.VolumeSnapshotContentStatus{
+		SnapshotHandle: ptr.To(snapshotHandle),
+	}
+	Expect(k8sClient.Status().Update(ctx, vsc)).To(Succeed())
+
+	snap := &snapv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "member-" + snapshotHandle,
+			Namespace: vgs.Namespace,
+		},
+		Spec: snapv1.VolumeSnapshotSpec{
+			Source: snapv1.VolumeSnapshotSource{
+				VolumeSnapshotContentName: &vscName,
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, snap)).To(Succeed())
+	snap.Status = &snapv1.VolumeSnapshotStatus{
+		BoundVolumeSnapshotContentName: &vscName,
+		VolumeGroupSnapshotName:        ptr.To(vgs.Name),
+	}
+	Expect(k8sClient.Status().Update(ctx, snap)).To(Succeed())
+}
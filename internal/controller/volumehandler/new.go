@@ -80,6 +80,7 @@ func FromSource(s *volsyncv1alpha1.ReplicationSourceVolumeOptions) VHOption {
 		vh.storageClassName = s.StorageClassName
 		vh.accessModes = s.AccessModes
 		vh.volumeSnapshotClassName = s.VolumeSnapshotClassName
+		vh.volumeGroupSnapshotClassName = s.VolumeGroupSnapshotClassName
 	}
 }
 
@@ -92,6 +93,8 @@ func FromDestination(d *volsyncv1alpha1.ReplicationDestinationVolumeOptions) VHO
 		vh.storageClassName = d.StorageClassName
 		vh.accessModes = d.AccessModes
 		vh.volumeSnapshotClassName = d.VolumeSnapshotClassName
+		vh.capacityPolicy = d.CapacityPolicy
+		vh.dataCapacity = d.Capacity
 	}
 }
 
@@ -113,6 +116,14 @@ func Capacity(c *resource.Quantity) VHOption {
 	}
 }
 
+// ScaledWithData marks a volume, such as a cache, whose capacity grows by the
+// same factor as the data volume with the FollowSource capacityPolicy
+func ScaledWithData() VHOption {
+	return func(vh *VolumeHandler) {
+		vh.scaledWithData = true
+	}
+}
+
 func VolumeMode(vm *corev1.PersistentVolumeMode) VHOption {
 	return func(vh *VolumeHandler) {
 		vh.volumeMode = vm
@@ -59,6 +59,13 @@ type VolumeHandler struct {
 	accessModes             []corev1.PersistentVolumeAccessMode
 	volumeMode              *corev1.PersistentVolumeMode
 	volumeSnapshotClassName *string
+	// Only used with CopyMethodGroupSnapshot
+	volumeGroupSnapshotClassName *string
+	capacityPolicy               volsyncv1alpha1.CapacityPolicyType
+	// Configured capacity of the data volume, used to scale other volumes
+	// with the FollowSource capacityPolicy
+	dataCapacity   *resource.Quantity
+	scaledWithData bool
 }
 
 // EnsurePVCFromSrc ensures the presence of a PVC that is based on the provided
@@ -76,6 +83,11 @@ func (vh *VolumeHandler) EnsurePVCFromSrc(ctx context.Context, log logr.Logger,
 	case volsyncv1alpha1.CopyMethodNone:
 		fallthrough // Same as CopyMethodDirect
 	case volsyncv1alpha1.CopyMethodDirect:
+		// There's no copy to wait for, the after hook is run once the
+		// synchronization completes
+		if err := utils.EnsureBeforeHook(ctx, vh.client, log, vh.eventRecorder, vh.owner); err != nil {
+			return nil, err
+		}
 		return src, nil
 	case volsyncv1alpha1.CopyMethodClone:
 		return vh.ensureClone(ctx, log, src, name, isTemporary)
@@ -135,7 +147,15 @@ func (vh *VolumeHandler) EnsureImage(ctx context.Context, log logr.Logger,
 }
 
 func (vh *VolumeHandler) UseProvidedPVC(ctx context.Context, pvcName string) (*corev1.PersistentVolumeClaim, error) {
-	return vh.getPVCByName(ctx, pvcName)
+	pvc, err := vh.getPVCByName(ctx, pvcName)
+	if err != nil {
+		return pvc, err
+	}
+	if !vh.FollowsSourceCapacity() {
+		vh.removeCapacityCondition()
+		return pvc, nil
+	}
+	return pvc, vh.expandProvidedPVC(ctx, ctrl.LoggerFrom(ctx).WithValues("PVC", pvcName), pvc)
 }
 
 func (vh *VolumeHandler) getPVCByName(ctx context.Context, pvcName string) (*corev1.PersistentVolumeClaim, error) {
@@ -154,6 +174,9 @@ func (vh *VolumeHandler) EnsureNewPVC(ctx context.Context, log logr.Logger,
 	name string, isTemporary bool) (*corev1.PersistentVolumeClaim, error) {
 	logger := log.WithValues("PVC", name)
 
+	if !vh.FollowsSourceCapacity() {
+		vh.removeCapacityCondition()
+	}
 	if vh.volumeMode == nil {
 		vh.volumeMode = &defaultVolumeMode
 	}
@@ -194,8 +217,15 @@ func (vh *VolumeHandler) EnsureNewPVC(ctx context.Context, log logr.Logger,
 			utils.MarkForCleanup(vh.owner, pvc)
 		}
 
+		capacity := *vh.capacity
+		if vh.FollowsSourceCapacity() {
+			capacity = vh.targetCapacity(capacity)
+			if !pvc.CreationTimestamp.IsZero() {
+				capacity = vh.expandedCapacity(ctx, logger, pvc, capacity)
+			}
+		}
 		pvc.Spec.Resources.Requests = corev1.ResourceList{
-			corev1.ResourceStorage: *vh.capacity,
+			corev1.ResourceStorage: capacity,
 		}
 		return nil
 	})
@@ -345,6 +375,12 @@ func (vh *VolumeHandler) ensureClone(ctx context.Context, log logr.Logger,
 		if wait || err != nil {
 			return nil, err
 		}
+
+		// Run the before hook (e.g. to quiesce the application) right before
+		// taking the copy
+		if err := utils.EnsureBeforeHook(ctx, vh.client, log, vh.eventRecorder, vh.owner); err != nil {
+			return nil, err
+		}
 	}
 
 	op, err := ctrlutil.CreateOrUpdate(ctx, vh.client, clone, func() error {
@@ -406,6 +442,12 @@ func (vh *VolumeHandler) ensureClone(ctx context.Context, log logr.Logger,
 			"created %s as a clone of %s",
 			utils.KindAndName(vh.client.Scheme(), clone), utils.KindAndName(vh.client.Scheme(), src))
 	}
+	// Release the application as soon as the clone has been requested. With a
+	// WaitForFirstConsumer StorageClass, it only binds once the mover Pod is
+	// scheduled.
+	if err = utils.EnsureAfterHook(ctx, vh.client, log, vh.eventRecorder, vh.owner); err != nil {
+		return nil, err
+	}
 	if !clone.CreationTimestamp.IsZero() &&
 		clone.CreationTimestamp.Add(mover.PVCBindTimeout).Before(time.Now()) &&
 		clone.Status.Phase != corev1.ClaimBound {
@@ -449,6 +491,12 @@ func (vh *VolumeHandler) ensureSnapshot(ctx context.Context, log logr.Logger,
 		if wait || err != nil {
 			return nil, err
 		}
+
+		// Run the before hook (e.g. to quiesce the application) right before
+		// taking the copy
+		if err := utils.EnsureBeforeHook(ctx, vh.client, log, vh.eventRecorder, vh.owner); err != nil {
+			return nil, err
+		}
 	}
 
 	op, err := ctrlutil.CreateOrUpdate(ctx, vh.client, snap, func() error {
@@ -480,6 +528,13 @@ func (vh *VolumeHandler) ensureSnapshot(ctx context.Context, log logr.Logger,
 			"created %s from %s",
 			utils.KindAndName(vh.client.Scheme(), snap), utils.KindAndName(vh.client.Scheme(), src))
 	}
+	// The snapshot has been cut once its creationTime is set, so release the
+	// application then instead of waiting for the snapshot to be ready
+	if snap.Status != nil && snap.Status.CreationTime != nil {
+		if err = utils.EnsureAfterHook(ctx, vh.client, log, vh.eventRecorder, vh.owner); err != nil {
+			return nil, err
+		}
+	}
 	if snap.Status == nil || snap.Status.BoundVolumeSnapshotContentName == nil {
 		logger.V(1).Info("waiting for snapshot to be bound")
 		if snap.CreationTimestamp.Add(mover.SnapshotBindTimeout).Before(time.Now()) {
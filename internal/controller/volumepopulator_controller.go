@@ -54,12 +54,15 @@ const (
 	populatorPvcPrefix      string = "vs-prime"
 	annotationSelectedNode  string = "volume.kubernetes.io/selected-node"
 	annotationPopulatedFrom string = "volsync.backube/populated-from"
+	annotationRetainedImage string = "volsync.backube/retained-image"
 	labelPvcPrime           string = utils.VolsyncLabelPrefix + "/populator-pvc-for"
 
 	VolPopPVCToReplicationDestinationIndex string = "volPopPvc.spec.dataSourceRef.Name"
+	VolPopPVCToVolSyncRestoreIndex         string = "volPopPvc.spec.dataSourceRef.volSyncRestoreName"
 	VolPopPVCToStorageClassIndex           string = "volPopPvc.spec.storageClassName"
 
-	VolPopCRName string = "volsync-replicationdestination"
+	VolPopCRName               string = "volsync-replicationdestination"
+	VolPopVolSyncRestoreCRName string = "volsync-volsyncrestore"
 )
 
 func IndexFieldsForVolumePopulator(ctx context.Context, fieldIndexer client.FieldIndexer) error {
@@ -83,8 +86,27 @@ func IndexFieldsForVolumePopulator(ctx context.Context, fieldIndexer client.Fiel
 		return err
 	}
 
+	// Index on PVCs - used to find pvc referring to (by dataSourceRef) a VolSyncRestore
+	err = fieldIndexer.IndexField(ctx, &corev1.PersistentVolumeClaim{},
+		VolPopPVCToVolSyncRestoreIndex, func(o client.Object) []string {
+			pvc, ok := o.(*corev1.PersistentVolumeClaim)
+			if !ok {
+				// This shouldn't happen
+				return []string{}
+			}
+			if !pvcHasVolSyncRestoreDataSourceRef(pvc) {
+				// This pvc is not using a VolSyncRestore as a DataSourceRef, don't add to index
+				return []string{}
+			}
+
+			return []string{pvc.Spec.DataSourceRef.Name}
+		})
+	if err != nil {
+		return err
+	}
+
 	// Index on PVCs - used to find pvcs (for this volume populator) referring to a storageclass
-	// Will only index PVCs that are using a ReplicationDestination as DataSourceRef
+	// Will only index PVCs that are using a ReplicationDestination or VolSyncRestore as DataSourceRef
 	return fieldIndexer.IndexField(ctx, &corev1.PersistentVolumeClaim{},
 		VolPopPVCToStorageClassIndex, func(o client.Object) []string {
 			res := []string{}
@@ -93,8 +115,9 @@ func IndexFieldsForVolumePopulator(ctx context.Context, fieldIndexer client.Fiel
 				// This shouldn't happen
 				return res
 			}
-			if !pvcHasReplicationDestinationDataSourceRef(pvc) {
-				// This pvc is not using a ReplicationDestination as a DataSourceRef, don't add to index
+			if !pvcHasVolSyncDataSourceRef(pvc) {
+				// This pvc is not using a ReplicationDestination or VolSyncRestore as a DataSourceRef,
+				// don't add to index
 				return res
 			}
 
@@ -108,11 +131,9 @@ func IndexFieldsForVolumePopulator(ctx context.Context, fieldIndexer client.Fiel
 }
 
 // If the VolumePopulator CRD is present (i.e. the VolumePopulator API is available), then make sure we have
-// VolumePopulator CR to register VolSync ReplicationDestination as a valid VolumePopulator
+// VolumePopulator CRs to register VolSync ReplicationDestination and VolSyncRestore as valid VolumePopulators
 func EnsureVolSyncVolumePopulatorCRIfCRDPresent(ctx context.Context,
 	k8sClient client.Client, logger logr.Logger) error {
-	logger = logger.WithValues("VolPopCRName", VolPopCRName)
-
 	ok, err := isVolumePopulatorCRDPresent(ctx, k8sClient)
 	if err != nil {
 		return err
@@ -123,15 +144,25 @@ func EnsureVolSyncVolumePopulatorCRIfCRDPresent(ctx context.Context,
 		return nil // VolumePopulator kind is not present, nothing to do
 	}
 
+	if err := ensureVolumePopulatorCR(ctx, k8sClient, logger, VolPopCRName, "ReplicationDestination"); err != nil {
+		return err
+	}
+	return ensureVolumePopulatorCR(ctx, k8sClient, logger, VolPopVolSyncRestoreCRName, "VolSyncRestore")
+}
+
+func ensureVolumePopulatorCR(ctx context.Context, k8sClient client.Client, logger logr.Logger,
+	name, kind string) error {
+	logger = logger.WithValues("VolPopCRName", name)
+
 	volSyncVP := &volumepopulatorv1beta1.VolumePopulator{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: VolPopCRName,
+			Name: name,
 		},
 	}
 	op, err := ctrlutil.CreateOrUpdate(ctx, k8sClient, volSyncVP, func() error {
 		volSyncVP.SourceKind = metav1.GroupKind{
 			Group: volsyncv1alpha1.GroupVersion.Group,
-			Kind:  "ReplicationDestination",
+			Kind:  kind,
 		}
 		// Add VolSync label
 		utils.SetOwnedByVolSync(volSyncVP)
@@ -168,14 +199,16 @@ func isVolumePopulatorCRDPresent(ctx context.Context, k8sClient client.Client) (
 //nolint:lll
 //+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=persistentvolumes,verbs=get;list;watch;patch
-//+kubebuilder:rbac:groups=volsync.backube,resources=replicationdestinations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=volsync.backube,resources=replicationdestinations,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups=volsync.backube,resources=volsyncrestores,verbs=get;list;watch
 //+kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 //+kubebuilder:rbac:groups=populator.storage.k8s.io,resources=volumepopulators,verbs=get;list;watch;create;update;patch
 
 // VolumePopulatorReconciler reconciles PVCs that use a dataSourceRef that refers to a
-// ReplicationDestination object.
+// ReplicationDestination or VolSyncRestore object.
 // The VolumePopulatorReconciler will create a PVC from the latest snapshot image in
-// a ReplicationDestination.
+// a ReplicationDestination, or restore a backup selected by the PVC's annotations
+// from the ReplicationDestination's or VolSyncRestore's repository.
 type VolumePopulatorReconciler struct {
 	client.Client
 	Log           logr.Logger
@@ -207,7 +240,7 @@ func (r *VolumePopulatorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	// Check to make sure we should be reconciling this PVC - just in case
-	if shouldReconcile := pvcHasReplicationDestinationDataSourceRef(pvc); !shouldReconcile {
+	if shouldReconcile := pvcHasVolSyncDataSourceRef(pvc); !shouldReconcile {
 		return ctrl.Result{}, nil
 	}
 
@@ -221,6 +254,15 @@ func (r *VolumePopulatorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 //nolint:funlen
 func (r *VolumePopulatorReconciler) reconcilePVC(ctx context.Context, logger logr.Logger,
 	pvc *corev1.PersistentVolumeClaim) (ctrl.Result, error) {
+	restore, err := populatorRestoreFor(pvc)
+	if err != nil {
+		logger.Error(err, "Unable to populate volume")
+		r.EventRecorder.Eventf(pvc, corev1.EventTypeWarning, volsyncv1alpha1.EvRVolPopPVCPopulatorError,
+			"Unable to populate volume: %s", err)
+		// Do not return error here - no use retrying
+		return ctrl.Result{}, nil
+	}
+
 	waitForFirstConsumer, nodeName, scResult := r.checkStorageClass(ctx, logger, pvc)
 	if scResult != nil {
 		return scResult.result()
@@ -236,11 +278,20 @@ func (r *VolumePopulatorReconciler) reconcilePVC(ctx context.Context, logger log
 
 	// If the PVC is unbound, we need to perform the population
 	if !isPVCBoundToVolume(pvc) {
-		pvcPrime, primeResult := r.reconcilePVCPrime(ctx, logger, pvc, pvcPrime, waitForFirstConsumer, nodeName)
+		pvcPrime, primeResult := r.reconcilePVCPrime(ctx, logger, pvc, pvcPrime, restore,
+			waitForFirstConsumer, nodeName)
 		if primeResult != nil {
 			return primeResult.result()
 		}
 
+		// A restored pvcPrime is bound before it is populated, so wait for
+		// the restore to complete before rebinding
+		if restore != nil {
+			if restoreResult := r.reconcilePopulatorRestore(ctx, logger, pvc, pvcPrime, restore); restoreResult != nil {
+				return restoreResult.result()
+			}
+		}
+
 		// Make sure any snapshots we've tried to use have owner reference of pvcPrime (for future cleanup)
 		err = r.ensureOwnerReferenceOnSnapshots(ctx, pvc, pvcPrime)
 		if err != nil {
@@ -318,8 +369,26 @@ func (r *VolumePopulatorReconciler) checkStorageClass(ctx context.Context, logge
 
 //nolint:funlen
 func (r *VolumePopulatorReconciler) reconcilePVCPrime(ctx context.Context, logger logr.Logger,
-	pvc, pvcPrime *corev1.PersistentVolumeClaim,
+	pvc, pvcPrime *corev1.PersistentVolumeClaim, restore *populatorRestore,
 	waitForFirstConsumer bool, nodeName string) (*corev1.PersistentVolumeClaim, *vpResult) {
+	if pvcPrime == nil && pvcHasVolSyncRestoreDataSourceRef(pvc) {
+		// The repository of the VolSyncRestore is restored into an empty pvcPrime
+		vsr, err := r.getVolSyncRestoreFromDataSourceRef(ctx, logger, pvc)
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return nil, &vpResult{ctrl.Result{}, err}
+			}
+			r.EventRecorder.Eventf(pvc, corev1.EventTypeWarning, volsyncv1alpha1.EvVolPopPVCVolSyncRestoreMissing,
+				"Unable to populate volume: %s", err)
+			// Do not return error - will rely on watches to reconcile once the VolSyncRestore is created
+			return nil, &vpResult{ctrl.Result{}, nil}
+		}
+
+		logger = logger.WithValues("volsyncrestore name", vsr.GetName(), "namespace", vsr.GetNamespace())
+		return r.createPVCPrime(ctx, logger, pvc, nil, waitForFirstConsumer, nodeName,
+			"Populator pvc created to restore "+restore.String())
+	}
+
 	if pvcPrime == nil {
 		// pvcPrime doesn't exist yet
 		// Check for existence of ReplicationDestination here - if PVC' was already there, then it may
@@ -338,6 +407,12 @@ func (r *VolumePopulatorReconciler) reconcilePVCPrime(ctx context.Context, logge
 
 		logger = logger.WithValues("replication destination name", rd.GetName(), "namespace", rd.GetNamespace())
 
+		if restore != nil {
+			// pvcPrime starts empty and the backup is restored into it
+			return r.createPVCPrime(ctx, logger, pvc, nil, waitForFirstConsumer, nodeName,
+				"Populator pvc created to restore "+restore.String())
+		}
+
 		if rd.Status == nil || rd.Status.LatestImage == nil {
 			logger.Info("ReplicationDestination has no latestImage, cannot populate volume yet")
 			r.EventRecorder.Eventf(pvc, corev1.EventTypeWarning, volsyncv1alpha1.EvRVolPopPVCReplicationDestNoLatestImage,
@@ -346,9 +421,16 @@ func (r *VolumePopulatorReconciler) reconcilePVCPrime(ctx context.Context, logge
 			return nil, &vpResult{ctrl.Result{}, nil}
 		}
 
-		latestImage := rd.Status.LatestImage
+		image, err := populatorImage(pvc, rd)
+		if err != nil {
+			logger.Error(err, "Unable to populate volume")
+			r.EventRecorder.Eventf(pvc, corev1.EventTypeWarning, volsyncv1alpha1.EvRVolPopPVCPopulatorError,
+				"Unable to populate volume: %s", err)
+			// Do not return error here - no use retrying
+			return nil, &vpResult{ctrl.Result{}, nil}
+		}
 
-		if !utils.IsSnapshot(latestImage) {
+		if !utils.IsSnapshot(image) {
 			// This means the replicationdestination is using "Direct" (aka "None") CopyMethod
 			dataSourceRefErr := fmt.Errorf("ReplicationDestination latestImage is not a volumesnapshot")
 			logger.Error(dataSourceRefErr, "Unable to populate volume")
@@ -358,57 +440,83 @@ func (r *VolumePopulatorReconciler) reconcilePVCPrime(ctx context.Context, logge
 			return nil, &vpResult{ctrl.Result{}, nil}
 		}
 
-		_, err = r.validateSnapshotAndLabel(ctx, logger, latestImage.Name, rd.GetNamespace(), pvc)
+		_, err = r.validateSnapshotAndLabel(ctx, logger, image.Name, rd.GetNamespace(), pvc)
 		if err != nil {
 			return nil, &vpResult{ctrl.Result{}, err}
 		}
 
-		pvcPrime = &corev1.PersistentVolumeClaim{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      getPVCPrimeName(pvc),
-				Namespace: pvc.GetNamespace(),
-			},
-			Spec: corev1.PersistentVolumeClaimSpec{
-				AccessModes:      pvc.Spec.AccessModes,
-				Resources:        pvc.Spec.Resources,
-				StorageClassName: pvc.Spec.StorageClassName,
-				VolumeMode:       pvc.Spec.VolumeMode,
-				DataSourceRef: &corev1.TypedObjectReference{
-					APIGroup: latestImage.APIGroup,
-					Kind:     latestImage.Kind,
-					Name:     latestImage.Name,
-					//Namespace: &rd.GetNamespace(), // Future, if we support cross-namespace
-				},
-			},
-		}
-		if waitForFirstConsumer {
-			pvcPrime.Annotations = map[string]string{
-				annotationSelectedNode: nodeName,
-			}
-		}
-		// Make pvcPrime owned by pvc - will be cleaned up via gc if pvc is deleted
-		if err := ctrl.SetControllerReference(pvc, pvcPrime, r.Client.Scheme()); err != nil {
-			logger.Error(err, utils.ErrUnableToSetControllerRef)
-			return nil, &vpResult{ctrl.Result{}, err}
-		}
-		utils.AddLabel(pvcPrime, labelPvcPrime, pvc.GetName()) // Use this filter in predicates in the &Owns() watcher
-		utils.SetOwnedByVolSync(pvcPrime)                      // Set created-by volsync label
+		return r.createPVCPrime(ctx, logger, pvc, &corev1.TypedObjectReference{
+			APIGroup: image.APIGroup,
+			Kind:     image.Kind,
+			Name:     image.Name,
+			//Namespace: &rd.GetNamespace(), // Future, if we support cross-namespace
+		}, waitForFirstConsumer, nodeName, "Populator pvc created from snapshot "+image.Name)
+	}
 
-		logger.Info("Creating temp populator pvc from snapshot", "volpop pvc name", pvcPrime.GetName())
-		err = r.Create(ctx, pvcPrime)
-		if err != nil {
-			r.EventRecorder.Eventf(pvc, corev1.EventTypeWarning, volsyncv1alpha1.EvRVolPopPVCCreationError,
-				"Failed to create populator PVC: %s", err)
-			return nil, &vpResult{ctrl.Result{}, err}
+	return pvcPrime, nil
+}
+
+// createPVCPrime creates the temp populator pvc, from the dataSourceRef if
+// there is one
+func (r *VolumePopulatorReconciler) createPVCPrime(ctx context.Context, logger logr.Logger,
+	pvc *corev1.PersistentVolumeClaim, dataSourceRef *corev1.TypedObjectReference,
+	waitForFirstConsumer bool, nodeName, createdMessage string) (*corev1.PersistentVolumeClaim, *vpResult) {
+	pvcPrime := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getPVCPrimeName(pvc),
+			Namespace: pvc.GetNamespace(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      pvc.Spec.AccessModes,
+			Resources:        pvc.Spec.Resources,
+			StorageClassName: pvc.Spec.StorageClassName,
+			VolumeMode:       pvc.Spec.VolumeMode,
+			DataSourceRef:    dataSourceRef,
+		},
+	}
+	if waitForFirstConsumer {
+		pvcPrime.Annotations = map[string]string{
+			annotationSelectedNode: nodeName,
 		}
+	}
+	// Make pvcPrime owned by pvc - will be cleaned up via gc if pvc is deleted
+	if err := ctrl.SetControllerReference(pvc, pvcPrime, r.Client.Scheme()); err != nil {
+		logger.Error(err, utils.ErrUnableToSetControllerRef)
+		return nil, &vpResult{ctrl.Result{}, err}
+	}
+	utils.AddLabel(pvcPrime, labelPvcPrime, pvc.GetName()) // Use this filter in predicates in the &Owns() watcher
+	utils.SetOwnedByVolSync(pvcPrime)                      // Set created-by volsync label
 
-		r.EventRecorder.Eventf(pvc, corev1.EventTypeNormal, volsyncv1alpha1.EvRVolPopPVCCreationSuccess,
-			"Populator pvc created from snapshot %s", latestImage.Name)
+	logger.Info("Creating temp populator pvc", "volpop pvc name", pvcPrime.GetName())
+	err := r.Create(ctx, pvcPrime)
+	if err != nil {
+		r.EventRecorder.Eventf(pvc, corev1.EventTypeWarning, volsyncv1alpha1.EvRVolPopPVCCreationError,
+			"Failed to create populator PVC: %s", err)
+		return nil, &vpResult{ctrl.Result{}, err}
 	}
 
+	r.EventRecorder.Eventf(pvc, corev1.EventTypeNormal, volsyncv1alpha1.EvRVolPopPVCCreationSuccess,
+		createdMessage)
 	return pvcPrime, nil
 }
 
+// populatorImage returns the image of the ReplicationDestination to populate
+// the pvc from. This is the latestImage, unless the pvc selects one of the
+// retainedImages by name with the retained-image annotation.
+func populatorImage(pvc *corev1.PersistentVolumeClaim,
+	rd *volsyncv1alpha1.ReplicationDestination) (*corev1.TypedLocalObjectReference, error) {
+	name, ok := pvc.GetAnnotations()[annotationRetainedImage]
+	if !ok || name == rd.Status.LatestImage.Name {
+		return rd.Status.LatestImage, nil
+	}
+	for i := range rd.Status.RetainedImages {
+		if rd.Status.RetainedImages[i].Image.Name == name {
+			return &rd.Status.RetainedImages[i].Image, nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not a retained image of the ReplicationDestination", name)
+}
+
 func (r *VolumePopulatorReconciler) rebindPVClaim(ctx context.Context, logger logr.Logger,
 	pvc, pvcPrime *corev1.PersistentVolumeClaim) *vpResult {
 	// Get PV from pvcPrime
@@ -437,12 +545,18 @@ func (r *VolumePopulatorReconciler) rebindPVClaim(ctx context.Context, logger lo
 		claimRef.Name != pvc.Name ||
 		claimRef.Namespace != pvc.Namespace ||
 		claimRef.UID != pvc.UID {
+		// A restored pvcPrime has no dataSourceRef, it is populated from the
+		// ReplicationDestination or VolSyncRestore itself
+		populatedFrom := pvc.Spec.DataSourceRef.Name
+		if pvcPrime.Spec.DataSourceRef != nil {
+			populatedFrom = pvcPrime.Spec.DataSourceRef.Name
+		}
 		// Make new PV with strategic patch values to perform the PV rebind
 		patchPv := &corev1.PersistentVolume{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: pv.Name,
 				Annotations: map[string]string{
-					annotationPopulatedFrom: pvc.Namespace + "/" + pvcPrime.Spec.DataSourceRef.Name,
+					annotationPopulatedFrom: pvc.Namespace + "/" + populatedFrom,
 				},
 			},
 			Spec: corev1.PersistentVolumeSpec{
@@ -479,10 +593,15 @@ func (r *VolumePopulatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			MaxConcurrentReconciles: 100,
 		}).
 		Owns(&corev1.PersistentVolumeClaim{}, builder.WithPredicates(pvcOwnedByPredicate())).
+		Owns(&volsyncv1alpha1.ReplicationDestination{}, builder.WithPredicates(pvcOwnedByPredicate())).
 		Watches(&volsyncv1alpha1.ReplicationDestination{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
 				return mapFuncReplicationDestinationToVolumePopulatorPVC(ctx, mgr.GetClient(), o)
 			}), builder.WithPredicates(replicationDestinationPredicate())).
+		Watches(&volsyncv1alpha1.VolSyncRestore{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+				return mapFuncVolSyncRestoreToVolumePopulatorPVC(ctx, mgr.GetClient(), o)
+			}), builder.WithPredicates(replicationDestinationPredicate())).
 		Watches(&storagev1.StorageClass{},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
 				return mapFuncStorageClassToVolumePopulatorPVC(ctx, mgr.GetClient(), o)
@@ -492,7 +611,7 @@ func (r *VolumePopulatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // Predicate for PVCs with owner (and controller=true) of a PVC - this is to reconcile our temp populator pvc
 // (i.e. pvcPrime).  In case there are other PVCs owned by a PVC, predicate will check for our labelPvcPrime to filter
-// those out.
+// those out. The ReplicationDestinations restoring into a pvcPrime carry the same label.
 func pvcOwnedByPredicate() predicate.Predicate {
 	return predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
@@ -517,7 +636,7 @@ func pvcForVolumePopulatorFilterPredicate() predicate.Predicate {
 			if !ok {
 				return false
 			}
-			return pvcHasReplicationDestinationDataSourceRef(pvc)
+			return pvcHasVolSyncDataSourceRef(pvc)
 		},
 		DeleteFunc: func(_ event.DeleteEvent) bool {
 			// Do not reconcile on PVC deletes
@@ -528,14 +647,14 @@ func pvcForVolumePopulatorFilterPredicate() predicate.Predicate {
 			if !ok {
 				return false
 			}
-			return pvcHasReplicationDestinationDataSourceRef(pvc)
+			return pvcHasVolSyncDataSourceRef(pvc)
 		},
 		GenericFunc: func(e event.GenericEvent) bool {
 			pvc, ok := e.Object.(*corev1.PersistentVolumeClaim)
 			if !ok {
 				return false
 			}
-			return pvcHasReplicationDestinationDataSourceRef(pvc)
+			return pvcHasVolSyncDataSourceRef(pvc)
 		},
 	}
 }
@@ -602,6 +721,32 @@ func mapFuncReplicationDestinationToVolumePopulatorPVC(ctx context.Context, k8sC
 	return filterRequestsOnlyUnboundPVCs(pvcList)
 }
 
+func mapFuncVolSyncRestoreToVolumePopulatorPVC(ctx context.Context, k8sClient client.Client,
+	o client.Object) []reconcile.Request {
+	logger := ctrl.Log.WithName("mapFuncVolSyncRestoreToVolumePopulatorPVC")
+
+	volSyncRestore, ok := o.(*volsyncv1alpha1.VolSyncRestore)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	// Find PVCs that use this VolSyncRestore in their dataSourceRef (using index)
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	err := k8sClient.List(ctx, pvcList,
+		client.MatchingFields{
+			VolPopPVCToVolSyncRestoreIndex: volSyncRestore.GetName()}, // custom index
+		client.InNamespace(volSyncRestore.GetNamespace()))
+	if err != nil {
+		logger.Error(err, "Error looking up pvcs (using index) matching volsyncrestore",
+			"name", volSyncRestore.GetName(), "namespace", volSyncRestore.GetNamespace(),
+			"index name", VolPopPVCToVolSyncRestoreIndex)
+		return []reconcile.Request{}
+	}
+
+	// Only enqueue a reconcile request if our PVC for volume populator is not already bound
+	return filterRequestsOnlyUnboundPVCs(pvcList)
+}
+
 func mapFuncStorageClassToVolumePopulatorPVC(ctx context.Context, k8sClient client.Client,
 	o client.Object) []reconcile.Request {
 	logger := ctrl.Log.WithName("mapFuncStorageClassToVolumePopulatorPVC")
@@ -613,6 +758,7 @@ func mapFuncStorageClassToVolumePopulatorPVC(ctx context.Context, k8sClient clie
 
 	// Find PVCs that have this storageClassName set in their spec (using index)
 	// Our custom index is only storing PVCs that have a dataSourceRef pointing to a ReplicationDestination
+	// or VolSyncRestore
 	pvcList := &corev1.PersistentVolumeClaimList{}
 	err := k8sClient.List(ctx, pvcList,
 		client.MatchingFields{
@@ -679,6 +825,27 @@ func (r VolumePopulatorReconciler) getReplicationDestinationFromDataSourceRef(ct
 	return replicationDestinationForVolPop, nil
 }
 
+func (r VolumePopulatorReconciler) getVolSyncRestoreFromDataSourceRef(ctx context.Context, logger logr.Logger,
+	pvc *corev1.PersistentVolumeClaim) (*volsyncv1alpha1.VolSyncRestore, error) {
+	// dataSourceRef should be pointing to a VolSyncRestore (see predicates)
+	vsr := &volsyncv1alpha1.VolSyncRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvc.Spec.DataSourceRef.Name,
+			Namespace: pvc.GetNamespace(),
+		},
+	}
+	err := r.Get(ctx, client.ObjectKeyFromObject(vsr), vsr)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			logger.Error(err, "Unable to populate volume - volsyncrestore not found",
+				"name", vsr.GetName(), "namespace", vsr.GetNamespace())
+		}
+		return nil, err
+	}
+
+	return vsr, nil
+}
+
 // Cleanup
 //   - if any snapshots have our vol pop label for our PVC, remove the label
 //   - if do-not-delete label is on the snapshot, remove our ownerref so we will not cause GC of the snap to happen
@@ -707,6 +874,10 @@ func (r *VolumePopulatorReconciler) cleanup(ctx context.Context, logger logr.Log
 		}
 	}
 
+	if err := r.cleanupPopulatorRestore(ctx, logger, pvc); err != nil {
+		return err
+	}
+
 	// If PVC' still exists, delete it
 	if pvcPrime != nil && pvcPrime.GetDeletionTimestamp().IsZero() {
 		logger.Info("Cleanup - deleting temp volume populator PVC", "volpop pvc name", pvcPrime.GetName())
@@ -856,14 +1027,28 @@ func getSnapshotInUseLabelKey(pvc *corev1.PersistentVolumeClaim) string {
 }
 
 func pvcHasReplicationDestinationDataSourceRef(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvcHasDataSourceRefKind(pvc, "ReplicationDestination")
+}
+
+func pvcHasVolSyncRestoreDataSourceRef(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvcHasDataSourceRefKind(pvc, "VolSyncRestore")
+}
+
+// pvcHasVolSyncDataSourceRef returns true if the pvc is populated by this
+// volume populator
+func pvcHasVolSyncDataSourceRef(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvcHasReplicationDestinationDataSourceRef(pvc) || pvcHasVolSyncRestoreDataSourceRef(pvc)
+}
+
+func pvcHasDataSourceRefKind(pvc *corev1.PersistentVolumeClaim, kind string) bool {
 	if pvc.Spec.DataSourceRef == nil || pvc.Spec.DataSourceRef.APIGroup == nil {
 		return false
 	}
 
 	// This volume populator responds to PVCs with dataSourceRef with group==volsync.backube
-	// and kind==ReplicationDestination
+	// and kind==ReplicationDestination or kind==VolSyncRestore
 	return *pvc.Spec.DataSourceRef.APIGroup == volsyncv1alpha1.GroupVersion.Group &&
-		pvc.Spec.DataSourceRef.Kind == "ReplicationDestination" &&
+		pvc.Spec.DataSourceRef.Kind == kind &&
 		pvc.Spec.DataSourceRef.Name != ""
 }
 
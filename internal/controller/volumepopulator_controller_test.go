@@ -105,6 +105,18 @@ var _ = Describe("VolumePopulator - helper funcs", func() {
 				Expect(pvcHasReplicationDestinationDataSourceRef(pvc)).To(BeTrue())
 			})
 		})
+		Context("When a PVC has a correct datasourceRef pointing to a VolSyncRestore", func() {
+			It("Should be populated by VolSync", func() {
+				pvc.Spec.DataSourceRef = &corev1.TypedObjectReference{
+					APIGroup: &volsyncv1alpha1.GroupVersion.Group,
+					Kind:     "VolSyncRestore",
+					Name:     "myrestore",
+				}
+				Expect(pvcHasReplicationDestinationDataSourceRef(pvc)).To(BeFalse())
+				Expect(pvcHasVolSyncRestoreDataSourceRef(pvc)).To(BeTrue())
+				Expect(pvcHasVolSyncDataSourceRef(pvc)).To(BeTrue())
+			})
+		})
 	})
 })
 
@@ -489,6 +501,17 @@ var _ = Describe("VolumePopulator - VolumePopulator CRD detection & ensuring CR
 				Expect(vpCR.SourceKind.Kind).To(Equal("ReplicationDestination"))
 			})
 
+			It("EnsureVolSyncVolumePopulatorCR should register VolSyncRestore", func() {
+				vsrCR := &volumepopulatorv1beta1.VolumePopulator{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: VolPopVolSyncRestoreCRName,
+					},
+				}
+				Expect(k8sDirectClient.Get(ctx, client.ObjectKeyFromObject(vsrCR), vsrCR)).To(Succeed())
+				Expect(vsrCR.SourceKind.Group).To(Equal("volsync.backube"))
+				Expect(vsrCR.SourceKind.Kind).To(Equal("VolSyncRestore"))
+			})
+
 			Context("When the Volsync VolumePopulator CR already exists", func() {
 				It("EnsureVolSyncVolumePopulatorCR should create it", func() {
 					// Run func again, should still succeed
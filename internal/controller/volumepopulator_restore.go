@@ -0,0 +1,406 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+const (
+	annotationRestoreAsOf string = "volsync.backube/restore-as-of"
+	annotationSnapshotID  string = "volsync.backube/snapshot-id"
+	// Number of failed attempts of the restore that have been reported on the
+	// PVC, set on the temporary ReplicationDestination
+	annotationReportedRestoreFailures string = "volsync.backube/reported-restore-failures"
+	// Manual trigger of the ReplicationDestination restoring into the PVC'
+	populatorRestoreTag string = "volsync-populator"
+)
+
+// populatorRestore is a backup, selected by the annotations of a PVC, that is
+// restored into its PVC' instead of using a snapshot image of the
+// ReplicationDestination. PVCs populated from a VolSyncRestore are always
+// restored, from the backup selected by the VolSyncRestore unless the
+// annotations select one.
+type populatorRestore struct {
+	restoreAsOf string
+	snapshotID  string
+}
+
+func (pr *populatorRestore) String() string {
+	switch {
+	case pr.snapshotID != "":
+		return "snapshot " + pr.snapshotID
+	case pr.restoreAsOf != "":
+		return "the backup as of " + pr.restoreAsOf
+	default:
+		return "the selected backup"
+	}
+}
+
+// asOf returns restoreAsOf as it is set in the mover specs
+func (pr *populatorRestore) asOf() *string {
+	if pr.restoreAsOf == "" {
+		return nil
+	}
+	return &pr.restoreAsOf
+}
+
+// selection returns the backup to restore: the one selected by the
+// annotations if there is one, or else the given one
+func (pr *populatorRestore) selection(restoreAsOf *string, snapshotID string) (*string, string) {
+	if pr.restoreAsOf == "" && pr.snapshotID == "" {
+		return restoreAsOf, snapshotID
+	}
+	return pr.asOf(), pr.snapshotID
+}
+
+// populatorRestoreFor returns the restore requested by the annotations or the
+// VolSyncRestore of the pvc, or nil if it is populated from a snapshot image
+func populatorRestoreFor(pvc *corev1.PersistentVolumeClaim) (*populatorRestore, error) {
+	annotations := pvc.GetAnnotations()
+	restoreAsOf, hasRestoreAsOf := annotations[annotationRestoreAsOf]
+	snapshotID, hasSnapshotID := annotations[annotationSnapshotID]
+	if !hasRestoreAsOf && !hasSnapshotID {
+		if pvcHasVolSyncRestoreDataSourceRef(pvc) {
+			return &populatorRestore{}, nil
+		}
+		return nil, nil
+	}
+
+	if hasRestoreAsOf && hasSnapshotID {
+		return nil, fmt.Errorf("only one of the %s and %s annotations may be set",
+			annotationRestoreAsOf, annotationSnapshotID)
+	}
+	if _, ok := annotations[annotationRetainedImage]; ok {
+		return nil, fmt.Errorf("the %s annotation cannot be combined with %s or %s",
+			annotationRetainedImage, annotationRestoreAsOf, annotationSnapshotID)
+	}
+	if hasRestoreAsOf {
+		if _, err := time.Parse(time.RFC3339, restoreAsOf); err != nil {
+			return nil, fmt.Errorf("the %s annotation must be an RFC3339 time: %w", annotationRestoreAsOf, err)
+		}
+	}
+	if hasSnapshotID && snapshotID == "" {
+		return nil, fmt.Errorf("the %s annotation cannot be empty", annotationSnapshotID)
+	}
+	return &populatorRestore{restoreAsOf: restoreAsOf, snapshotID: snapshotID}, nil
+}
+
+// reconcilePopulatorRestore ensures the temporary ReplicationDestination that
+// restores the backup into pvcPrime. It returns nil once the restore has
+// completed and the PV can be rebound to the pvc.
+func (r *VolumePopulatorReconciler) reconcilePopulatorRestore(ctx context.Context, logger logr.Logger,
+	pvc, pvcPrime *corev1.PersistentVolumeClaim, restore *populatorRestore) *vpResult {
+	rd := &volsyncv1alpha1.ReplicationDestination{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getPVCPrimeName(pvc),
+			Namespace: pvc.GetNamespace(),
+		},
+	}
+	logger = logger.WithValues("restore replicationdestination", rd.GetName())
+
+	err := r.Get(ctx, client.ObjectKeyFromObject(rd), rd)
+	if kerrors.IsNotFound(err) {
+		return r.createPopulatorRestore(ctx, logger, pvc, pvcPrime, restore, rd)
+	}
+	if err != nil {
+		return &vpResult{ctrl.Result{}, err}
+	}
+
+	if rd.Status == nil {
+		return &vpResult{ctrl.Result{}, nil}
+	}
+	exhausted := apimeta.FindStatusCondition(rd.Status.Conditions, volsyncv1alpha1.ConditionRetriesExhausted)
+	if exhausted != nil && exhausted.Status == metav1.ConditionTrue {
+		// The ReplicationDestination has given up and won't run the restore
+		// again, so there is nothing to wait for until it is recreated
+		logger.Info("Restore failed", "reason", exhausted.Reason)
+		return r.reportPopulatorRestoreFailure(ctx, pvc, rd,
+			fmt.Sprintf("Unable to restore %s, giving up: %s. Delete ReplicationDestination %s to retry.",
+				restore, exhausted.Message, rd.GetName()))
+	}
+	failed := rd.Status.LatestMoverStatus != nil &&
+		rd.Status.LatestMoverStatus.Result == volsyncv1alpha1.MoverResultFailed
+	if failed {
+		// The ReplicationDestination retries the restore
+		logger.Info("Waiting for the restore to be retried")
+		return r.reportPopulatorRestoreFailure(ctx, pvc, rd,
+			fmt.Sprintf("Unable to restore %s: %s", restore, rd.Status.LatestMoverStatus.Logs))
+	}
+	if rd.Status.LastManualSync != populatorRestoreTag {
+		logger.Info("Waiting for the restore to complete")
+		return &vpResult{ctrl.Result{}, nil}
+	}
+
+	logger.Info("Restore complete")
+	return nil
+}
+
+// reportPopulatorRestoreFailure records a warning event on the pvc for the
+// latest failed attempt of the restore, unless it has already been reported.
+// The ReplicationDestination keeps track of the attempts reported so that the
+// reconciles that follow don't repeat the event.
+func (r *VolumePopulatorReconciler) reportPopulatorRestoreFailure(ctx context.Context,
+	pvc *corev1.PersistentVolumeClaim, rd *volsyncv1alpha1.ReplicationDestination, message string) *vpResult {
+	attempts, unreported := unreportedRestoreFailures(rd)
+	if !unreported {
+		return &vpResult{ctrl.Result{}, nil}
+	}
+	if rd.Annotations == nil {
+		rd.Annotations = map[string]string{}
+	}
+	rd.Annotations[annotationReportedRestoreFailures] = strconv.Itoa(int(attempts))
+	if err := r.Update(ctx, rd); err != nil {
+		return &vpResult{ctrl.Result{}, err}
+	}
+	r.EventRecorder.Event(pvc, corev1.EventTypeWarning, volsyncv1alpha1.EvRVolPopPVCRestoreFailed, message)
+	return &vpResult{ctrl.Result{}, nil}
+}
+
+// unreportedRestoreFailures returns the number of failed attempts of the
+// restore, and whether the latest one hasn't been reported yet
+func unreportedRestoreFailures(rd *volsyncv1alpha1.ReplicationDestination) (int32, bool) {
+	attempts := int32(1)
+	if rd.Status != nil && rd.Status.Retry != nil && rd.Status.Retry.Attempts > 0 {
+		attempts = rd.Status.Retry.Attempts
+	}
+	reported, err := strconv.Atoi(rd.GetAnnotations()[annotationReportedRestoreFailures])
+	return attempts, err != nil || int32(reported) < attempts
+}
+
+// createPopulatorRestore creates the temporary ReplicationDestination, owned
+// by the pvc, from the mover of the ReplicationDestination or VolSyncRestore
+// in its dataSourceRef
+func (r *VolumePopulatorReconciler) createPopulatorRestore(ctx context.Context, logger logr.Logger,
+	pvc, pvcPrime *corev1.PersistentVolumeClaim, restore *populatorRestore,
+	rd *volsyncv1alpha1.ReplicationDestination) *vpResult {
+	spec, result := r.populatorRestoreSpec(ctx, logger, pvc, pvcPrime, restore)
+	if result != nil {
+		return result
+	}
+	rd.Spec = *spec
+
+	// Make the ReplicationDestination owned by pvc - will be cleaned up via gc if pvc is deleted
+	if err := ctrl.SetControllerReference(pvc, rd, r.Client.Scheme()); err != nil {
+		logger.Error(err, utils.ErrUnableToSetControllerRef)
+		return &vpResult{ctrl.Result{}, err}
+	}
+	utils.AddLabel(rd, labelPvcPrime, pvc.GetName())
+	utils.SetOwnedByVolSync(rd)
+
+	logger.Info("Creating ReplicationDestination to restore into the temp populator pvc")
+	if err := r.Create(ctx, rd); err != nil {
+		return &vpResult{ctrl.Result{}, err}
+	}
+	r.EventRecorder.Eventf(pvc, corev1.EventTypeNormal, volsyncv1alpha1.EvRVolPopPVCRestoreStarted,
+		"Restoring %s from %s %s", restore, pvc.Spec.DataSourceRef.Kind, pvc.Spec.DataSourceRef.Name)
+	return &vpResult{ctrl.Result{}, nil}
+}
+
+// populatorRestoreSpec returns the spec of the temporary ReplicationDestination
+// from the dataSourceRef of the pvc
+func (r *VolumePopulatorReconciler) populatorRestoreSpec(ctx context.Context, logger logr.Logger,
+	pvc, pvcPrime *corev1.PersistentVolumeClaim,
+	restore *populatorRestore) (*volsyncv1alpha1.ReplicationDestinationSpec, *vpResult) {
+	var spec *volsyncv1alpha1.ReplicationDestinationSpec
+	if pvcHasVolSyncRestoreDataSourceRef(pvc) {
+		vsr, err := r.getVolSyncRestoreFromDataSourceRef(ctx, logger, pvc)
+		if err != nil {
+			return nil, r.dataSourceRefMissing(pvc, err, volsyncv1alpha1.EvVolPopPVCVolSyncRestoreMissing)
+		}
+		spec, err = volSyncRestoreDestinationSpec(vsr, pvcPrime.GetName(), restore)
+		if err != nil {
+			return nil, r.populatorRestoreError(logger, pvc, err)
+		}
+	} else {
+		source, err := r.getReplicationDestinationFromDataSourceRef(ctx, logger, pvc)
+		if err != nil {
+			return nil, r.dataSourceRefMissing(pvc, err, volsyncv1alpha1.EvVolPopPVCReplicationDestMissing)
+		}
+		spec, err = populatorRestoreDestinationSpec(source, pvcPrime.GetName(), restore)
+		if err != nil {
+			return nil, r.populatorRestoreError(logger, pvc, err)
+		}
+	}
+	return spec, nil
+}
+
+func (r *VolumePopulatorReconciler) dataSourceRefMissing(pvc *corev1.PersistentVolumeClaim,
+	err error, reason string) *vpResult {
+	if !kerrors.IsNotFound(err) {
+		return &vpResult{ctrl.Result{}, err}
+	}
+	r.EventRecorder.Eventf(pvc, corev1.EventTypeWarning, reason, "Unable to populate volume: %s", err)
+	return &vpResult{ctrl.Result{}, nil}
+}
+
+func (r *VolumePopulatorReconciler) populatorRestoreError(logger logr.Logger,
+	pvc *corev1.PersistentVolumeClaim, err error) *vpResult {
+	logger.Error(err, "Unable to populate volume")
+	r.EventRecorder.Eventf(pvc, corev1.EventTypeWarning, volsyncv1alpha1.EvRVolPopPVCPopulatorError,
+		"Unable to populate volume: %s", err)
+	// Do not return error here - no use retrying
+	return &vpResult{ctrl.Result{}, nil}
+}
+
+// populatorRestoreDestinationSpec returns the spec of a ReplicationDestination
+// that restores the selected backup of the source ReplicationDestination's
+// repository into the named PVC
+func populatorRestoreDestinationSpec(source *volsyncv1alpha1.ReplicationDestination, pvcName string,
+	restore *populatorRestore) (*volsyncv1alpha1.ReplicationDestinationSpec, error) {
+	volumeOptions := volsyncv1alpha1.ReplicationDestinationVolumeOptions{
+		CopyMethod:     volsyncv1alpha1.CopyMethodDirect,
+		DestinationPVC: &pvcName,
+	}
+	restoreAsOf := restore.asOf()
+	spec := &volsyncv1alpha1.ReplicationDestinationSpec{
+		Trigger:     &volsyncv1alpha1.ReplicationDestinationTriggerSpec{Manual: populatorRestoreTag},
+		RetryPolicy: source.Spec.RetryPolicy.DeepCopy(),
+	}
+
+	switch {
+	case source.Spec.Kopia != nil:
+		kopia := source.Spec.Kopia.DeepCopy()
+		kopia.ReplicationDestinationVolumeOptions = volumeOptions
+		kopia.CleanupCachePVC = true
+		kopia.RestoreAsOf = restoreAsOf
+		kopia.SnapshotID = restore.snapshotID
+		kopia.SnapshotName = ""
+		kopia.Shallow = nil
+		kopia.Previous = nil
+		if kopia.SourceIdentity == nil && kopia.Username == nil && kopia.Hostname == nil {
+			// The default identity is derived from the name of the
+			// ReplicationDestination, so keep the one of the source
+			username, hostname := source.GetName(), source.GetNamespace()
+			if source.Status != nil && source.Status.Kopia != nil {
+				if u, h, ok := strings.Cut(source.Status.Kopia.RequestedIdentity, "@"); ok {
+					username, hostname = u, h
+				}
+			}
+			kopia.Username = &username
+			kopia.Hostname = &hostname
+		}
+		spec.Kopia = kopia
+	case source.Spec.Restic != nil:
+		restic := source.Spec.Restic.DeepCopy()
+		restic.ReplicationDestinationVolumeOptions = volumeOptions
+		restic.CleanupCachePVC = true
+		restic.RestoreAsOf = restoreAsOf
+		restic.SnapshotID = restore.snapshotID
+		restic.Previous = nil
+		spec.Restic = restic
+	default:
+		return nil, fmt.Errorf("ReplicationDestination %s must use the restic or kopia mover to restore %s",
+			source.GetName(), restore)
+	}
+	return spec, nil
+}
+
+// volSyncRestoreDestinationSpec returns the spec of a ReplicationDestination
+// that restores the repository of the VolSyncRestore into the named PVC. A
+// backup selected by the annotations of the PVC takes precedence over the one
+// selected by the VolSyncRestore.
+func volSyncRestoreDestinationSpec(vsr *volsyncv1alpha1.VolSyncRestore, pvcName string,
+	restore *populatorRestore) (*volsyncv1alpha1.ReplicationDestinationSpec, error) {
+	volumeOptions := volsyncv1alpha1.ReplicationDestinationVolumeOptions{
+		CopyMethod:     volsyncv1alpha1.CopyMethodDirect,
+		DestinationPVC: &pvcName,
+	}
+	spec := &volsyncv1alpha1.ReplicationDestinationSpec{
+		Trigger: &volsyncv1alpha1.ReplicationDestinationTriggerSpec{Manual: populatorRestoreTag},
+	}
+
+	switch {
+	case vsr.Spec.Kopia != nil:
+		source := vsr.Spec.Kopia.DeepCopy()
+		restoreAsOf, snapshotID := restore.selection(source.RestoreAsOf, source.SnapshotID)
+		spec.Kopia = &volsyncv1alpha1.ReplicationDestinationKopiaSpec{
+			ReplicationDestinationVolumeOptions: volumeOptions,
+			Repository:                          source.Repository,
+			CustomCA:                            source.CustomCA,
+			CleanupCachePVC:                     true,
+			RestoreAsOf:                         restoreAsOf,
+			SnapshotID:                          snapshotID,
+			SourceIdentity:                      source.SourceIdentity,
+			Username:                            source.Username,
+			Hostname:                            source.Hostname,
+			MoverConfig:                         source.MoverConfig,
+		}
+	case vsr.Spec.Restic != nil:
+		source := vsr.Spec.Restic.DeepCopy()
+		restoreAsOf, snapshotID := restore.selection(source.RestoreAsOf, source.SnapshotID)
+		spec.Restic = &volsyncv1alpha1.ReplicationDestinationResticSpec{
+			ReplicationDestinationVolumeOptions: volumeOptions,
+			Repository:                          source.Repository,
+			CustomCA:                            source.CustomCA,
+			CleanupCachePVC:                     true,
+			RestoreAsOf:                         restoreAsOf,
+			SnapshotID:                          snapshotID,
+			MoverConfig:                         source.MoverConfig,
+		}
+	case vsr.Spec.Rclone != nil:
+		if restore.restoreAsOf != "" || restore.snapshotID != "" {
+			return nil, fmt.Errorf("VolSyncRestore %s uses the rclone mover, which cannot restore %s",
+				vsr.GetName(), restore)
+		}
+		source := vsr.Spec.Rclone.DeepCopy()
+		spec.Rclone = &volsyncv1alpha1.ReplicationDestinationRcloneSpec{
+			ReplicationDestinationVolumeOptions: volumeOptions,
+			RcloneConfigSection:                 &source.RcloneConfigSection,
+			RcloneDestPath:                      &source.RcloneDestPath,
+			RcloneConfig:                        &source.RcloneConfig,
+			CustomCA:                            source.CustomCA,
+			MoverConfig:                         source.MoverConfig,
+		}
+	default:
+		return nil, fmt.Errorf("VolSyncRestore %s has no mover", vsr.GetName())
+	}
+	return spec, nil
+}
+
+// cleanupPopulatorRestore deletes the temporary ReplicationDestination once
+// the volume has been populated
+func (r *VolumePopulatorReconciler) cleanupPopulatorRestore(ctx context.Context, logger logr.Logger,
+	pvc *corev1.PersistentVolumeClaim) error {
+	rd := &volsyncv1alpha1.ReplicationDestination{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getPVCPrimeName(pvc),
+			Namespace: pvc.GetNamespace(),
+		},
+	}
+	err := r.Delete(ctx, rd, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err == nil {
+		logger.Info("Cleanup - deleted restore ReplicationDestination", "name", rd.GetName())
+	}
+	return client.IgnoreNotFound(err)
+}
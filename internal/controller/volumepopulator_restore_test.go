@@ -0,0 +1,245 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var _ = Describe("VolumePopulator - restores", func() {
+	var pvc *corev1.PersistentVolumeClaim
+
+	BeforeEach(func() {
+		pvc = &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "clone",
+				Namespace:   "ns",
+				Annotations: map[string]string{},
+			},
+		}
+	})
+
+	Describe("populatorRestoreFor", func() {
+		It("should return nil without restore annotations", func() {
+			restore, err := populatorRestoreFor(pvc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restore).To(BeNil())
+		})
+
+		It("should always restore from a VolSyncRestore", func() {
+			pvc.Spec.DataSourceRef = &corev1.TypedObjectReference{
+				APIGroup: &volsyncv1alpha1.GroupVersion.Group,
+				Kind:     "VolSyncRestore",
+				Name:     "db-restore",
+			}
+			restore, err := populatorRestoreFor(pvc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restore).To(Equal(&populatorRestore{}))
+		})
+
+		It("should select a backup by time or id", func() {
+			pvc.Annotations[annotationRestoreAsOf] = "2026-10-15T00:00:00Z"
+			restore, err := populatorRestoreFor(pvc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restore).To(Equal(&populatorRestore{restoreAsOf: "2026-10-15T00:00:00Z"}))
+
+			delete(pvc.Annotations, annotationRestoreAsOf)
+			pvc.Annotations[annotationSnapshotID] = "k1234"
+			restore, err = populatorRestoreFor(pvc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restore).To(Equal(&populatorRestore{snapshotID: "k1234"}))
+		})
+
+		It("should reject invalid annotations", func() {
+			pvc.Annotations[annotationRestoreAsOf] = "yesterday"
+			_, err := populatorRestoreFor(pvc)
+			Expect(err).To(MatchError(ContainSubstring("RFC3339")))
+
+			pvc.Annotations[annotationRestoreAsOf] = "2026-10-15T00:00:00Z"
+			pvc.Annotations[annotationSnapshotID] = "k1234"
+			_, err = populatorRestoreFor(pvc)
+			Expect(err).To(MatchError(ContainSubstring("only one")))
+
+			delete(pvc.Annotations, annotationSnapshotID)
+			pvc.Annotations[annotationRetainedImage] = "snap"
+			_, err = populatorRestoreFor(pvc)
+			Expect(err).To(MatchError(ContainSubstring("cannot be combined")))
+		})
+	})
+
+	Describe("populatorRestoreDestinationSpec", func() {
+		var source *volsyncv1alpha1.ReplicationDestination
+
+		BeforeEach(func() {
+			source = &volsyncv1alpha1.ReplicationDestination{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "db-backup",
+					Namespace: "ns",
+				},
+				Spec: volsyncv1alpha1.ReplicationDestinationSpec{
+					Kopia: &volsyncv1alpha1.ReplicationDestinationKopiaSpec{
+						ReplicationDestinationVolumeOptions: volsyncv1alpha1.ReplicationDestinationVolumeOptions{
+							CopyMethod: volsyncv1alpha1.CopyMethodSnapshot,
+						},
+						Repository: "kopia-secret",
+						Previous:   ptr.To(int32(2)),
+					},
+				},
+				Status: &volsyncv1alpha1.ReplicationDestinationStatus{
+					Kopia: &volsyncv1alpha1.ReplicationDestinationKopiaStatus{
+						RequestedIdentity: "db@prod",
+					},
+				},
+			}
+		})
+
+		It("should restore the selected kopia snapshot into the pvc", func() {
+			spec, err := populatorRestoreDestinationSpec(source, "vs-prime-1234", &populatorRestore{snapshotID: "k1234"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Trigger.Manual).To(Equal(populatorRestoreTag))
+			Expect(spec.Kopia.Repository).To(Equal("kopia-secret"))
+			Expect(spec.Kopia.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodDirect))
+			Expect(spec.Kopia.DestinationPVC).To(HaveValue(Equal("vs-prime-1234")))
+			Expect(spec.Kopia.SnapshotID).To(Equal("k1234"))
+			Expect(spec.Kopia.Previous).To(BeNil())
+			// The identity of the source is kept
+			Expect(spec.Kopia.Username).To(HaveValue(Equal("db")))
+			Expect(spec.Kopia.Hostname).To(HaveValue(Equal("prod")))
+			// The source isn't modified
+			Expect(source.Spec.Kopia.Previous).NotTo(BeNil())
+		})
+
+		It("should restore a restic backup as of a time", func() {
+			source.Spec.Kopia = nil
+			source.Spec.Restic = &volsyncv1alpha1.ReplicationDestinationResticSpec{
+				Repository: "restic-secret",
+			}
+			spec, err := populatorRestoreDestinationSpec(source, "vs-prime-1234",
+				&populatorRestore{restoreAsOf: "2026-10-15T00:00:00Z"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Restic.RestoreAsOf).To(HaveValue(Equal("2026-10-15T00:00:00Z")))
+			Expect(spec.Restic.CleanupCachePVC).To(BeTrue())
+		})
+
+		It("should require a mover with backups", func() {
+			source.Spec.Kopia = nil
+			source.Spec.RsyncTLS = &volsyncv1alpha1.ReplicationDestinationRsyncTLSSpec{}
+			_, err := populatorRestoreDestinationSpec(source, "vs-prime-1234", &populatorRestore{snapshotID: "k1234"})
+			Expect(err).To(MatchError(ContainSubstring("restic or kopia")))
+		})
+	})
+
+	Describe("volSyncRestoreDestinationSpec", func() {
+		var vsr *volsyncv1alpha1.VolSyncRestore
+
+		BeforeEach(func() {
+			vsr = &volsyncv1alpha1.VolSyncRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "db-restore",
+					Namespace: "ns",
+				},
+				Spec: volsyncv1alpha1.VolSyncRestoreSpec{
+					Kopia: &volsyncv1alpha1.VolSyncRestoreKopiaSpec{
+						Repository:  "kopia-secret",
+						Username:    ptr.To("db"),
+						Hostname:    ptr.To("prod"),
+						RestoreAsOf: ptr.To("2026-10-01T00:00:00Z"),
+					},
+				},
+			}
+		})
+
+		It("should restore the kopia snapshot selected by the VolSyncRestore", func() {
+			spec, err := volSyncRestoreDestinationSpec(vsr, "vs-prime-1234", &populatorRestore{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Trigger.Manual).To(Equal(populatorRestoreTag))
+			Expect(spec.Kopia.Repository).To(Equal("kopia-secret"))
+			Expect(spec.Kopia.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodDirect))
+			Expect(spec.Kopia.DestinationPVC).To(HaveValue(Equal("vs-prime-1234")))
+			Expect(spec.Kopia.CleanupCachePVC).To(BeTrue())
+			Expect(spec.Kopia.Username).To(HaveValue(Equal("db")))
+			Expect(spec.Kopia.Hostname).To(HaveValue(Equal("prod")))
+			Expect(spec.Kopia.RestoreAsOf).To(HaveValue(Equal("2026-10-01T00:00:00Z")))
+		})
+
+		It("should prefer the backup selected by the pvc annotations", func() {
+			spec, err := volSyncRestoreDestinationSpec(vsr, "vs-prime-1234", &populatorRestore{snapshotID: "k1234"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Kopia.RestoreAsOf).To(BeNil())
+			Expect(spec.Kopia.SnapshotID).To(Equal("k1234"))
+		})
+
+		It("should restore a restic repository", func() {
+			vsr.Spec.Kopia = nil
+			vsr.Spec.Restic = &volsyncv1alpha1.VolSyncRestoreResticSpec{
+				Repository: "restic-secret",
+				SnapshotID: "abcd1234",
+			}
+			spec, err := volSyncRestoreDestinationSpec(vsr, "vs-prime-1234", &populatorRestore{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Restic.Repository).To(Equal("restic-secret"))
+			Expect(spec.Restic.SnapshotID).To(Equal("abcd1234"))
+			Expect(spec.Restic.DestinationPVC).To(HaveValue(Equal("vs-prime-1234")))
+		})
+
+		It("should sync an rclone remote path, without selecting backups", func() {
+			vsr.Spec.Kopia = nil
+			vsr.Spec.Rclone = &volsyncv1alpha1.VolSyncRestoreRcloneSpec{
+				RcloneConfigSection: "s3",
+				RcloneDestPath:      "bucket/db",
+				RcloneConfig:        "rclone-secret",
+			}
+			spec, err := volSyncRestoreDestinationSpec(vsr, "vs-prime-1234", &populatorRestore{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Rclone.RcloneDestPath).To(HaveValue(Equal("bucket/db")))
+			Expect(spec.Rclone.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodDirect))
+
+			_, err = volSyncRestoreDestinationSpec(vsr, "vs-prime-1234",
+				&populatorRestore{restoreAsOf: "2026-10-15T00:00:00Z"})
+			Expect(err).To(MatchError(ContainSubstring("rclone")))
+		})
+	})
+
+	Describe("unreportedRestoreFailures", func() {
+		It("should report each failed attempt once", func() {
+			rd := &volsyncv1alpha1.ReplicationDestination{
+				Status: &volsyncv1alpha1.ReplicationDestinationStatus{
+					Retry: &volsyncv1alpha1.RetryStatus{Attempts: 1},
+				},
+			}
+			attempts, unreported := unreportedRestoreFailures(rd)
+			Expect(unreported).To(BeTrue())
+			Expect(attempts).To(Equal(int32(1)))
+
+			rd.Annotations = map[string]string{annotationReportedRestoreFailures: "1"}
+			_, unreported = unreportedRestoreFailures(rd)
+			Expect(unreported).To(BeFalse())
+
+			rd.Status.Retry.Attempts = 2
+			attempts, unreported = unreportedRestoreFailures(rd)
+			Expect(unreported).To(BeTrue())
+			Expect(attempts).To(Equal(int32(2)))
+		})
+	})
+})
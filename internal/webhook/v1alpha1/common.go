@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// Package v1alpha1 contains the validating and defaulting admission webhooks
+// for the volsync.backube/v1alpha1 API.
+//
+// The webhooks only perform checks that can be made from the object itself.
+// References to other objects (repository Secrets, CA ConfigMaps, moverVolume
+// PVCs, ...) are not looked up here since they may legitimately be created
+// after the VolSync CR (e.g. when applying a directory of manifests). Those
+// are still validated by the controllers at reconcile time.
+package v1alpha1
+
+import (
+	cron "github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+// validateSchedule makes sure a trigger schedule can be parsed the same way
+// the state machine will parse it when scheduling syncs.
+func validateSchedule(schedule *string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if schedule == nil {
+		return allErrs
+	}
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	if _, err := parser.Parse(*schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, *schedule, err.Error()))
+	}
+	return allErrs
+}
+
+// validateMoverCount ensures exactly one replication method has been
+// specified. moverPaths contains the spec path of each mover that is set.
+func validateMoverCount(moverPaths []*field.Path, specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case len(moverPaths) == 0:
+		allErrs = append(allErrs, field.Required(specPath, mover.ErrNoMoverFound.Error()))
+	case len(moverPaths) > 1:
+		for _, p := range moverPaths[1:] {
+			allErrs = append(allErrs, field.Forbidden(p, mover.ErrMultipleMoversFound.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateMoverConfig validates the fields common to all movers
+func validateMoverConfig(moverConfig *volsyncv1alpha1.MoverConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if err := utils.ValidateMoverVolumeSpecs(moverConfig.MoverVolumes); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("moverVolumes"), moverConfig.MoverVolumes, err.Error()))
+	}
+	if moverConfig.MoverServiceAccount != nil && *moverConfig.MoverServiceAccount == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("moverServiceAccount"), "",
+			"moverServiceAccount cannot be empty if specified"))
+	}
+	if err := utils.ValidateTransferPolicy(moverConfig.TransferPolicy); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("transferPolicy"), moverConfig.TransferPolicy,
+			err.Error()))
+	}
+	return allErrs
+}
+
+// validateRetryPolicy makes sure the backoffs of a retryPolicy are positive
+// and that the initial backoff doesn't exceed the maximum
+func validateRetryPolicy(policy *volsyncv1alpha1.RetryPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy == nil {
+		return allErrs
+	}
+	if policy.InitialBackoff != nil && policy.InitialBackoff.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialBackoff"),
+			policy.InitialBackoff.Duration.String(), "must be greater than zero"))
+	}
+	if policy.MaxBackoff != nil && policy.MaxBackoff.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackoff"),
+			policy.MaxBackoff.Duration.String(), "must be greater than zero"))
+	}
+	if policy.InitialBackoff != nil && policy.MaxBackoff != nil &&
+		policy.InitialBackoff.Duration > policy.MaxBackoff.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialBackoff"),
+			policy.InitialBackoff.Duration.String(), "cannot be greater than maxBackoff"))
+	}
+	return allErrs
+}
+
+// validateCustomCA validates a customCA spec without looking up the
+// referenced Secret or ConfigMap
+func validateCustomCA(customCA volsyncv1alpha1.CustomCASpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if err := utils.ValidateCustomCASpec(customCA); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, customCA, err.Error()))
+	}
+	return allErrs
+}
+
+// defaultCopyMethod replaces the deprecated "None" copyMethod with its
+// replacement, "Direct"
+func defaultCopyMethod(copyMethod *volsyncv1alpha1.CopyMethodType) {
+	if *copyMethod == volsyncv1alpha1.CopyMethodNone {
+		*copyMethod = volsyncv1alpha1.CopyMethodDirect
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+var kopiamaintenancelog = logf.Log.WithName("kopiamaintenance-webhook")
+
+// SetupKopiaMaintenanceWebhookWithManager registers the webhook for
+// KopiaMaintenance with the manager.
+func SetupKopiaMaintenanceWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&volsyncv1alpha1.KopiaMaintenance{}).
+		WithValidator(&KopiaMaintenanceCustomValidator{}).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-volsync-backube-v1alpha1-kopiamaintenance,mutating=false,failurePolicy=fail,sideEffects=None,groups=volsync.backube,resources=kopiamaintenances,verbs=create;update,versions=v1alpha1,name=vkopiamaintenance-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// KopiaMaintenanceCustomValidator validates KopiaMaintenances when they are
+// created or updated.
+type KopiaMaintenanceCustomValidator struct{}
+
+var _ webhook.CustomValidator = &KopiaMaintenanceCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *KopiaMaintenanceCustomValidator) ValidateCreate(_ context.Context,
+	obj runtime.Object) (admission.Warnings, error) {
+	km, ok := obj.(*volsyncv1alpha1.KopiaMaintenance)
+	if !ok {
+		return nil, fmt.Errorf("expected a KopiaMaintenance object but got %T", obj)
+	}
+	kopiamaintenancelog.V(1).Info("validating create", "name", km.GetName(), "namespace", km.GetNamespace())
+	return nil, validateKopiaMaintenance(km)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *KopiaMaintenanceCustomValidator) ValidateUpdate(_ context.Context,
+	_, newObj runtime.Object) (admission.Warnings, error) {
+	km, ok := newObj.(*volsyncv1alpha1.KopiaMaintenance)
+	if !ok {
+		return nil, fmt.Errorf("expected a KopiaMaintenance object for the newObj but got %T", newObj)
+	}
+	kopiamaintenancelog.V(1).Info("validating update", "name", km.GetName(), "namespace", km.GetNamespace())
+	if !km.GetDeletionTimestamp().IsZero() {
+		// Don't block finalizer removal on an object that is being deleted
+		return nil, nil
+	}
+	return nil, validateKopiaMaintenance(km)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *KopiaMaintenanceCustomValidator) ValidateDelete(_ context.Context,
+	_ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateKopiaMaintenance(km *volsyncv1alpha1.KopiaMaintenance) error {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+
+	if err := km.Validate(); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath, field.OmitValueType{}, err.Error()))
+	}
+	if err := utils.ValidateMoverVolumeSpecs(km.Spec.MoverVolumes); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("moverVolumes"), km.Spec.MoverVolumes, err.Error()))
+	}
+	if km.Spec.Repository.CustomCA != nil {
+		allErrs = append(allErrs, validateCustomCA(volsyncv1alpha1.CustomCASpec(*km.Spec.Repository.CustomCA),
+			specPath.Child("repository", "customCA"))...)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(volsyncv1alpha1.GroupVersion.WithKind("KopiaMaintenance").GroupKind(),
+		km.GetName(), allErrs)
+}
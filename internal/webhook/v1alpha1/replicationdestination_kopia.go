@@ -0,0 +1,50 @@
+//go:build !disable_kopia
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover/kopia"
+)
+
+// validateKopiaDestination runs the kopia mover's own static checks on a
+// ReplicationDestination
+func validateKopiaDestination(rd *volsyncv1alpha1.ReplicationDestination) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if rd.Spec.Kopia == nil {
+		return allErrs
+	}
+
+	kopiaPath := field.NewPath("spec", "kopia")
+	if err := kopia.ValidateDestinationIdentity(rd); err != nil {
+		allErrs = append(allErrs, field.Invalid(kopiaPath, field.OmitValueType{}, err.Error()))
+	}
+	if err := kopia.ValidateRestorePaths(rd.Spec.Kopia.IncludePaths); err != nil {
+		allErrs = append(allErrs, field.Invalid(kopiaPath.Child("includePaths"), rd.Spec.Kopia.IncludePaths,
+			err.Error()))
+	}
+	if err := kopia.ValidateRestorePaths(rd.Spec.Kopia.ExcludePaths); err != nil {
+		allErrs = append(allErrs, field.Invalid(kopiaPath.Child("excludePaths"), rd.Spec.Kopia.ExcludePaths,
+			err.Error()))
+	}
+	return allErrs
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+var replicationdestinationlog = logf.Log.WithName("replicationdestination-webhook")
+
+// SetupReplicationDestinationWebhookWithManager registers the webhooks for
+// ReplicationDestination with the manager.
+func SetupReplicationDestinationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&volsyncv1alpha1.ReplicationDestination{}).
+		WithValidator(&ReplicationDestinationCustomValidator{}).
+		WithDefaulter(&ReplicationDestinationCustomDefaulter{}).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/mutate-volsync-backube-v1alpha1-replicationdestination,mutating=true,failurePolicy=fail,sideEffects=None,groups=volsync.backube,resources=replicationdestinations,verbs=create;update,versions=v1alpha1,name=mreplicationdestination-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ReplicationDestinationCustomDefaulter sets defaults on
+// ReplicationDestinations when they are created or updated.
+type ReplicationDestinationCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ReplicationDestinationCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *ReplicationDestinationCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	rd, ok := obj.(*volsyncv1alpha1.ReplicationDestination)
+	if !ok {
+		return fmt.Errorf("expected a ReplicationDestination object but got %T", obj)
+	}
+	replicationdestinationlog.V(1).Info("defaulting", "name", rd.GetName(), "namespace", rd.GetNamespace())
+
+	for _, volOpts := range utils.DestinationVolumeOptions(&rd.Spec) {
+		defaultCopyMethod(&volOpts.CopyMethod)
+	}
+	return nil
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-volsync-backube-v1alpha1-replicationdestination,mutating=false,failurePolicy=fail,sideEffects=None,groups=volsync.backube,resources=replicationdestinations,verbs=create;update,versions=v1alpha1,name=vreplicationdestination-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ReplicationDestinationCustomValidator validates ReplicationDestinations
+// when they are created or updated.
+type ReplicationDestinationCustomValidator struct{}
+
+var _ webhook.CustomValidator = &ReplicationDestinationCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *ReplicationDestinationCustomValidator) ValidateCreate(_ context.Context,
+	obj runtime.Object) (admission.Warnings, error) {
+	rd, ok := obj.(*volsyncv1alpha1.ReplicationDestination)
+	if !ok {
+		return nil, fmt.Errorf("expected a ReplicationDestination object but got %T", obj)
+	}
+	replicationdestinationlog.V(1).Info("validating create", "name", rd.GetName(), "namespace", rd.GetNamespace())
+	return nil, validateReplicationDestination(rd)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *ReplicationDestinationCustomValidator) ValidateUpdate(_ context.Context,
+	_, newObj runtime.Object) (admission.Warnings, error) {
+	rd, ok := newObj.(*volsyncv1alpha1.ReplicationDestination)
+	if !ok {
+		return nil, fmt.Errorf("expected a ReplicationDestination object for the newObj but got %T", newObj)
+	}
+	replicationdestinationlog.V(1).Info("validating update", "name", rd.GetName(), "namespace", rd.GetNamespace())
+	if !rd.GetDeletionTimestamp().IsZero() {
+		// Don't block finalizer removal on an object that is being deleted
+		return nil, nil
+	}
+	return nil, validateReplicationDestination(rd)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *ReplicationDestinationCustomValidator) ValidateDelete(_ context.Context,
+	_ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateReplicationDestination(rd *volsyncv1alpha1.ReplicationDestination) error {
+	allErrs := validateReplicationDestinationSpec(&rd.Spec, field.NewPath("spec"))
+
+	allErrs = append(allErrs, validateKopiaDestination(rd)...)
+	allErrs = append(allErrs, validateResticDestination(rd)...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(volsyncv1alpha1.GroupVersion.WithKind("ReplicationDestination").GroupKind(),
+		rd.GetName(), allErrs)
+}
+
+func validateReplicationDestinationSpec(spec *volsyncv1alpha1.ReplicationDestinationSpec,
+	specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Trigger != nil {
+		allErrs = append(allErrs, validateSchedule(spec.Trigger.Schedule, specPath.Child("trigger", "schedule"))...)
+	}
+
+	moverPaths := []*field.Path{}
+	if spec.Rsync != nil {
+		moverPaths = append(moverPaths, specPath.Child("rsync"))
+	}
+	if spec.RsyncTLS != nil {
+		p := specPath.Child("rsyncTLS")
+		moverPaths = append(moverPaths, p)
+		allErrs = append(allErrs, validateMoverConfig(&spec.RsyncTLS.MoverConfig, p)...)
+	}
+	if spec.Rclone != nil {
+		p := specPath.Child("rclone")
+		moverPaths = append(moverPaths, p)
+		allErrs = append(allErrs, validateMoverConfig(&spec.Rclone.MoverConfig, p)...)
+		allErrs = append(allErrs, validateCustomCA(spec.Rclone.CustomCA, p.Child("customCA"))...)
+	}
+	if spec.Restic != nil {
+		p := specPath.Child("restic")
+		moverPaths = append(moverPaths, p)
+		allErrs = append(allErrs, validateMoverConfig(&spec.Restic.MoverConfig, p)...)
+		allErrs = append(allErrs, validateCustomCA(volsyncv1alpha1.CustomCASpec(spec.Restic.CustomCA),
+			p.Child("customCA"))...)
+		allErrs = append(allErrs, validateResticSnapshotID(spec.Restic, p)...)
+	}
+	if spec.Kopia != nil {
+		p := specPath.Child("kopia")
+		moverPaths = append(moverPaths, p)
+		allErrs = append(allErrs, validateMoverConfig(&spec.Kopia.MoverConfig, p)...)
+		allErrs = append(allErrs, validateCustomCA(volsyncv1alpha1.CustomCASpec(spec.Kopia.CustomCA),
+			p.Child("customCA"))...)
+		allErrs = append(allErrs, validateKopiaSnapshotName(spec.Kopia, p)...)
+		allErrs = append(allErrs, validateKopiaSnapshotID(spec.Kopia, p)...)
+	}
+	if spec.External != nil {
+		moverPaths = append(moverPaths, specPath.Child("external"))
+	}
+	allErrs = append(allErrs, validateMoverCount(moverPaths, specPath)...)
+	allErrs = append(allErrs, validateRetryPolicy(spec.RetryPolicy, specPath.Child("retryPolicy"))...)
+
+	// The volume options are in the same order as the mover paths
+	for i, opts := range utils.DestinationVolumeOptions(spec) {
+		if opts.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+			allErrs = append(allErrs, field.Forbidden(moverPaths[i].Child("copyMethod"),
+				"the GroupSnapshot copyMethod is only supported by ReplicationSources"))
+		}
+		if opts.SnapshotRetention != nil && opts.CopyMethod != volsyncv1alpha1.CopyMethodSnapshot {
+			allErrs = append(allErrs, field.Forbidden(moverPaths[i].Child("snapshotRetention"),
+				"snapshotRetention requires the Snapshot copyMethod"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateKopiaSnapshotName ensures a snapshot selected by KopiaSnapshot name
+// isn't combined with the other ways of selecting a snapshot
+func validateKopiaSnapshotName(kopia *volsyncv1alpha1.ReplicationDestinationKopiaSpec,
+	kopiaPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if kopia.SnapshotName == "" {
+		return allErrs
+	}
+
+	p := kopiaPath.Child("snapshotName")
+	if kopia.RestoreAsOf != nil {
+		allErrs = append(allErrs, field.Forbidden(p, "snapshotName cannot be combined with restoreAsOf"))
+	}
+	if kopia.Shallow != nil {
+		allErrs = append(allErrs, field.Forbidden(p, "snapshotName cannot be combined with shallow"))
+	}
+	if kopia.Previous != nil {
+		allErrs = append(allErrs, field.Forbidden(p, "snapshotName cannot be combined with previous"))
+	}
+	return allErrs
+}
+
+// validateKopiaSnapshotID ensures a snapshot selected by id isn't combined
+// with the other ways of selecting a snapshot
+func validateKopiaSnapshotID(kopia *volsyncv1alpha1.ReplicationDestinationKopiaSpec,
+	kopiaPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if kopia.SnapshotID == "" {
+		return allErrs
+	}
+
+	p := kopiaPath.Child("snapshotID")
+	if kopia.SnapshotName != "" {
+		allErrs = append(allErrs, field.Forbidden(p, "snapshotID cannot be combined with snapshotName"))
+	}
+	if kopia.RestoreAsOf != nil {
+		allErrs = append(allErrs, field.Forbidden(p, "snapshotID cannot be combined with restoreAsOf"))
+	}
+	if kopia.Shallow != nil {
+		allErrs = append(allErrs, field.Forbidden(p, "snapshotID cannot be combined with shallow"))
+	}
+	if kopia.Previous != nil {
+		allErrs = append(allErrs, field.Forbidden(p, "snapshotID cannot be combined with previous"))
+	}
+	return allErrs
+}
+
+// validateResticSnapshotID ensures a snapshot selected by id isn't combined
+// with the other ways of selecting a snapshot
+func validateResticSnapshotID(restic *volsyncv1alpha1.ReplicationDestinationResticSpec,
+	resticPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if restic.SnapshotID == "" {
+		return allErrs
+	}
+
+	p := resticPath.Child("snapshotID")
+	if restic.RestoreAsOf != nil {
+		allErrs = append(allErrs, field.Forbidden(p, "snapshotID cannot be combined with restoreAsOf"))
+	}
+	if restic.Previous != nil {
+		allErrs = append(allErrs, field.Forbidden(p, "snapshotID cannot be combined with previous"))
+	}
+	return allErrs
+}
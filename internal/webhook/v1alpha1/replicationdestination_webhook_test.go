@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var _ = Describe("ReplicationDestination Webhook", func() {
+	var rd *volsyncv1alpha1.ReplicationDestination
+	var validator *ReplicationDestinationCustomValidator
+	var defaulter *ReplicationDestinationCustomDefaulter
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		validator = &ReplicationDestinationCustomValidator{}
+		defaulter = &ReplicationDestinationCustomDefaulter{}
+		rd = &volsyncv1alpha1.ReplicationDestination{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "rd",
+				Namespace: "test-ns",
+			},
+			Spec: volsyncv1alpha1.ReplicationDestinationSpec{
+				Trigger: &volsyncv1alpha1.ReplicationDestinationTriggerSpec{
+					Manual: "restore-once",
+				},
+				Kopia: &volsyncv1alpha1.ReplicationDestinationKopiaSpec{
+					Repository: "kopia-secret",
+				},
+			},
+		}
+	})
+
+	When("the spec is valid", func() {
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("multiple movers are specified", func() {
+		BeforeEach(func() {
+			rd.Spec.Rsync = &volsyncv1alpha1.ReplicationDestinationRsyncSpec{}
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("only one replication method can be supplied")))
+		})
+	})
+
+	When("only the external provider is specified", func() {
+		BeforeEach(func() {
+			rd.Spec.Kopia = nil
+			rd.Spec.External = &volsyncv1alpha1.ReplicationDestinationExternalSpec{}
+		})
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("a kopia username is provided without a hostname", func() {
+		BeforeEach(func() {
+			rd.Spec.Kopia.Username = ptr.To("user")
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("missing 'hostname'")))
+		})
+	})
+
+	When("both kopia username and hostname are provided", func() {
+		BeforeEach(func() {
+			rd.Spec.Kopia.Username = ptr.To("user")
+			rd.Spec.Kopia.Hostname = ptr.To("host")
+		})
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("partial restore paths are specified", func() {
+		It("should admit paths relative to the snapshot root", func() {
+			rd.Spec.Kopia.IncludePaths = []string{"app/config", "/db/dump.sql"}
+			rd.Spec.Kopia.ExcludePaths = []string{"app/config/cache"}
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should reject paths that escape the snapshot", func() {
+			rd.Spec.Kopia.IncludePaths = []string{"../etc"}
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("spec.kopia.includePaths")))
+		})
+		It("should reject excluding the snapshot root", func() {
+			rd.Spec.Kopia.ExcludePaths = []string{"/"}
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("spec.kopia.excludePaths")))
+		})
+	})
+
+	When("restic restoreIncludes are specified", func() {
+		BeforeEach(func() {
+			rd.Spec.Kopia = nil
+			rd.Spec.Restic = &volsyncv1alpha1.ReplicationDestinationResticSpec{
+				Repository:      "restic-secret",
+				RestoreIncludes: []string{"/app/config", "*.sql"},
+			}
+		})
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should reject multi-line patterns", func() {
+			rd.Spec.Restic.RestoreIncludes = []string{"/app/config\n/etc"}
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.restoreIncludes")))
+		})
+	})
+
+	When("a KopiaSnapshot is selected by name", func() {
+		BeforeEach(func() {
+			rd.Spec.Kopia.SnapshotName = "km-k1234"
+		})
+		It("should be admitted on its own", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should be rejected when combined with previous", func() {
+			rd.Spec.Kopia.Previous = ptr.To(int32(1))
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("spec.kopia.snapshotName")))
+		})
+		It("should be rejected when combined with restoreAsOf", func() {
+			rd.Spec.Kopia.RestoreAsOf = ptr.To("2026-01-01T00:00:00Z")
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("restoreAsOf")))
+		})
+	})
+
+	When("a snapshot is selected by id", func() {
+		BeforeEach(func() {
+			rd.Spec.Kopia.SnapshotID = "k1234"
+		})
+		It("should be admitted on its own", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should be rejected when combined with snapshotName", func() {
+			rd.Spec.Kopia.SnapshotName = "km-k1234"
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("spec.kopia.snapshotID")))
+		})
+		It("should be rejected when combined with restoreAsOf for restic", func() {
+			rd.Spec.Kopia = nil
+			rd.Spec.Restic = &volsyncv1alpha1.ReplicationDestinationResticSpec{
+				Repository:  "restic-secret",
+				SnapshotID:  "4f2a9c1e",
+				RestoreAsOf: ptr.To("2026-01-01T00:00:00Z"),
+			}
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.snapshotID")))
+		})
+	})
+
+	When("a customCA key is set without a secret or configmap", func() {
+		BeforeEach(func() {
+			rd.Spec.Kopia.CustomCA = volsyncv1alpha1.ReplicationDestinationKopiaCA{Key: "ca.crt"}
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("spec.kopia.customCA")))
+		})
+	})
+
+	When("two moverVolumes use the same mount path", func() {
+		BeforeEach(func() {
+			rd.Spec.Kopia.MoverVolumes = []volsyncv1alpha1.MoverVolume{
+				{
+					MountPath: "data",
+					VolumeSource: volsyncv1alpha1.MoverVolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: "sec"},
+					},
+				},
+				{
+					MountPath: "data",
+					VolumeSource: volsyncv1alpha1.MoverVolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+					},
+				},
+			}
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("must be unique")))
+		})
+	})
+
+	When("the GroupSnapshot copyMethod is used", func() {
+		BeforeEach(func() {
+			rd.Spec.Kopia.CopyMethod = volsyncv1alpha1.CopyMethodGroupSnapshot
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("spec.kopia.copyMethod")))
+		})
+	})
+
+	When("snapshotRetention is used", func() {
+		BeforeEach(func() {
+			rd.Spec.Kopia.SnapshotRetention = &volsyncv1alpha1.SnapshotRetentionPolicy{
+				Last: ptr.To(int32(3)),
+			}
+		})
+		It("should be admitted with the Snapshot copyMethod", func() {
+			rd.Spec.Kopia.CopyMethod = volsyncv1alpha1.CopyMethodSnapshot
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should be rejected with the Direct copyMethod", func() {
+			rd.Spec.Kopia.CopyMethod = volsyncv1alpha1.CopyMethodDirect
+			_, err := validator.ValidateCreate(ctx, rd)
+			Expect(err).To(MatchError(ContainSubstring("spec.kopia.snapshotRetention")))
+		})
+	})
+
+	It("should default the deprecated None copyMethod to Direct", func() {
+		rd.Spec.Kopia.CopyMethod = volsyncv1alpha1.CopyMethodNone
+		Expect(defaulter.Default(ctx, rd)).To(Succeed())
+		Expect(rd.Spec.Kopia.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodDirect))
+	})
+})
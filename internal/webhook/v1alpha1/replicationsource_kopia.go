@@ -0,0 +1,42 @@
+//go:build !disable_kopia
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover/kopia"
+)
+
+// validateKopiaSource runs the kopia mover's own static checks on a
+// ReplicationSource
+func validateKopiaSource(rs *volsyncv1alpha1.ReplicationSource) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if rs.Spec.Kopia == nil {
+		return allErrs
+	}
+
+	kopiaPath := field.NewPath("spec", "kopia")
+	if err := kopia.ValidateSyncTargets(rs.Spec.Kopia.Repository, rs.Spec.Kopia.SyncTo); err != nil {
+		allErrs = append(allErrs, field.Invalid(kopiaPath.Child("syncTo"), field.OmitValueType{}, err.Error()))
+	}
+	return allErrs
+}
@@ -0,0 +1,60 @@
+//go:build !disable_restic
+
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/mover/restic"
+)
+
+// validateResticSource runs the restic mover's own static checks on a
+// ReplicationSource
+func validateResticSource(rs *volsyncv1alpha1.ReplicationSource) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if rs.Spec.Restic == nil {
+		return allErrs
+	}
+
+	resticPath := field.NewPath("spec", "restic")
+	if err := restic.ValidateBackupIncludes(rs.Spec.Restic.Includes); err != nil {
+		allErrs = append(allErrs, field.Invalid(resticPath.Child("includes"), rs.Spec.Restic.Includes,
+			err.Error()))
+	}
+	if err := restic.ValidatePatterns(rs.Spec.Restic.Excludes); err != nil {
+		allErrs = append(allErrs, field.Invalid(resticPath.Child("excludes"), rs.Spec.Restic.Excludes,
+			err.Error()))
+	}
+	if err := restic.ValidatePatterns(rs.Spec.Restic.CaseInsensitiveExcludes); err != nil {
+		allErrs = append(allErrs, field.Invalid(resticPath.Child("caseInsensitiveExcludes"),
+			rs.Spec.Restic.CaseInsensitiveExcludes, err.Error()))
+	}
+	if err := restic.ValidatePatterns(rs.Spec.Restic.ExcludeIfPresent); err != nil {
+		allErrs = append(allErrs, field.Invalid(resticPath.Child("excludeIfPresent"),
+			rs.Spec.Restic.ExcludeIfPresent, err.Error()))
+	}
+	if err := restic.ValidateSecondaryRepositories(rs.Spec.Restic.Repository,
+		rs.Spec.Restic.SecondaryRepositories); err != nil {
+		allErrs = append(allErrs, field.Invalid(resticPath.Child("secondaryRepositories"),
+			field.OmitValueType{}, err.Error()))
+	}
+	return allErrs
+}
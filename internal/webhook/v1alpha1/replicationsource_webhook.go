@@ -0,0 +1,325 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+	"github.com/backube/volsync/internal/controller/utils"
+)
+
+var replicationsourcelog = logf.Log.WithName("replicationsource-webhook")
+
+// SetupReplicationSourceWebhookWithManager registers the webhooks for
+// ReplicationSource with the manager.
+func SetupReplicationSourceWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&volsyncv1alpha1.ReplicationSource{}).
+		WithValidator(&ReplicationSourceCustomValidator{}).
+		WithDefaulter(&ReplicationSourceCustomDefaulter{}).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/mutate-volsync-backube-v1alpha1-replicationsource,mutating=true,failurePolicy=fail,sideEffects=None,groups=volsync.backube,resources=replicationsources,verbs=create;update,versions=v1alpha1,name=mreplicationsource-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ReplicationSourceCustomDefaulter sets defaults on ReplicationSources
+// when they are created or updated.
+type ReplicationSourceCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ReplicationSourceCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *ReplicationSourceCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	rs, ok := obj.(*volsyncv1alpha1.ReplicationSource)
+	if !ok {
+		return fmt.Errorf("expected a ReplicationSource object but got %T", obj)
+	}
+	replicationsourcelog.V(1).Info("defaulting", "name", rs.GetName(), "namespace", rs.GetNamespace())
+
+	for _, volOpts := range sourceVolumeOptions(&rs.Spec) {
+		defaultCopyMethod(&volOpts.CopyMethod)
+	}
+	return nil
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-volsync-backube-v1alpha1-replicationsource,mutating=false,failurePolicy=fail,sideEffects=None,groups=volsync.backube,resources=replicationsources,verbs=create;update,versions=v1alpha1,name=vreplicationsource-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ReplicationSourceCustomValidator validates ReplicationSources when they
+// are created or updated.
+type ReplicationSourceCustomValidator struct{}
+
+var _ webhook.CustomValidator = &ReplicationSourceCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *ReplicationSourceCustomValidator) ValidateCreate(_ context.Context,
+	obj runtime.Object) (admission.Warnings, error) {
+	rs, ok := obj.(*volsyncv1alpha1.ReplicationSource)
+	if !ok {
+		return nil, fmt.Errorf("expected a ReplicationSource object but got %T", obj)
+	}
+	replicationsourcelog.V(1).Info("validating create", "name", rs.GetName(), "namespace", rs.GetNamespace())
+	return nil, validateReplicationSource(rs)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *ReplicationSourceCustomValidator) ValidateUpdate(_ context.Context,
+	_, newObj runtime.Object) (admission.Warnings, error) {
+	rs, ok := newObj.(*volsyncv1alpha1.ReplicationSource)
+	if !ok {
+		return nil, fmt.Errorf("expected a ReplicationSource object for the newObj but got %T", newObj)
+	}
+	replicationsourcelog.V(1).Info("validating update", "name", rs.GetName(), "namespace", rs.GetNamespace())
+	if !rs.GetDeletionTimestamp().IsZero() {
+		// Don't block finalizer removal on an object that is being deleted
+		return nil, nil
+	}
+	return nil, validateReplicationSource(rs)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *ReplicationSourceCustomValidator) ValidateDelete(_ context.Context,
+	_ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateReplicationSource(rs *volsyncv1alpha1.ReplicationSource) error {
+	allErrs := validateReplicationSourceSpec(&rs.Spec, field.NewPath("spec"))
+
+	allErrs = append(allErrs, validateKopiaSource(rs)...)
+	allErrs = append(allErrs, validateResticSource(rs)...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(volsyncv1alpha1.GroupVersion.WithKind("ReplicationSource").GroupKind(),
+		rs.GetName(), allErrs)
+}
+
+func validateReplicationSourceSpec(spec *volsyncv1alpha1.ReplicationSourceSpec,
+	specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Trigger != nil {
+		allErrs = append(allErrs, validateSchedule(spec.Trigger.Schedule, specPath.Child("trigger", "schedule"))...)
+	}
+
+	moverPaths := []*field.Path{}
+	if spec.Rsync != nil {
+		p := specPath.Child("rsync")
+		moverPaths = append(moverPaths, p)
+		if err := utils.ValidateTransferPolicy(spec.Rsync.TransferPolicy); err != nil {
+			allErrs = append(allErrs, field.Invalid(p.Child("transferPolicy"), spec.Rsync.TransferPolicy,
+				err.Error()))
+		}
+		allErrs = append(allErrs, validateUploadOnlyTransferPolicy(spec.Rsync.TransferPolicy,
+			p.Child("transferPolicy"), "rsync")...)
+	}
+	if spec.RsyncTLS != nil {
+		p := specPath.Child("rsyncTLS")
+		moverPaths = append(moverPaths, p)
+		allErrs = append(allErrs, validateMoverConfig(&spec.RsyncTLS.MoverConfig, p)...)
+	}
+	if spec.Rclone != nil {
+		p := specPath.Child("rclone")
+		moverPaths = append(moverPaths, p)
+		allErrs = append(allErrs, validateMoverConfig(&spec.Rclone.MoverConfig, p)...)
+		allErrs = append(allErrs, validateCustomCA(spec.Rclone.CustomCA, p.Child("customCA"))...)
+	}
+	if spec.Restic != nil {
+		p := specPath.Child("restic")
+		moverPaths = append(moverPaths, p)
+		allErrs = append(allErrs, validateMoverConfig(&spec.Restic.MoverConfig, p)...)
+		allErrs = append(allErrs, validateCustomCA(volsyncv1alpha1.CustomCASpec(spec.Restic.CustomCA),
+			p.Child("customCA"))...)
+	}
+	if spec.Syncthing != nil {
+		p := specPath.Child("syncthing")
+		moverPaths = append(moverPaths, p)
+		allErrs = append(allErrs, validateMoverConfig(&spec.Syncthing.MoverConfig, p)...)
+		if spec.Syncthing.TransferPolicy != nil {
+			allErrs = append(allErrs, field.Forbidden(p.Child("transferPolicy"),
+				"transferPolicy is not supported by the syncthing mover"))
+		}
+		if spec.RetryPolicy != nil {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("retryPolicy"),
+				"retryPolicy is not supported by the syncthing mover"))
+		}
+	}
+	if spec.Kopia != nil {
+		p := specPath.Child("kopia")
+		moverPaths = append(moverPaths, p)
+		allErrs = append(allErrs, validateMoverConfig(&spec.Kopia.MoverConfig, p)...)
+		allErrs = append(allErrs, validateCustomCA(volsyncv1alpha1.CustomCASpec(spec.Kopia.CustomCA),
+			p.Child("customCA"))...)
+		allErrs = append(allErrs, validateKopiaVerify(spec.Kopia.Verify, p.Child("verify"))...)
+	}
+	if spec.External != nil {
+		moverPaths = append(moverPaths, specPath.Child("external"))
+	}
+	allErrs = append(allErrs, validateMoverCount(moverPaths, specPath)...)
+	allErrs = append(allErrs, validateSourcePVCSelector(spec, specPath)...)
+	allErrs = append(allErrs, validateHooks(spec.Hooks, specPath.Child("hooks"))...)
+	allErrs = append(allErrs, validateRetryPolicy(spec.RetryPolicy, specPath.Child("retryPolicy"))...)
+
+	return allErrs
+}
+
+// sourceVolumeOptions returns the volume options of each mover that is set
+// in the spec
+func sourceVolumeOptions(
+	spec *volsyncv1alpha1.ReplicationSourceSpec) []*volsyncv1alpha1.ReplicationSourceVolumeOptions {
+	opts := []*volsyncv1alpha1.ReplicationSourceVolumeOptions{}
+	if spec.Rsync != nil {
+		opts = append(opts, &spec.Rsync.ReplicationSourceVolumeOptions)
+	}
+	if spec.RsyncTLS != nil {
+		opts = append(opts, &spec.RsyncTLS.ReplicationSourceVolumeOptions)
+	}
+	if spec.Rclone != nil {
+		opts = append(opts, &spec.Rclone.ReplicationSourceVolumeOptions)
+	}
+	if spec.Restic != nil {
+		opts = append(opts, &spec.Restic.ReplicationSourceVolumeOptions)
+	}
+	if spec.Kopia != nil {
+		opts = append(opts, &spec.Kopia.ReplicationSourceVolumeOptions)
+	}
+	return opts
+}
+
+// validateSourcePVCSelector checks that sourcePVCSelector is only used
+// instead of sourcePVC, and always together with the GroupSnapshot copyMethod.
+// Only the restic and kopia movers support the GroupSnapshot copyMethod.
+func validateSourcePVCSelector(spec *volsyncv1alpha1.ReplicationSourceSpec, specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	selectorPath := specPath.Child("sourcePVCSelector")
+	if spec.SourcePVCSelector != nil {
+		if spec.SourcePVC != "" {
+			allErrs = append(allErrs, field.Forbidden(selectorPath,
+				"only one of sourcePVC and sourcePVCSelector may be set"))
+		}
+		if _, err := metav1.LabelSelectorAsSelector(spec.SourcePVCSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(selectorPath, spec.SourcePVCSelector, err.Error()))
+		}
+	}
+
+	unsupportedPaths := []*field.Path{}
+	if spec.Rsync != nil && spec.Rsync.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		unsupportedPaths = append(unsupportedPaths, specPath.Child("rsync", "copyMethod"))
+	}
+	if spec.RsyncTLS != nil && spec.RsyncTLS.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		unsupportedPaths = append(unsupportedPaths, specPath.Child("rsyncTLS", "copyMethod"))
+	}
+	if spec.Rclone != nil && spec.Rclone.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+		unsupportedPaths = append(unsupportedPaths, specPath.Child("rclone", "copyMethod"))
+	}
+	for _, p := range unsupportedPaths {
+		allErrs = append(allErrs, field.Forbidden(p,
+			"the GroupSnapshot copyMethod is only supported by the restic and kopia movers"))
+	}
+
+	usesGroupSnapshot := false
+	for _, opts := range sourceVolumeOptions(spec) {
+		if opts.CopyMethod == volsyncv1alpha1.CopyMethodGroupSnapshot {
+			usesGroupSnapshot = true
+		}
+	}
+	switch {
+	case usesGroupSnapshot && spec.SourcePVCSelector == nil:
+		allErrs = append(allErrs, field.Required(selectorPath,
+			"sourcePVCSelector must be set when copyMethod is GroupSnapshot"))
+	case !usesGroupSnapshot && spec.SourcePVCSelector != nil:
+		allErrs = append(allErrs, field.Forbidden(selectorPath,
+			"sourcePVCSelector may only be set when copyMethod is GroupSnapshot"))
+	}
+	return allErrs
+}
+
+// validateKopiaVerify checks that a verification interval is given in only
+// one way and that its schedule can be parsed
+func validateKopiaVerify(verify *volsyncv1alpha1.KopiaVerifySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if verify == nil {
+		return allErrs
+	}
+	if verify.EveryNSyncs != nil && verify.Schedule != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("schedule"),
+			"only one of everyNSyncs and schedule may be set"))
+	}
+	allErrs = append(allErrs, validateSchedule(verify.Schedule, fldPath.Child("schedule"))...)
+	return allErrs
+}
+
+// validateHooks checks that at least one hook is defined, that the pod
+// selector can be parsed and that hook timeouts are positive
+func validateHooks(hooks *volsyncv1alpha1.ReplicationSourceHooksSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hooks == nil {
+		return allErrs
+	}
+	if hooks.Before == nil && hooks.After == nil {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of before and after must be set"))
+	}
+	if _, err := metav1.LabelSelectorAsSelector(&hooks.PodSelector); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("podSelector"), hooks.PodSelector, err.Error()))
+	}
+	allErrs = append(allErrs, validateHookTimeout(hooks.Before, fldPath.Child("before", "timeout"))...)
+	allErrs = append(allErrs, validateHookTimeout(hooks.After, fldPath.Child("after", "timeout"))...)
+	return allErrs
+}
+
+func validateHookTimeout(hook *volsyncv1alpha1.ReplicationSourceHook, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if hook != nil && hook.Timeout != nil && hook.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, hook.Timeout.Duration.String(),
+			"must be greater than zero"))
+	}
+	return allErrs
+}
+
+// validateUploadOnlyTransferPolicy rejects the download limits of the
+// transferPolicy of a mover that can only limit the data it sends
+func validateUploadOnlyTransferPolicy(policy *volsyncv1alpha1.TransferPolicy, p *field.Path,
+	moverName string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy == nil {
+		return allErrs
+	}
+	msg := fmt.Sprintf("downloadLimit is not supported by the %s mover, which only limits uploads", moverName)
+	if policy.DownloadLimit != nil {
+		allErrs = append(allErrs, field.Forbidden(p.Child("downloadLimit"), msg))
+	}
+	for i, w := range policy.Windows {
+		if w.DownloadLimit != nil {
+			allErrs = append(allErrs, field.Forbidden(p.Child("windows").Index(i).Child("downloadLimit"), msg))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,433 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var _ = Describe("ReplicationSource Webhook", func() {
+	var rs *volsyncv1alpha1.ReplicationSource
+	var validator *ReplicationSourceCustomValidator
+	var defaulter *ReplicationSourceCustomDefaulter
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		validator = &ReplicationSourceCustomValidator{}
+		defaulter = &ReplicationSourceCustomDefaulter{}
+		rs = &volsyncv1alpha1.ReplicationSource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "rs",
+				Namespace: "test-ns",
+			},
+			Spec: volsyncv1alpha1.ReplicationSourceSpec{
+				SourcePVC: "mypvc",
+				Trigger: &volsyncv1alpha1.ReplicationSourceTriggerSpec{
+					Schedule: ptr.To("*/5 * * * *"),
+				},
+				Restic: &volsyncv1alpha1.ReplicationSourceResticSpec{
+					Repository: "restic-secret",
+				},
+			},
+		}
+	})
+
+	When("the spec is valid", func() {
+		It("should be admitted on create and update", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = validator.ValidateUpdate(ctx, rs.DeepCopy(), rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("no mover is specified", func() {
+		BeforeEach(func() {
+			rs.Spec.Restic = nil
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("a replication method must be specified")))
+		})
+	})
+
+	When("multiple movers are specified", func() {
+		BeforeEach(func() {
+			rs.Spec.Rclone = &volsyncv1alpha1.ReplicationSourceRcloneSpec{}
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("only one replication method can be supplied")))
+		})
+	})
+
+	When("an external provider is used along with a mover", func() {
+		BeforeEach(func() {
+			rs.Spec.External = &volsyncv1alpha1.ReplicationSourceExternalSpec{Provider: "example.com/provider"}
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.external")))
+		})
+	})
+
+	When("the schedule cannot be parsed", func() {
+		BeforeEach(func() {
+			rs.Spec.Trigger.Schedule = ptr.To("61 * * * *")
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.trigger.schedule")))
+		})
+	})
+
+	When("a descriptor schedule is used", func() {
+		BeforeEach(func() {
+			rs.Spec.Trigger.Schedule = ptr.To("@daily")
+		})
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the customCA sets both a secret and a configmap", func() {
+		BeforeEach(func() {
+			rs.Spec.Restic.CustomCA = volsyncv1alpha1.ReplicationSourceResticCA{
+				SecretName:    "ca-secret",
+				ConfigMapName: "ca-cm",
+				Key:           "ca.crt",
+			}
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.customCA")))
+		})
+	})
+
+	When("a moverVolume has an invalid mount path", func() {
+		BeforeEach(func() {
+			rs.Spec.Restic.MoverVolumes = []volsyncv1alpha1.MoverVolume{
+				{
+					MountPath: "../etc",
+					VolumeSource: volsyncv1alpha1.MoverVolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: "sec"},
+					},
+				},
+			}
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.moverVolumes")))
+		})
+		It("should not block updates while the object is being deleted", func() {
+			now := metav1.Now()
+			rs.DeletionTimestamp = &now
+			_, err := validator.ValidateUpdate(ctx, rs.DeepCopy(), rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("a transferPolicy is specified", func() {
+		BeforeEach(func() {
+			rs.Spec.Restic.TransferPolicy = &volsyncv1alpha1.TransferPolicy{
+				UploadLimit: ptr.To(resource.MustParse("10Mi")),
+				Windows: []volsyncv1alpha1.TransferWindow{{
+					Start: "22:00",
+					End:   "06:00",
+				}},
+			}
+		})
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should be rejected with overlapping windows", func() {
+			rs.Spec.Restic.TransferPolicy.Windows = append(rs.Spec.Restic.TransferPolicy.Windows,
+				volsyncv1alpha1.TransferWindow{Start: "05:00", End: "07:00"})
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.transferPolicy")))
+		})
+		It("should be rejected with a zero limit", func() {
+			rs.Spec.Restic.TransferPolicy.UploadLimit = ptr.To(resource.MustParse("0"))
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.transferPolicy")))
+		})
+		It("should be rejected for the syncthing mover", func() {
+			rs.Spec.Syncthing = &volsyncv1alpha1.ReplicationSourceSyncthingSpec{}
+			rs.Spec.Syncthing.TransferPolicy = rs.Spec.Restic.TransferPolicy
+			rs.Spec.Restic = nil
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.syncthing.transferPolicy")))
+		})
+		It("should be rejected with a downloadLimit for the rsync mover", func() {
+			rs.Spec.Rsync = &volsyncv1alpha1.ReplicationSourceRsyncSpec{}
+			rs.Spec.Rsync.TransferPolicy = rs.Spec.Restic.TransferPolicy
+			rs.Spec.Restic = nil
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+
+			rs.Spec.Rsync.TransferPolicy.Windows[0].DownloadLimit = ptr.To(resource.MustParse("1Mi"))
+			_, err = validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.rsync.transferPolicy.windows[0].downloadLimit")))
+		})
+	})
+
+	When("a retryPolicy is specified", func() {
+		BeforeEach(func() {
+			rs.Spec.RetryPolicy = &volsyncv1alpha1.RetryPolicy{
+				MaxAttempts:    ptr.To(int32(5)),
+				InitialBackoff: &metav1.Duration{Duration: 30 * time.Second},
+				MaxBackoff:     &metav1.Duration{Duration: 10 * time.Minute},
+				RetryOn:        []volsyncv1alpha1.FailureClass{volsyncv1alpha1.FailureClassTransient},
+			}
+		})
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should be rejected with an initialBackoff above the maxBackoff", func() {
+			rs.Spec.RetryPolicy.InitialBackoff.Duration = time.Hour
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.retryPolicy.initialBackoff")))
+		})
+		It("should be rejected with a zero maxBackoff", func() {
+			rs.Spec.RetryPolicy.MaxBackoff.Duration = 0
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.retryPolicy.maxBackoff")))
+		})
+		It("should be rejected for the syncthing mover", func() {
+			rs.Spec.Syncthing = &volsyncv1alpha1.ReplicationSourceSyncthingSpec{}
+			rs.Spec.Restic = nil
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.retryPolicy")))
+		})
+	})
+
+	When("a kopia verify interval is specified", func() {
+		BeforeEach(func() {
+			rs.Spec.Restic = nil
+			rs.Spec.Kopia = &volsyncv1alpha1.ReplicationSourceKopiaSpec{
+				Repository: "kopia-secret",
+				Verify: &volsyncv1alpha1.KopiaVerifySpec{
+					FilesPercent: ptr.To(int32(5)),
+					Schedule:     ptr.To("@weekly"),
+				},
+			}
+		})
+		It("should be admitted with a schedule", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should be rejected with both everyNSyncs and schedule", func() {
+			rs.Spec.Kopia.Verify.EveryNSyncs = ptr.To(int32(3))
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.kopia.verify.schedule")))
+		})
+		It("should be rejected with an invalid schedule", func() {
+			rs.Spec.Kopia.Verify.Schedule = ptr.To("every sunday")
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.kopia.verify.schedule")))
+		})
+	})
+
+	When("kopia syncTo targets are specified", func() {
+		BeforeEach(func() {
+			rs.Spec.Restic = nil
+			rs.Spec.Kopia = &volsyncv1alpha1.ReplicationSourceKopiaSpec{
+				Repository: "kopia-secret",
+				SyncTo: []volsyncv1alpha1.KopiaSyncTarget{
+					{Repository: "local-bucket"},
+					{Repository: "remote-bucket"},
+				},
+			}
+		})
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should reject syncing to the source repository", func() {
+			rs.Spec.Kopia.SyncTo[1].Repository = "kopia-secret"
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.kopia.syncTo")))
+		})
+	})
+
+	When("restic includes and excludes are specified", func() {
+		BeforeEach(func() {
+			rs.Spec.Restic.Includes = []string{"app/data", "/config.yaml"}
+			rs.Spec.Restic.Excludes = []string{"/app/data/cache", "*.tmp"}
+			rs.Spec.Restic.ExcludeIfPresent = []string{".nobackup"}
+		})
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should reject includes that escape the volume", func() {
+			rs.Spec.Restic.Includes = []string{"app/../../etc"}
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.includes")))
+		})
+		It("should reject including the root of the volume", func() {
+			rs.Spec.Restic.Includes = []string{"/"}
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.includes")))
+		})
+		It("should reject empty and multi-line patterns", func() {
+			rs.Spec.Restic.Excludes = []string{""}
+			rs.Spec.Restic.CaseInsensitiveExcludes = []string{"*.tmp\n*.bak"}
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.excludes")))
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.caseInsensitiveExcludes")))
+		})
+	})
+
+	When("restic secondary repositories are specified", func() {
+		BeforeEach(func() {
+			rs.Spec.Restic.SecondaryRepositories = []volsyncv1alpha1.ResticSecondaryRepository{
+				{Repository: "offsite-secret"},
+			}
+		})
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should reject copying to the primary repository", func() {
+			rs.Spec.Restic.SecondaryRepositories[0].Repository = rs.Spec.Restic.Repository
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.secondaryRepositories")))
+		})
+		It("should reject repeated repositories", func() {
+			rs.Spec.Restic.SecondaryRepositories = append(rs.Spec.Restic.SecondaryRepositories,
+				volsyncv1alpha1.ResticSecondaryRepository{Repository: "offsite-secret"})
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.restic.secondaryRepositories")))
+		})
+	})
+
+	When("hooks are specified", func() {
+		BeforeEach(func() {
+			rs.Spec.Hooks = &volsyncv1alpha1.ReplicationSourceHooksSpec{
+				PodSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "db"},
+				},
+				ContainerName: "db",
+				Before: &volsyncv1alpha1.ReplicationSourceHook{
+					Command: []string{"fsfreeze", "-f", "/data"},
+				},
+			}
+		})
+		It("should be admitted", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should be rejected without a before or after hook", func() {
+			rs.Spec.Hooks.Before = nil
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.hooks")))
+		})
+		It("should be rejected with an invalid podSelector", func() {
+			rs.Spec.Hooks.PodSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{
+				Key:      "app",
+				Operator: "Bogus",
+			}}
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.hooks.podSelector")))
+		})
+		It("should be rejected with a zero timeout", func() {
+			rs.Spec.Hooks.Before.Timeout = &metav1.Duration{}
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.hooks.before.timeout")))
+		})
+	})
+
+	When("a sourcePVCSelector is specified", func() {
+		BeforeEach(func() {
+			rs.Spec.SourcePVC = ""
+			rs.Spec.SourcePVCSelector = &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "db"},
+			}
+			rs.Spec.Restic.CopyMethod = volsyncv1alpha1.CopyMethodGroupSnapshot
+		})
+		It("should be admitted with the GroupSnapshot copyMethod", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should be rejected together with a sourcePVC", func() {
+			rs.Spec.SourcePVC = "mypvc"
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("only one of sourcePVC and sourcePVCSelector")))
+		})
+		It("should be rejected with another copyMethod", func() {
+			rs.Spec.Restic.CopyMethod = volsyncv1alpha1.CopyMethodSnapshot
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.sourcePVCSelector")))
+		})
+		It("should be rejected if it can't be parsed", func() {
+			rs.Spec.SourcePVCSelector.MatchExpressions = []metav1.LabelSelectorRequirement{{
+				Key:      "app",
+				Operator: "Bogus",
+			}}
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.sourcePVCSelector")))
+		})
+		It("should be rejected with a mover that doesn't support GroupSnapshot", func() {
+			rs.Spec.Restic = nil
+			rs.Spec.Rclone = &volsyncv1alpha1.ReplicationSourceRcloneSpec{
+				ReplicationSourceVolumeOptions: volsyncv1alpha1.ReplicationSourceVolumeOptions{
+					CopyMethod: volsyncv1alpha1.CopyMethodGroupSnapshot,
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.rclone.copyMethod")))
+		})
+	})
+
+	When("the GroupSnapshot copyMethod is used without a sourcePVCSelector", func() {
+		BeforeEach(func() {
+			rs.Spec.Restic.CopyMethod = volsyncv1alpha1.CopyMethodGroupSnapshot
+		})
+		It("should be rejected", func() {
+			_, err := validator.ValidateCreate(ctx, rs)
+			Expect(err).To(MatchError(ContainSubstring("spec.sourcePVCSelector")))
+		})
+	})
+
+	Describe("Defaulting", func() {
+		It("should replace the deprecated None copyMethod with Direct", func() {
+			rs.Spec.Restic.CopyMethod = volsyncv1alpha1.CopyMethodNone
+			Expect(defaulter.Default(ctx, rs)).To(Succeed())
+			Expect(rs.Spec.Restic.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodDirect))
+		})
+		It("should leave other copyMethods untouched", func() {
+			rs.Spec.Restic.CopyMethod = volsyncv1alpha1.CopyMethodSnapshot
+			Expect(defaulter.Default(ctx, rs)).To(Succeed())
+			Expect(rs.Spec.Restic.CopyMethod).To(Equal(volsyncv1alpha1.CopyMethodSnapshot))
+		})
+	})
+})
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var resticmaintenancelog = logf.Log.WithName("resticmaintenance-webhook")
+
+// SetupResticMaintenanceWebhookWithManager registers the webhook for
+// ResticMaintenance with the manager.
+func SetupResticMaintenanceWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&volsyncv1alpha1.ResticMaintenance{}).
+		WithValidator(&ResticMaintenanceCustomValidator{}).
+		Complete()
+}
+
+//nolint:lll
+//+kubebuilder:webhook:path=/validate-volsync-backube-v1alpha1-resticmaintenance,mutating=false,failurePolicy=fail,sideEffects=None,groups=volsync.backube,resources=resticmaintenances,verbs=create;update,versions=v1alpha1,name=vresticmaintenance-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ResticMaintenanceCustomValidator validates ResticMaintenances when they are
+// created or updated.
+type ResticMaintenanceCustomValidator struct{}
+
+var _ webhook.CustomValidator = &ResticMaintenanceCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *ResticMaintenanceCustomValidator) ValidateCreate(_ context.Context,
+	obj runtime.Object) (admission.Warnings, error) {
+	rm, ok := obj.(*volsyncv1alpha1.ResticMaintenance)
+	if !ok {
+		return nil, fmt.Errorf("expected a ResticMaintenance object but got %T", obj)
+	}
+	resticmaintenancelog.V(1).Info("validating create", "name", rm.GetName(), "namespace", rm.GetNamespace())
+	return nil, validateResticMaintenance(rm)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *ResticMaintenanceCustomValidator) ValidateUpdate(_ context.Context,
+	_, newObj runtime.Object) (admission.Warnings, error) {
+	rm, ok := newObj.(*volsyncv1alpha1.ResticMaintenance)
+	if !ok {
+		return nil, fmt.Errorf("expected a ResticMaintenance object for the newObj but got %T", newObj)
+	}
+	resticmaintenancelog.V(1).Info("validating update", "name", rm.GetName(), "namespace", rm.GetNamespace())
+	if !rm.GetDeletionTimestamp().IsZero() {
+		// Don't block finalizer removal on an object that is being deleted
+		return nil, nil
+	}
+	return nil, validateResticMaintenance(rm)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *ResticMaintenanceCustomValidator) ValidateDelete(_ context.Context,
+	_ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateResticMaintenance(rm *volsyncv1alpha1.ResticMaintenance) error {
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+
+	if err := rm.Validate(); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath, field.OmitValueType{}, err.Error()))
+	}
+	if rm.Spec.CustomCA != nil {
+		allErrs = append(allErrs, validateCustomCA(volsyncv1alpha1.CustomCASpec(*rm.Spec.CustomCA),
+			specPath.Child("customCA"))...)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(volsyncv1alpha1.GroupVersion.WithKind("ResticMaintenance").GroupKind(),
+		rm.GetName(), allErrs)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The VolSync authors.
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	volsyncv1alpha1 "github.com/backube/volsync/api/v1alpha1"
+)
+
+var _ = Describe("ResticMaintenance Webhook", func() {
+	var rm *volsyncv1alpha1.ResticMaintenance
+	var validator *ResticMaintenanceCustomValidator
+	ctx := context.Background()
+
+	BeforeEach(func() {
+		validator = &ResticMaintenanceCustomValidator{}
+		rm = &volsyncv1alpha1.ResticMaintenance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "rm",
+				Namespace: "test-ns",
+			},
+			Spec: volsyncv1alpha1.ResticMaintenanceSpec{
+				Repository: "restic-secret",
+				Trigger: &volsyncv1alpha1.ResticMaintenanceTriggerSpec{
+					Schedule: ptr.To("0 3 * * 0"),
+				},
+			},
+		}
+	})
+
+	It("should admit a valid spec", func() {
+		_, err := validator.ValidateCreate(ctx, rm)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject specs that fail ResticMaintenance.Validate()", func() {
+		rm.Spec.Trigger.Manual = "now"
+		_, err := validator.ValidateCreate(ctx, rm)
+		Expect(err).To(MatchError(ContainSubstring("cannot specify both schedule and manual triggers")))
+	})
+
+	It("should reject an invalid customCA", func() {
+		rm.Spec.CustomCA = &volsyncv1alpha1.ReplicationSourceResticCA{
+			SecretName: "ca-secret",
+		}
+		_, err := validator.ValidateUpdate(ctx, rm.DeepCopy(), rm)
+		Expect(err).To(MatchError(ContainSubstring("spec.customCA")))
+	})
+})
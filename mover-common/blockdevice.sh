@@ -0,0 +1,45 @@
+#! /bin/bash
+# Restores a snapshot of a block volume to the block device. Sourced by the
+# restic and kopia movers, which both snapshot a block device as a single file.
+
+#######################################
+# Zeroes the block device if the device
+# can do it without writing the zeros
+# Globals:
+#   BLOCK_DEVICE
+#######################################
+function zero_block_device {
+    local device major minor max_bytes
+    device=$(stat -L -c '%t:%T' "${BLOCK_DEVICE}") || return 1
+    major=$((16#${device%:*}))
+    minor=$((16#${device#*:}))
+    max_bytes=$(cat "/sys/dev/block/${major}:${minor}/queue/write_zeroes_max_bytes" 2>/dev/null) || return 1
+    [[ ${max_bytes} -gt 0 ]] && blkdiscard --zeroout "${BLOCK_DEVICE}"
+}
+
+#######################################
+# Writes stdin to the block device. The
+# device is only modified if the image
+# fits on it, so that a snapshot of the
+# wrong volume doesn't wipe the device.
+# The zero regions are skipped if the
+# device could be zeroed first, otherwise
+# they would keep stale data.
+# Globals:
+#   BLOCK_DEVICE
+# Arguments:
+#   Size of the image in bytes
+#######################################
+function write_block_device {
+    local device_size conv="notrunc,fsync"
+    device_size=$(blockdev --getsize64 "${BLOCK_DEVICE}") || return 1
+    if [[ ! $1 =~ ^[0-9]+$ || $1 -eq 0 || $1 -gt ${device_size} ]]; then
+        echo "The image of $1 bytes doesn't fit on ${BLOCK_DEVICE} (${device_size} bytes)"
+        return 1
+    fi
+    if zero_block_device; then
+        echo "Skipping zero regions of the image"
+        conv="notrunc,sparse,fsync"
+    fi
+    dd of="${BLOCK_DEVICE}" bs=4M iflag=fullblock conv="${conv}" status=none
+}
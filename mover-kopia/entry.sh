@@ -59,6 +59,10 @@ OPERATION_RESULT="UNKNOWN"
 OPERATION_FAILURE_REASON=""
 KOPIA_ERROR_OUTPUT=""
 MAINTENANCE_DURATION=""
+# Block volumes are snapshotted as a single file with this name
+BLOCK_FILENAME="data.img"
+# shellcheck source=../mover-common/blockdevice.sh
+source /mover-common/blockdevice.sh
 
 # Function to log with structured prefixes and respect log level
 log_info() {
@@ -234,6 +238,38 @@ output_operation_summary() {
     log_info "=== Done ==="
 }
 
+# Machine-readable result of the mover. It is written to the termination
+# message of the container when the script exits, and the controller reads the
+# snapshot ID, statistics, errors and Kopia specific details from it. The logs
+# are only meant for humans.
+MOVER_RESULT_FILE="${MOVER_RESULT_FILE:-/dev/termination-log}"
+MOVER_RESULT='{"version":1}'
+MOVER_START_SECONDS=${SECONDS}
+
+# Update the mover result with a jq filter. Failures are ignored since the
+# result must never fail the operation.
+# Usage: result_update [jq options...] filter
+result_update() {
+    local updated
+    if updated=$(jq -c "$@" <<< "${MOVER_RESULT}" 2>/dev/null); then
+        MOVER_RESULT="${updated}"
+    fi
+}
+
+# Write the mover result to the termination message. Kubernetes truncates the
+# message to 4096 bytes, so the lists that grow with the repository are dropped
+# if the result doesn't fit.
+write_mover_result() {
+    result_update --argjson d "$((SECONDS - MOVER_START_SECONDS))" '.durationSeconds = $d'
+    local result="${MOVER_RESULT}"
+    if [[ $(printf '%s' "${result}" | wc -c) -gt 4096 ]]; then
+        result=$(jq -c '.truncated = true
+            | del(.details.availableIdentities, .details.restoredPaths)
+            | if .errors then .errors |= (.[-5:] | map(.[0:256])) else . end' <<< "${result}" 2>/dev/null) || return 0
+    fi
+    printf '%s' "${result}" > "${MOVER_RESULT_FILE}" 2>/dev/null || true
+}
+
 # EXIT trap handler for consistent cleanup and summary output
 handle_exit() {
     local exit_code=$?
@@ -245,6 +281,7 @@ handle_exit() {
     fi
 
     output_operation_summary "${exit_code}"
+    write_mover_result
 }
 
 # Install EXIT trap for summary output
@@ -393,7 +430,11 @@ echo "KOPIA_MANUAL_CONFIG: $([ -n "${KOPIA_MANUAL_CONFIG}" ] && echo "[SET]" ||
 echo "KOPIA_RESTORE_AS_OF: $([ -n "${KOPIA_RESTORE_AS_OF}" ] && echo "[SET]" || echo "[NOT SET]")"
 echo "KOPIA_SHALLOW: $([ -n "${KOPIA_SHALLOW}" ] && echo "[SET]" || echo "[NOT SET]")"
 echo "KOPIA_PREVIOUS: $([ -n "${KOPIA_PREVIOUS}" ] && echo "[SET]" || echo "[NOT SET]")"
+echo "KOPIA_VERIFY_FILES_PERCENT: ${KOPIA_VERIFY_FILES_PERCENT:-[NOT SET]}"
+echo "KOPIA_RESTORE_SNAPSHOT_ID: $([ -n "${KOPIA_RESTORE_SNAPSHOT_ID}" ] && echo "[SET]" || echo "[NOT SET]")"
 echo "KOPIA_ENABLE_FILE_DELETION: $([ -n "${KOPIA_ENABLE_FILE_DELETION}" ] && echo "[SET]" || echo "[NOT SET]")"
+echo "KOPIA_RESTORE_INCLUDE_PATHS: $([ -n "${KOPIA_RESTORE_INCLUDE_PATHS}" ] && echo "[SET]" || echo "[NOT SET]")"
+echo "KOPIA_RESTORE_EXCLUDE_PATHS: $([ -n "${KOPIA_RESTORE_EXCLUDE_PATHS}" ] && echo "[SET]" || echo "[NOT SET]")"
 echo "KOPIA_ADDITIONAL_ARGS: $([ -n "${KOPIA_ADDITIONAL_ARGS}" ] && echo "[SET]" || echo "[NOT SET]")"
 echo "KOPIA_CACHE_CAPACITY_BYTES: $([ -n "${KOPIA_CACHE_CAPACITY_BYTES}" ] && echo "[SET] ($(format_bytes ${KOPIA_CACHE_CAPACITY_BYTES}))" || echo "[NOT SET] (default: 8Gi)")"
 echo ""
@@ -453,6 +494,7 @@ echo "====================="
 function error {
     echo ""  # Add blank line before error
     echo "ERROR: $2"
+    result_update --arg e "$2" '.errors += [$e]'
     exit "$1"
 }
 
@@ -643,6 +685,9 @@ function execute_action {
 }
 
 function check_contents {
+    if [[ -n "${BLOCK_DEVICE}" ]]; then
+        return 0
+    fi
     echo "=== Checking directory for content ==="
     DIR_CONTENTS="$(ls -A "${DATA_DIR}" --ignore="lost+found")"
     if [ -z "${DIR_CONTENTS}" ]; then
@@ -888,6 +933,22 @@ function add_manual_config_params {
     fi
 }
 
+# lower_speed_limit current configured
+# Prints the lower of two speed limits in bytes per second. The current limit
+# is set by the controller from the transferPolicy, and is kept if the
+# configured one isn't lower or isn't a plain number.
+function lower_speed_limit {
+    local current=$1
+    local configured=$2
+    if [[ -z "${current}" ]]; then
+        echo "${configured}"
+    elif [[ "${configured}" =~ ^[0-9]+$ ]] && [[ ${configured} -lt ${current} ]]; then
+        echo "${configured}"
+    else
+        echo "${current}"
+    fi
+}
+
 # Apply policy configuration if available
 # This function handles two types of configuration from mounted ConfigMaps/Secrets:
 # 1. Global policy file (e.g., retention, compression) - applied via 'kopia policy set --global'
@@ -1048,6 +1109,8 @@ function apply_policy_config {
             local upload_speed
             upload_speed=$(jq -r '.uploadSpeed // empty' <<< "${repo_config}" 2>/dev/null || true)
             if [[ -n "${upload_speed}" && "${upload_speed}" != "null" ]]; then
+                # A limit from the transferPolicy is only lowered, never raised
+                upload_speed=$(lower_speed_limit "${KOPIA_UPLOAD_SPEED}" "${upload_speed}")
                 echo "Setting upload speed limit: ${upload_speed}"
                 export KOPIA_UPLOAD_SPEED="${upload_speed}"
             fi
@@ -1056,6 +1119,7 @@ function apply_policy_config {
             local download_speed
             download_speed=$(jq -r '.downloadSpeed // empty' <<< "${repo_config}" 2>/dev/null || true)
             if [[ -n "${download_speed}" && "${download_speed}" != "null" ]]; then
+                download_speed=$(lower_speed_limit "${KOPIA_DOWNLOAD_SPEED}" "${download_speed}")
                 echo "Setting download speed limit: ${download_speed}"
                 export KOPIA_DOWNLOAD_SPEED="${download_speed}"
             fi
@@ -1948,12 +2012,26 @@ function do_backup {
         SNAPSHOT_CMD+=(--upload-speed="${KOPIA_UPLOAD_SPEED}")
     fi
     
+    # Record the capacity of the source volume so that destinations can grow
+    # their volume to match it
+    if [[ -n "${SOURCE_CAPACITY}" ]]; then
+        SNAPSHOT_CMD+=(--tags="volsync-source-capacity:${SOURCE_CAPACITY}")
+    fi
+
     # Add source path override if specified
     if [[ -n "${KOPIA_SOURCE_PATH_OVERRIDE}" ]]; then
         echo "Using source path override: ${KOPIA_SOURCE_PATH_OVERRIDE}"
         SNAPSHOT_CMD+=(--override-source="${KOPIA_SOURCE_PATH_OVERRIDE}")
     fi
 
+    # Block devices are snapshotted as a single file read from stdin
+    local snapshot_input=/dev/stdin
+    if [[ -n "${BLOCK_DEVICE}" ]]; then
+        log_info "Snapshotting block device ${BLOCK_DEVICE} as ${BLOCK_FILENAME}"
+        SNAPSHOT_CMD+=(--stdin-file="${BLOCK_FILENAME}")
+        snapshot_input="${BLOCK_DEVICE}"
+    fi
+
     # Note: The client identity for snapshots is set via 'repository set-client' command
     # which is executed in the set_client_identity() function after repository connection
 
@@ -1975,7 +2053,7 @@ function do_backup {
     local snapshot_start_time=$(date +%s)
     log_info "Starting kopia snapshot creation..."
 
-    if ! run_with_progress_output "${SNAPSHOT_CMD[@]}"; then
+    if ! run_with_progress_output "${SNAPSHOT_CMD[@]}" < "${snapshot_input}"; then
         local snapshot_end_time=$(date +%s)
         log_timing "Snapshot creation failed after $((snapshot_end_time - snapshot_start_time)) seconds"
         error 1 "Failed to create snapshot"
@@ -1984,6 +2062,7 @@ function do_backup {
     local snapshot_end_time=$(date +%s)
     log_timing "Snapshot creation completed in $((snapshot_end_time - snapshot_start_time)) seconds"
     log_info "Snapshot created successfully"
+    record_snapshot_result
     
     # Run after-snapshot action if specified (check if actions are enabled)
     if [[ -n "${KOPIA_AFTER_SNAPSHOT}" ]] && [[ "${KOPIA_ACTIONS_ENABLED}" != "false" ]]; then
@@ -1997,6 +2076,20 @@ function do_backup {
     log_timing "Total backup operation took $((backup_end_time - backup_start_time)) seconds"
 }
 
+# Record the ID and statistics of the snapshot created by do_backup in the
+# mover result
+function record_snapshot_result {
+    local snapshot_path="${KOPIA_SOURCE_PATH_OVERRIDE:-${DATA_DIR}}"
+    local snapshot
+    snapshot=$("${KOPIA[@]}" snapshot list "${snapshot_path}" --json 2>/dev/null | jq -c 'last // empty' 2>/dev/null || true)
+    if [[ -z "${snapshot}" ]]; then
+        log_warn "Unable to find the new snapshot of ${snapshot_path}"
+        return 0
+    fi
+    result_update --argjson s "${snapshot}" \
+        '.snapshotID = $s.id | .bytesProcessed = $s.stats.totalSize | .filesProcessed = $s.stats.fileCount'
+}
+
 function ensure_maintenance_ownership {
     log_info "=== Checking maintenance ownership ===="
 
@@ -2128,6 +2221,244 @@ function do_maintenance {
     return 0
 }
 
+# List every snapshot in the repository for the KopiaSnapshot catalog. Each
+# snapshot manifest, reduced to the fields the catalog uses, is printed on its
+# own line, followed by the number of snapshots so the controller can detect
+# truncated output. The number is also reported in the mover result, so the
+# controller only reads the end of the logs that holds the catalog.
+function do_catalog {
+    log_info "=== Listing repository snapshots ===="
+
+    local snapshot_output
+    local list_exit_code=0
+    local stderr_file
+    stderr_file=$(mktemp)
+    snapshot_output=$("${KOPIA[@]}" snapshot list --all --json 2>"${stderr_file}") || list_exit_code=$?
+    if [[ ${list_exit_code} -ne 0 ]]; then
+        KOPIA_ERROR_OUTPUT="$(cat "${stderr_file}")"
+        rm -f "${stderr_file}"
+        OPERATION_FAILURE_REASON="Kopia snapshot list failed with exit code ${list_exit_code}"
+        OPERATION_RESULT="FAILURE"
+        log_error "${OPERATION_FAILURE_REASON}"
+        return ${list_exit_code}
+    fi
+    rm -f "${stderr_file}"
+
+    if [[ -z "${snapshot_output}" ]]; then
+        snapshot_output="[]"
+    fi
+
+    local snapshot_count
+    snapshot_count=$(echo "${snapshot_output}" | jq 'length')
+    echo "${snapshot_output}" | jq -c '.[] | {id, source, description, startTime, endTime,
+        stats: {totalSize: .stats.totalSize, fileCount: .stats.fileCount, dirCount: .stats.dirCount},
+        retentionReason, pins}' | while read -r snapshot; do
+        echo "KOPIA_CATALOG_SNAPSHOT: ${snapshot}"
+    done
+    echo "KOPIA_CATALOG_COUNT: ${snapshot_count}"
+    result_update --argjson n "${snapshot_count}" '.details.catalogCount = $n'
+
+    OPERATION_RESULT="SUCCESS"
+    return 0
+}
+
+# Verify the snapshot created by do_backup by reading back a sample of its
+# files. The result is reported to the controller through the KOPIA_VERIFY_*
+# lines. A failed verification doesn't fail the job since the snapshot has
+# already been created; the controller records the failure instead.
+function do_verify {
+    log_info "=== Verifying snapshot ==="
+    local verify_start_time=$(date +%s)
+    local snapshot_path="${KOPIA_SOURCE_PATH_OVERRIDE:-${DATA_DIR}}"
+
+    local snapshot_id
+    snapshot_id=$("${KOPIA[@]}" snapshot list "${snapshot_path}" --json 2>/dev/null | jq -r 'last | .id // empty' || true)
+    if [[ -z "${snapshot_id}" ]]; then
+        log_error "Unable to find the snapshot to verify for ${snapshot_path}"
+        echo "KOPIA_VERIFY_OBJECTS: 0"
+        echo "KOPIA_VERIFY_ERRORS: 1"
+        echo "KOPIA_VERIFY_RESULT: FAILURE"
+        result_update '.details.verify = {snapshotID: "", objects: 0, errors: 1, succeeded: false}'
+        return 0
+    fi
+
+    log_info "Verifying snapshot ${snapshot_id} (reading ${KOPIA_VERIFY_FILES_PERCENT}% of files)"
+    local verify_output
+    local verify_exit_code=0
+    verify_output=$("${KOPIA[@]}" snapshot verify --verify-files-percent="${KOPIA_VERIFY_FILES_PERCENT}" "${snapshot_id}" 2>&1) || verify_exit_code=$?
+    echo "${verify_output}"
+
+    local objects errors
+    objects=$(echo "${verify_output}" | grep -oE '[Pp]rocess(ed|ing) [0-9]+ objects' | tail -1 | grep -oE '[0-9]+' || true)
+    errors=$(echo "${verify_output}" | grep -oE 'encountered [0-9]+ errors' | tail -1 | grep -oE '[0-9]+' || true)
+    if [[ -z "${errors}" ]]; then
+        errors=0
+        if [[ ${verify_exit_code} -ne 0 ]]; then
+            errors=1
+        fi
+    fi
+
+    local verify_end_time=$(date +%s)
+    log_timing "Snapshot verification took $((verify_end_time - verify_start_time)) seconds"
+
+    echo "KOPIA_VERIFY_SNAPSHOT: ${snapshot_id}"
+    echo "KOPIA_VERIFY_OBJECTS: ${objects:-0}"
+    echo "KOPIA_VERIFY_ERRORS: ${errors}"
+    local succeeded=false
+    if [[ ${verify_exit_code} -eq 0 ]] && [[ "${errors}" -eq 0 ]]; then
+        log_info "Snapshot verification completed successfully"
+        echo "KOPIA_VERIFY_RESULT: SUCCESS"
+        succeeded=true
+    else
+        log_error "Snapshot verification failed with exit code ${verify_exit_code}"
+        echo "KOPIA_VERIFY_RESULT: FAILURE"
+    fi
+    result_update --arg id "${snapshot_id}" --argjson objects "${objects:-0}" --argjson errors "${errors}" \
+        --argjson succeeded "${succeeded}" \
+        '.details.verify = {snapshotID: $id, objects: $objects, errors: $errors, succeeded: $succeeded}'
+    return 0
+}
+
+# Environment variables that configure the storage backend of a repository.
+# They are cleared before the variables of a syncTo target are applied, so
+# that the settings of the source repository don't leak into the target.
+SYNC_TO_BACKEND_VARS=(KOPIA_REPOSITORY
+    AWS_ACCESS_KEY_ID AWS_SECRET_ACCESS_KEY AWS_SESSION_TOKEN AWS_DEFAULT_REGION AWS_REGION AWS_PROFILE
+    AWS_S3_ENDPOINT AWS_S3_DISABLE_TLS KOPIA_S3_BUCKET KOPIA_S3_ENDPOINT KOPIA_S3_DISABLE_TLS
+    AZURE_ACCOUNT_NAME AZURE_ACCOUNT_KEY AZURE_ACCOUNT_SAS AZURE_ENDPOINT_SUFFIX
+    AZURE_STORAGE_ACCOUNT AZURE_STORAGE_KEY AZURE_STORAGE_SAS_TOKEN
+    KOPIA_AZURE_CONTAINER KOPIA_AZURE_STORAGE_ACCOUNT KOPIA_AZURE_STORAGE_KEY
+    GOOGLE_PROJECT_ID GOOGLE_APPLICATION_CREDENTIALS GOOGLE_DRIVE_FOLDER_ID GOOGLE_DRIVE_CREDENTIALS
+    KOPIA_GCS_BUCKET GCS_BUCKET
+    B2_ACCOUNT_ID B2_APPLICATION_KEY KOPIA_B2_BUCKET
+    WEBDAV_URL WEBDAV_USERNAME WEBDAV_PASSWORD
+    SFTP_HOST SFTP_PORT SFTP_USERNAME SFTP_PASSWORD SFTP_PATH SFTP_KEY_FILE SFTP_KNOWN_HOSTS SFTP_KNOWN_HOSTS_DATA
+    RCLONE_REMOTE_PATH RCLONE_EXE RCLONE_CONFIG)
+
+# Add the storage backend of the current environment to a sync-to command
+# add_sync_to_storage command_array_name
+function add_sync_to_storage {
+    local -n sync_cmd=$1
+
+    if [[ -n "${KOPIA_S3_BUCKET}" ]] || [[ "${KOPIA_REPOSITORY}" =~ ^s3:// ]]; then
+        local s3_bucket="${KOPIA_S3_BUCKET}"
+        local s3_prefix=""
+        if [[ "${KOPIA_REPOSITORY}" =~ ^s3://([a-z0-9][a-z0-9.-]{1,61}[a-z0-9])/?(.*)$ ]]; then
+            s3_bucket="${s3_bucket:-${BASH_REMATCH[1]}}"
+            s3_prefix="${BASH_REMATCH[2]}"
+        fi
+        if [[ -z "${s3_bucket}" ]]; then
+            log_error "Could not determine S3 bucket name"
+            return 1
+        fi
+        if [[ -n "${s3_prefix}" ]] && [[ ! "${s3_prefix}" =~ /$ ]]; then
+            s3_prefix="${s3_prefix}/"
+        fi
+        local s3_endpoint="${KOPIA_S3_ENDPOINT:-${AWS_S3_ENDPOINT:-s3.amazonaws.com}}"
+        sync_cmd+=(s3 --bucket="${s3_bucket}" --endpoint="${s3_endpoint#*://}")
+        if [[ -n "${s3_prefix}" ]]; then
+            sync_cmd+=(--prefix="${s3_prefix}")
+        fi
+        if [[ -n "${AWS_ACCESS_KEY_ID}" ]]; then
+            sync_cmd+=(--access-key="${AWS_ACCESS_KEY_ID}")
+        fi
+        if [[ -n "${AWS_SECRET_ACCESS_KEY}" ]]; then
+            sync_cmd+=(--secret-access-key="${AWS_SECRET_ACCESS_KEY}")
+        fi
+        if [[ -n "${AWS_SESSION_TOKEN}" ]]; then
+            sync_cmd+=(--session-token="${AWS_SESSION_TOKEN}")
+        fi
+        if [[ -n "${AWS_REGION:-${AWS_DEFAULT_REGION}}" ]]; then
+            sync_cmd+=(--region="${AWS_REGION:-${AWS_DEFAULT_REGION}}")
+        fi
+        if [[ "${KOPIA_S3_DISABLE_TLS}" == "true" ]] || [[ "${AWS_S3_DISABLE_TLS}" == "true" ]]; then
+            sync_cmd+=(--disable-tls)
+        fi
+    elif [[ -n "${KOPIA_AZURE_CONTAINER}" ]]; then
+        sync_cmd+=(azure --container="${KOPIA_AZURE_CONTAINER}"
+            --storage-account="${KOPIA_AZURE_STORAGE_ACCOUNT}"
+            --storage-key="${KOPIA_AZURE_STORAGE_KEY}")
+    elif [[ "${KOPIA_REPOSITORY}" =~ ^filesystem://(/.*) ]]; then
+        if [[ "${BASH_REMATCH[1]}" =~ \.\./ ]]; then
+            log_error "Invalid filesystem path. Path cannot contain .."
+            return 1
+        fi
+        sync_cmd+=(filesystem --path="${BASH_REMATCH[1]}")
+    elif [[ -n "${KOPIA_B2_BUCKET}" ]]; then
+        sync_cmd+=(b2 --bucket="${KOPIA_B2_BUCKET}" --key-id="${B2_ACCOUNT_ID}" --key="${B2_APPLICATION_KEY}")
+    elif [[ -n "${WEBDAV_URL}" ]]; then
+        sync_cmd+=(webdav --url="${WEBDAV_URL}" --webdav-username="${WEBDAV_USERNAME}"
+            --webdav-password="${WEBDAV_PASSWORD}")
+    elif [[ -n "${SFTP_HOST}" ]]; then
+        sync_cmd+=(sftp --host="${SFTP_HOST}" --username="${SFTP_USERNAME}" --path="${SFTP_PATH}")
+        if [[ -n "${SFTP_PORT}" ]]; then
+            sync_cmd+=(--port="${SFTP_PORT}")
+        fi
+        if [[ -n "${SFTP_PASSWORD}" ]]; then
+            sync_cmd+=(--sftp-password="${SFTP_PASSWORD}")
+        fi
+        if [[ -n "${SFTP_KNOWN_HOSTS_DATA}" ]]; then
+            sync_cmd+=(--known-hosts-data="${SFTP_KNOWN_HOSTS_DATA}")
+        fi
+    elif [[ -n "${RCLONE_REMOTE_PATH}" ]]; then
+        sync_cmd+=(rclone --remote-path="${RCLONE_REMOTE_PATH}")
+    else
+        log_error "No supported storage backend configuration found"
+        return 1
+    fi
+}
+
+# Mirror the repository to the syncTo target with the given index. It is run
+# in a subshell since it replaces the backend environment variables.
+function sync_to_target {
+    local prefix="KOPIA_SYNC_TO_${1}_"
+    local var
+    for var in "${SYNC_TO_BACKEND_VARS[@]}"; do
+        unset "${var}"
+    done
+    for var in $(compgen -v "${prefix}"); do
+        export "${var#"${prefix}"}=${!var}"
+    done
+
+    declare -a SYNC_CMD
+    SYNC_CMD=("${KOPIA[@]}" repository sync-to)
+    if ! add_sync_to_storage SYNC_CMD; then
+        return 1
+    fi
+    # Blobs that were removed from the repository by maintenance are also
+    # removed from the target so that it stays an exact copy
+    SYNC_CMD+=(--delete)
+    "${SYNC_CMD[@]}"
+}
+
+# Mirror the repository to each of the syncTo targets after the backup. The
+# result of each target is reported to the controller through the
+# KOPIA_SYNC_TO_RESULT lines. A failed sync doesn't fail the job since the
+# backup has already been created; the controller records the failure instead.
+function do_sync_to {
+    log_info "=== Syncing repository to ${KOPIA_SYNC_TO_COUNT} target(s) ==="
+    local i
+    for ((i = 0; i < KOPIA_SYNC_TO_COUNT; i++)); do
+        local name_var="KOPIA_SYNC_TO_${i}_NAME"
+        local name="${!name_var}"
+        local sync_start_time=$(date +%s)
+        local sync_exit_code=0
+        (sync_to_target "${i}") || sync_exit_code=$?
+        local sync_end_time=$(date +%s)
+        log_timing "Sync to ${name} took $((sync_end_time - sync_start_time)) seconds"
+        if [[ ${sync_exit_code} -eq 0 ]]; then
+            log_info "Repository synced to ${name}"
+            echo "KOPIA_SYNC_TO_RESULT: ${name} SUCCESS"
+            result_update --arg name "${name}" '.details.syncTo[$name] = true'
+        else
+            log_error "Sync to ${name} failed with exit code ${sync_exit_code}"
+            echo "KOPIA_SYNC_TO_RESULT: ${name} FAILURE"
+            result_update --arg name "${name}" '.details.syncTo[$name] = false'
+        fi
+    done
+    return 0
+}
+
 # Function removed: do_retention_global is no longer needed
 # Global retention should be configured through the KopiaMaintenance CRD if needed
 
@@ -2179,7 +2510,7 @@ function discover_available_snapshots {
     echo "Listing all available snapshot identities in the repository..."
     
     # Use kopia snapshot list without path to see all snapshots
-    # Output in JSON for parsing by the controller
+    # Output in JSON so that the identities can be summarized
     local all_snapshots
     all_snapshots=$("${KOPIA[@]}" snapshot list --all --json 2>/dev/null || true)
     
@@ -2187,8 +2518,16 @@ function discover_available_snapshots {
         echo "Found snapshots in repository:"
         echo ""
         
-        # Output raw JSON for controller parsing
+        # Output raw JSON for troubleshooting
         echo "${all_snapshots}" | jq -c '.[] | {id: .id, userName: .source.userName, hostName: .source.host, path: .source.path, startTime: .startTime, endTime: .endTime}' 2>/dev/null || true
+        local identities
+        identities=$(echo "${all_snapshots}" | jq -c 'group_by(.source.userName + "@" + .source.host)
+            | map({identity: (.[0].source.userName + "@" + .[0].source.host),
+                   snapshotCount: length,
+                   latestSnapshot: (map(.endTime) | max)})' 2>/dev/null || true)
+        if [[ -n "${identities}" ]]; then
+            result_update --argjson ids "${identities}" '.details.availableIdentities = $ids'
+        fi
         
         echo ""
         echo "Available identities (username@hostname combinations):"
@@ -2209,6 +2548,14 @@ function discover_available_snapshots {
 
 function select_snapshot_to_restore {
     echo "Selecting snapshot to restore" >&2
+
+    # A snapshot referenced by KopiaSnapshot name has already been resolved
+    # to its id by the controller
+    if [[ -n "${KOPIA_RESTORE_SNAPSHOT_ID}" ]]; then
+        echo "Using snapshot id from KopiaSnapshot: ${KOPIA_RESTORE_SNAPSHOT_ID}" >&2
+        echo "${KOPIA_RESTORE_SNAPSHOT_ID}"
+        return 0
+    fi
     
     # Build the full identity string for listing snapshots
     # When using overrides, we need to specify the full identity: username@hostname:path
@@ -2288,6 +2635,206 @@ function select_snapshot_to_restore {
     fi
 }
 
+# Partial restore support
+# KOPIA_RESTORE_INCLUDE_PATHS and KOPIA_RESTORE_EXCLUDE_PATHS contain one path
+# per line. The controller normalizes them to be relative to the snapshot root
+# without leading or trailing slashes.
+declare -a RESTORE_INCLUDE_PATHS=()
+declare -a RESTORE_EXCLUDE_PATHS=()
+if [[ -n "${KOPIA_RESTORE_INCLUDE_PATHS}" ]]; then
+    mapfile -t RESTORE_INCLUDE_PATHS <<< "${KOPIA_RESTORE_INCLUDE_PATHS}"
+fi
+if [[ -n "${KOPIA_RESTORE_EXCLUDE_PATHS}" ]]; then
+    mapfile -t RESTORE_EXCLUDE_PATHS <<< "${KOPIA_RESTORE_EXCLUDE_PATHS}"
+fi
+
+function is_partial_restore {
+    [[ ${#RESTORE_INCLUDE_PATHS[@]} -gt 0 ]] || [[ ${#RESTORE_EXCLUDE_PATHS[@]} -gt 0 ]]
+}
+
+# is_excluded_path relative_path
+# Returns success if the path is excluded or lies below an excluded directory
+function is_excluded_path {
+    local rel_path=$1
+    local excluded
+    for excluded in "${RESTORE_EXCLUDE_PATHS[@]}"; do
+        [[ -z "${excluded}" ]] && continue
+        if [[ "${rel_path}" == "${excluded}" ]] || [[ "${rel_path}" == "${excluded}/"* ]]; then
+            return 0
+        fi
+    done
+    return 1
+}
+
+# has_excluded_descendant relative_path
+# Returns success if an excluded path lies below the given directory
+# (an empty path is the snapshot root)
+function has_excluded_descendant {
+    local rel_path=$1
+    local excluded
+    for excluded in "${RESTORE_EXCLUDE_PATHS[@]}"; do
+        [[ -z "${excluded}" ]] && continue
+        if [[ -z "${rel_path}" ]] || [[ "${excluded}" == "${rel_path}/"* ]]; then
+            return 0
+        fi
+    done
+    return 1
+}
+
+# delete_restore_path relative_path
+# Deletes a path below DATA_DIR before it is restored by a restore with file
+# deletion enabled. Excluded paths are kept, so directories containing them
+# are expanded and only their other entries are deleted (an empty path is the
+# whole volume, except lost+found).
+function delete_restore_path {
+    local rel_path=$1
+    local target="${DATA_DIR}"
+    if [[ -n "${rel_path}" ]]; then
+        target="${DATA_DIR}/${rel_path}"
+    fi
+
+    if is_excluded_path "${rel_path}"; then
+        echo "Keeping excluded path: ${rel_path}"
+        return 0
+    fi
+    if [[ -n "${rel_path}" ]] && ! has_excluded_descendant "${rel_path}"; then
+        rm -rf "${target:?}"
+        return 0
+    fi
+    if [[ ! -d "${target}" ]] || [[ -L "${target}" ]]; then
+        [[ -n "${rel_path}" ]] && rm -f "${target:?}"
+        return 0
+    fi
+
+    local entry child
+    for entry in "${target}"/* "${target}"/.[!.]* "${target}"/..?*; do
+        # Skip the unmatched glob patterns
+        [[ -e "${entry}" ]] || [[ -L "${entry}" ]] || continue
+        entry="${entry##*/}"
+        if [[ -z "${rel_path}" ]] && [[ "${entry}" == "lost+found" ]]; then
+            continue
+        fi
+        child="${entry}"
+        if [[ -n "${rel_path}" ]]; then
+            child="${rel_path}/${entry}"
+        fi
+        delete_restore_path "${child}"
+    done
+}
+
+# restore_snapshot_path snapshot_id relative_path
+# Restores a single subtree of the snapshot into the same location below the
+# current directory. Directories containing excluded paths are expanded so
+# that only their non-excluded entries are restored.
+function restore_snapshot_path {
+    local snapshot_id=$1
+    local rel_path=$2
+
+    if is_excluded_path "${rel_path}"; then
+        echo "Skipping excluded path: ${rel_path}"
+        return 0
+    fi
+
+    local snapshot_path="${snapshot_id}"
+    if [[ -n "${rel_path}" ]]; then
+        snapshot_path="${snapshot_id}/${rel_path}"
+    fi
+
+    if has_excluded_descendant "${rel_path}"; then
+        local -a entries=()
+        local listing
+        if ! listing=$("${KOPIA[@]}" ls "${snapshot_path}"); then
+            error 1 "Failed to list snapshot path: /${rel_path}"
+        fi
+        if [[ -n "${listing}" ]]; then
+            mapfile -t entries <<< "${listing}"
+        fi
+        mkdir -p "./${rel_path}"
+
+        local entry child
+        for entry in "${entries[@]}"; do
+            # Directories are listed with a trailing slash
+            entry="${entry%/}"
+            [[ -z "${entry}" ]] && continue
+            child="${entry}"
+            if [[ -n "${rel_path}" ]]; then
+                child="${rel_path}/${entry}"
+            fi
+            restore_snapshot_path "${snapshot_id}" "${child}"
+        done
+        return 0
+    fi
+
+    mkdir -p "$(dirname "./${rel_path}")"
+
+    declare -a PATH_RESTORE_CMD
+    PATH_RESTORE_CMD=("${KOPIA[@]}" snapshot restore "${snapshot_path}" "./${rel_path}" \
+        --write-files-atomically \
+        --ignore-permission-errors)
+    if [[ -n "${KOPIA_DOWNLOAD_SPEED}" ]]; then
+        PATH_RESTORE_CMD+=(--download-speed="${KOPIA_DOWNLOAD_SPEED}")
+    fi
+    add_additional_args PATH_RESTORE_CMD
+
+    log_debug "Restore command: ${PATH_RESTORE_CMD[*]}"
+    if ! run_with_progress_output "${PATH_RESTORE_CMD[@]}"; then
+        error 1 "Failed to restore path /${rel_path} from snapshot: ${snapshot_id}"
+    fi
+    echo "Restored path: /${rel_path}"
+    result_update --arg p "/${rel_path}" '.details.restoredPaths += [$p]'
+}
+
+# do_partial_restore snapshot_id
+# Restores only the included (or all non-excluded) paths of the snapshot
+function do_partial_restore {
+    local snapshot_id=$1
+    local -a roots=("${RESTORE_INCLUDE_PATHS[@]}")
+    if [[ ${#roots[@]} -eq 0 ]]; then
+        # Only exclusions were given, start from the snapshot root
+        roots=("")
+    fi
+
+    log_info "Performing partial restore of ${#roots[@]} path(s) with ${#RESTORE_EXCLUDE_PATHS[@]} exclusion(s)"
+    local root
+    for root in "${roots[@]}"; do
+        restore_snapshot_path "${snapshot_id}" "${root}"
+    done
+}
+
+# Report the capacity of the source volume recorded in the tags of the snapshot
+# report_source_capacity snapshot_id
+function report_source_capacity {
+    local capacity
+    capacity=$("${KOPIA[@]}" snapshot list --all --json 2>/dev/null | jq -r --arg id "$1" \
+        '.[] | select(.id == $id) | .tags["tag:volsync-source-capacity"] // empty' || true)
+    if [[ "${capacity}" =~ ^[0-9]+$ ]]; then
+        log_info "Source capacity of snapshot: ${capacity} bytes"
+        result_update --argjson c "${capacity}" '.sourceCapacity = $c'
+    fi
+}
+
+# Write the file of a snapshot of a block volume back to the block device
+# restore_block_device snapshot_id
+function restore_block_device {
+    local root_entry root size
+    root_entry=$("${KOPIA[@]}" snapshot list --all --json 2>/dev/null | jq -c --arg id "$1" \
+        '.[] | select(.id == $id) | .rootEntry' || true)
+    root=$(jq -r '.obj // empty' <<< "${root_entry}")
+    if [[ -z "${root}" ]]; then
+        error 1 "Unable to find snapshot $1"
+    fi
+    # The snapshot must only hold the image, otherwise it's a snapshot of a
+    # filesystem volume
+    if [[ "$("${KOPIA[@]}" ls "${root}")" != "${BLOCK_FILENAME}" ]]; then
+        error 1 "Snapshot $1 is not a snapshot of a block volume, it must only contain ${BLOCK_FILENAME}"
+    fi
+    size=$(jq -r '.summ.size // empty' <<< "${root_entry}")
+    log_info "Restoring ${BLOCK_FILENAME} to block device ${BLOCK_DEVICE}"
+    if ! "${KOPIA[@]}" show "${root}/${BLOCK_FILENAME}" | write_block_device "${size}"; then
+        error 1 "Unable to restore snapshot $1 to ${BLOCK_DEVICE}"
+    fi
+}
+
 function do_restore {
     log_info "=== Starting restore operation ==="
     local restore_start_time=$(date +%s)
@@ -2298,7 +2845,17 @@ function do_restore {
     fi
     
     # Check if file deletion is enabled
-    if [[ "${KOPIA_ENABLE_FILE_DELETION}" == "true" ]]; then
+    if [[ "${KOPIA_ENABLE_FILE_DELETION}" == "true" ]] && [[ ${#RESTORE_INCLUDE_PATHS[@]} -gt 0 ]]; then
+        echo "File deletion enabled - cleaning included paths, except excluded ones, before restore"
+        local included
+        for included in "${RESTORE_INCLUDE_PATHS[@]}"; do
+            [[ -z "${included}" ]] && continue
+            delete_restore_path "${included}"
+        done
+    elif [[ "${KOPIA_ENABLE_FILE_DELETION}" == "true" ]] && [[ ${#RESTORE_EXCLUDE_PATHS[@]} -gt 0 ]]; then
+        echo "File deletion enabled - cleaning destination directory, except excluded paths, before restore"
+        delete_restore_path ""
+    elif [[ "${KOPIA_ENABLE_FILE_DELETION}" == "true" ]]; then
         echo "File deletion enabled - cleaning destination directory before restore"
         # Clean the destination directory but preserve lost+found
         # Use find to delete everything except lost+found directory
@@ -2328,7 +2885,9 @@ function do_restore {
             if [[ "${DATA_DIR}" != "/restore/data" ]] && [[ -z "${KOPIA_SOURCE_PATH_OVERRIDE}" ]]; then
                 search_path="${DATA_DIR}"
             fi
-            echo "No snapshots found for ${KOPIA_OVERRIDE_USERNAME:-$(whoami)}@${KOPIA_OVERRIDE_HOSTNAME:-$(hostname)}:${search_path}"
+            local requested_identity="${KOPIA_OVERRIDE_USERNAME:-$(whoami)}@${KOPIA_OVERRIDE_HOSTNAME:-$(hostname)}"
+            echo "No snapshots found for ${requested_identity}:${search_path}"
+            result_update --arg id "${requested_identity}" '.details.requestedIdentity = $id'
             discover_available_snapshots
         fi
         
@@ -2337,6 +2896,14 @@ function do_restore {
     fi
     
     echo "Selected snapshot with id: ${snapshot_id}"
+    result_update --arg id "${snapshot_id}" '.snapshotID = $id'
+    report_source_capacity "${snapshot_id}"
+
+    if [[ -n "${BLOCK_DEVICE}" ]]; then
+        restore_block_device "${snapshot_id}"
+        log_info "Snapshot restore completed successfully"
+        return 0
+    fi
     
     # Restore the snapshot with proper error handling
     # Change to the target directory first to avoid path construction issues
@@ -2344,6 +2911,15 @@ function do_restore {
     if ! cd "${DATA_DIR}"; then
         error 1 "Failed to change to data directory: ${DATA_DIR}"
     fi
+
+    if is_partial_restore; then
+        local partial_start=$(date +%s)
+        do_partial_restore "${snapshot_id}"
+        local partial_end=$(date +%s)
+        log_timing "Partial restore took $((partial_end - partial_start)) seconds"
+        log_info "Snapshot restore completed successfully"
+        return 0
+    fi
     
     # Build restore command with options
     declare -a RESTORE_CMD
@@ -2352,6 +2928,9 @@ function do_restore {
         --ignore-permission-errors)
     
     # Add additional arguments if specified
+    if [[ -n "${KOPIA_DOWNLOAD_SPEED}" ]]; then
+        RESTORE_CMD+=(--download-speed="${KOPIA_DOWNLOAD_SPEED}")
+    fi
     add_additional_args RESTORE_CMD
     
     # Execute the restore command with progress output formatting
@@ -2386,7 +2965,7 @@ echo "Testing mandatory env variables"
 check_var_defined KOPIA_PASSWORD
 
 # For non-maintenance operations, also check DATA_DIR
-if [[ "${DIRECTION}" != "maintenance" ]]; then
+if [[ "${DIRECTION}" != "maintenance" ]] && [[ "${DIRECTION}" != "catalog" ]]; then
     check_var_defined DATA_DIR
 fi
 
@@ -2397,12 +2976,18 @@ fi
 
 log_info "Cache directory validation passed"
 
-if [[ "${DIRECTION}" != "maintenance" ]]; then
+if [[ "${DIRECTION}" != "maintenance" ]] && [[ "${DIRECTION}" != "catalog" ]]; then
     # Validate data directory exists and is accessible for backup/restore
-    if [[ ! -d "${DATA_DIR}" ]]; then
+    if [[ -n "${BLOCK_DEVICE}" ]]; then
+        if [[ ! -b "${BLOCK_DEVICE}" ]]; then
+            error 1 "Block device ${BLOCK_DEVICE} does not exist"
+        fi
+        log_info "Block device validation passed"
+    elif [[ ! -d "${DATA_DIR}" ]]; then
         error 1 "Data directory ${DATA_DIR} does not exist"
+    else
+        log_info "Data directory validation passed"
     fi
-    log_info "Data directory validation passed"
 fi
 
 echo ""
@@ -2425,13 +3010,21 @@ if [[ "${DIRECTION}" == "source" ]]; then
     ensure_connected
     do_backup
     do_retention
+    if [[ -n "${KOPIA_VERIFY_FILES_PERCENT}" ]]; then
+        do_verify
+    fi
+    if [[ -n "${KOPIA_SYNC_TO_COUNT}" ]]; then
+        do_sync_to
+    fi
     # Maintenance is now handled by the KopiaMaintenance CRD, not during backups
     OPERATION_RESULT="SUCCESS"
 elif [[ "${DIRECTION}" == "destination" ]]; then
     log_info "=== Running as DESTINATION ===="
     ensure_connected
     do_restore
-    sync -f "${DATA_DIR}"
+    if [[ -z "${BLOCK_DEVICE}" ]]; then
+        sync -f "${DATA_DIR}"
+    fi
     OPERATION_RESULT="SUCCESS"
 elif [[ "${DIRECTION}" == "maintenance" ]]; then
     log_info "=== Running MAINTENANCE ONLY ===="
@@ -2445,6 +3038,11 @@ elif [[ "${DIRECTION}" == "maintenance" ]]; then
     do_maintenance
 
     log_info "Maintenance operation completed"
+elif [[ "${DIRECTION}" == "catalog" ]]; then
+    log_info "=== Running SNAPSHOT CATALOG ===="
+    # Read-only listing of the repository for the KopiaSnapshot catalog
+    ensure_connected
+    do_catalog
 else
     # Execute operations based on arguments (current VolSync approach)
     for op in "$@"; do
@@ -2454,11 +3052,19 @@ else
                 ensure_connected
                 do_backup
                 do_retention
+                if [[ -n "${KOPIA_VERIFY_FILES_PERCENT}" ]]; then
+                    do_verify
+                fi
+                if [[ -n "${KOPIA_SYNC_TO_COUNT}" ]]; then
+                    do_sync_to
+                fi
                 ;;
             "restore")
                 ensure_connected
                 do_restore
-                sync -f "${DATA_DIR}"
+                if [[ -z "${BLOCK_DEVICE}" ]]; then
+                    sync -f "${DATA_DIR}"
+                fi
                 ;;
             "maintenance")
                 log_error "Command-based maintenance no longer supported. Use KopiaMaintenance CRD."
@@ -48,9 +48,36 @@ function error {
     rc="$1"
     shift
     echo "error: $*"
+    MOVER_ERROR="$*"
     exit "$rc"
 }
 
+# Machine-readable result of the mover, written to the termination message of
+# the container on exit. The controller reads the amount of data transferred
+# from it.
+MOVER_RESULT_FILE="${MOVER_RESULT_FILE:-/dev/termination-log}"
+RCLONE_STATS_FILE=/tmp/rclone-stats.log
+MOVER_ERROR=""
+
+# Write the mover result from the final statistics of the rclone sync. rclone
+# reports sizes in binary units.
+# shellcheck disable=SC2317  # It's reachable due to the TRAP
+function write_mover_result {
+    local rc=$?
+    touch "${RCLONE_STATS_FILE}"
+    jq -nc --argjson rc "${rc}" --arg err "${MOVER_ERROR}" --argjson d "$(( SECONDS - ${START_TIME:-0} ))" \
+        --rawfile stats "${RCLONE_STATS_FILE}" '
+        def last_match($re): [$stats | scan($re)] | last;
+        {version: 1, durationSeconds: $d,
+         bytesProcessed: (last_match("Transferred:\\s+([0-9.]+) ([KMGTP]?)i?B /") | if . then
+            .[1] as $u | (.[0] | tonumber) * pow(1024; if $u == "" then 0 else ("KMGTP" | index($u)) + 1 end) | floor
+            else null end),
+         filesProcessed: (last_match("Transferred:\\s+([0-9]+) / [0-9]+,") | if . then .[0] | tonumber else null end)}
+        | if $rc != 0 then .errors = [if $err != "" then $err else "rclone failed with exit code \($rc)" end] else . end
+        | with_entries(select(.value != null))' > "${MOVER_RESULT_FILE}" 2>/dev/null || true
+}
+trap write_mover_result EXIT
+
 # Rclone config file that gets mounted as a Secret onto RCLONE_CONFIG
 
 [[ -n "${RCLONE_DEST_PATH}" ]] || error 1 "RCLONE_DEST_PATH must be defined"
@@ -78,11 +105,11 @@ START_TIME=$SECONDS
 case "${DIRECTION}" in
 source)
     find "${MOUNT_PATH}" -path "${MOUNT_PATH}/lost+found" -prune -o -print | getfacl -P - > /tmp/permissions.facl
-    rclone sync "${RCLONE_FLAGS_SYNC[@]}" --exclude "lost+found/**" "${MOUNT_PATH}" "${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}" --log-level DEBUG
+    rclone sync "${RCLONE_FLAGS_SYNC[@]}" --exclude "lost+found/**" "${MOUNT_PATH}" "${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}" --log-level DEBUG 2>&1 | tee "${RCLONE_STATS_FILE}"
     rclone copy "${RCLONE_FLAGS_COPY[@]}" --include permissions.facl /tmp "${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}" --log-level DEBUG
     ;;
 destination)
-    rclone sync "${RCLONE_FLAGS_SYNC[@]}" --exclude "lost+found/**" --exclude permissions.facl "${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}" "${MOUNT_PATH}" --log-level DEBUG
+    rclone sync "${RCLONE_FLAGS_SYNC[@]}" --exclude "lost+found/**" --exclude permissions.facl "${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}" "${MOUNT_PATH}" --log-level DEBUG 2>&1 | tee "${RCLONE_STATS_FILE}"
     rclone copy "${RCLONE_FLAGS_COPY[@]}" --include permissions.facl "${RCLONE_CONFIG_SECTION}:${RCLONE_DEST_PATH}" /tmp --log-level DEBUG
     stat /tmp/permissions.facl
     setfacl --restore=/tmp/permissions.facl || true
@@ -55,6 +55,15 @@ if [[ -n "${CUSTOM_CA}" ]]; then
     echo "Using custom CA."
     RESTIC+=(--cacert "${CUSTOM_CA}")
 fi
+# Bandwidth limits (KiB/s) from the transferPolicy
+if [[ -n "${RESTIC_LIMIT_UPLOAD}" ]]; then
+    echo "Limiting uploads to ${RESTIC_LIMIT_UPLOAD} KiB/s"
+    RESTIC+=(--limit-upload "${RESTIC_LIMIT_UPLOAD}")
+fi
+if [[ -n "${RESTIC_LIMIT_DOWNLOAD}" ]]; then
+    echo "Limiting downloads to ${RESTIC_LIMIT_DOWNLOAD} KiB/s"
+    RESTIC+=(--limit-download "${RESTIC_LIMIT_DOWNLOAD}")
+fi
 
 "${RESTIC[@]}" version
 
@@ -62,11 +71,46 @@ fi
 RESTIC_HOST="volsync"
 # Make restic output progress reports every 10s
 export RESTIC_PROGRESS_FPS=0.1
+# Block volumes are backed up as a single file with this name
+BLOCK_FILENAME="data.img"
+# shellcheck source=../mover-common/blockdevice.sh
+source /mover-common/blockdevice.sh
+
+# Machine-readable result of the mover. It is written to the termination
+# message of the container on exit, and the controller reads the snapshot ID,
+# statistics, errors and the check and copy results from it.
+MOVER_RESULT_FILE="${MOVER_RESULT_FILE:-/dev/termination-log}"
+MOVER_RESULT='{"version":1}'
+
+# Update the mover result with a jq filter. Failures are ignored since the
+# result must never fail the operation.
+# result_update [jq options...] filter
+function result_update {
+    local updated
+    if updated=$(jq -c "$@" <<< "${MOVER_RESULT}" 2>/dev/null); then
+        MOVER_RESULT="${updated}"
+    fi
+}
+
+# Write the mover result to the termination message. Kubernetes truncates the
+# message to 4096 bytes, so only the last errors are kept if it doesn't fit.
+function write_mover_result {
+    result_update --argjson d "$((SECONDS - START_TIME))" '.durationSeconds = $d'
+    local result="${MOVER_RESULT}"
+    if [[ $(printf '%s' "${result}" | wc -c) -gt 4096 ]]; then
+        result=$(jq -c '.truncated = true
+            | if .errors then .errors |= (.[-5:] | map(.[0:256])) else . end' <<< "${result}" 2>/dev/null) || return 0
+    fi
+    printf '%s' "${result}" > "${MOVER_RESULT_FILE}" 2>/dev/null || true
+}
+START_TIME=$SECONDS
+trap write_mover_result EXIT
 
 # Print an error message and exit
 # error rc "message"
 function error {
     echo "ERROR: $2"
+    result_update --arg e "$2" '.errors += [$e]'
     exit "$1"
 }
 
@@ -79,6 +123,9 @@ function check_var_defined {
 }
 
 function check_contents {
+    if [[ -n ${BLOCK_DEVICE} ]]; then
+        return 0
+    fi
     echo "== Checking directory for content ==="
     DIR_CONTENTS="$(ls -A "${DATA_DIR}" --ignore="lost+found")"
     if [ -z "${DIR_CONTENTS}" ]; then
@@ -88,6 +135,7 @@ function check_contents {
 }
 
 # Ensure the repo has been initialized
+# Arguments are passed to restic init
 function ensure_initialized {
     echo "=== Check for dir initialized ==="
     # check for restic config and capture rc
@@ -121,7 +169,7 @@ function ensure_initialized {
         # the following cmd `restic init` will also fail)
         if [[ $output =~ .*(Is there a repository at the following location).* ]]; then
             echo "=== Initialize Dir ==="
-            "${RESTIC[@]}" init
+            "${RESTIC[@]}" init "$@"
         else
             cat "$outfile"
             error 3 "failure checking existence of repository"
@@ -130,7 +178,7 @@ function ensure_initialized {
     10)
         # rc = 10  Repository does not exist (since restic 0.17.0)
         echo "=== Initialize Dir ==="
-        "${RESTIC[@]}" init
+        "${RESTIC[@]}" init "$@"
         ;;
     11)
         # rc = 11  Failed to lock repository (since restic 0.17.0)
@@ -151,11 +199,79 @@ function ensure_initialized {
     rm -f "$outfile"
 }
 
+#######################################
+# Appends "option value" to an array for
+# each line of a newline-separated list
+# append_list_options ARRAY "--option" "$LIST"
+#######################################
+function append_list_options {
+    local -n options_ref=$1
+    local value
+    while IFS= read -r value; do
+        if [[ -n ${value} ]]; then
+            options_ref+=("$2" "${value}")
+        fi
+    done <<< "$3"
+}
+
 function do_backup {
+    if [[ -n ${BLOCK_DEVICE} ]]; then
+        do_backup_block
+        return
+    fi
     echo "=== Starting backup ==="
+    local -a backup_options=(--exclude='lost+found')
+    append_list_options backup_options --exclude "${BACKUP_EXCLUDES}"
+    append_list_options backup_options --iexclude "${BACKUP_IEXCLUDES}"
+    append_list_options backup_options --exclude-if-present "${BACKUP_EXCLUDE_IF_PRESENT}"
+    local -a backup_paths=(.)
+    if [[ -n ${BACKUP_INCLUDES} ]]; then
+        mapfile -t backup_paths <<< "${BACKUP_INCLUDES}"
+        echo "Backing up: ${backup_paths[*]}"
+    fi
     pushd "${DATA_DIR}"
-    "${RESTIC[@]}" backup --host "${RESTIC_HOST}" --exclude='lost+found' .
+    local outfile
+    outfile=$(mktemp -q)
+    "${RESTIC[@]}" backup --host "${RESTIC_HOST}" "${backup_options[@]}" "${backup_paths[@]}" | tee "${outfile}"
     popd
+    record_snapshot_result "$(grep -oE 'snapshot [0-9a-f]+ saved' "${outfile}" | tail -1 | cut -d' ' -f2 || true)"
+    rm -f "${outfile}"
+}
+
+#######################################
+# Backs up the block device as a single
+# file, read from stdin
+# Globals:
+#   BLOCK_DEVICE
+#   BLOCK_FILENAME
+#######################################
+function do_backup_block {
+    echo "=== Starting backup of block device ${BLOCK_DEVICE} ==="
+    local outfile
+    outfile=$(mktemp -q)
+    "${RESTIC[@]}" backup --host "${RESTIC_HOST}" --stdin --stdin-filename "${BLOCK_FILENAME}" \
+        < "${BLOCK_DEVICE}" | tee "${outfile}"
+    record_snapshot_result "$(grep -oE 'snapshot [0-9a-f]+ saved' "${outfile}" | tail -1 | cut -d' ' -f2 || true)"
+    rm -f "${outfile}"
+}
+
+#######################################
+# Records the ID and statistics of a
+# snapshot in the mover result
+# record_snapshot_result snapshot_id
+#######################################
+function record_snapshot_result {
+    if [[ -z $1 ]]; then
+        echo "Unable to find the ID of the new snapshot"
+        return 0
+    fi
+    result_update --arg id "$1" '.snapshotID = $id'
+    local summary
+    summary=$("${RESTIC[@]}" snapshots --json "$1" 2>/dev/null | jq -c '.[0].summary // empty' 2>/dev/null || true)
+    if [[ -n ${summary} ]]; then
+        result_update --argjson s "${summary}" \
+            '.bytesProcessed = $s.total_bytes_processed | .filesProcessed = $s.total_files_processed'
+    fi
 }
 
 function do_forget {
@@ -191,6 +307,82 @@ function do_prune {
     "${RESTIC[@]}" prune
 }
 
+#######################################
+# Copies the snapshots to each secondary
+# repository. A failed copy is reported
+# but doesn't fail the backup.
+#######################################
+function do_copy {
+    local i name_var rc
+    for ((i = 0; i < ${SECONDARY_REPOSITORY_COUNT:-0}; i++)); do
+        name_var="SECONDARY_${i}_NAME"
+        echo "=== Starting copy to secondary repository ${!name_var} ==="
+        set +e  # Don't exit on command failure
+        (set -e; copy_to_secondary "${i}")
+        rc=$?
+        set -e  # Exit on command failure
+        if [[ $rc -eq 0 ]]; then
+            echo "Copy to secondary repository ${!name_var} succeeded"
+            result_update --arg name "${!name_var}" '.details.copies[$name] = true'
+        else
+            echo "Copy to secondary repository ${!name_var} failed"
+            result_update --arg name "${!name_var}" '.details.copies[$name] = false'
+        fi
+    done
+}
+
+#######################################
+# Copies the snapshots to a secondary
+# repository. Must run in a subshell since
+# the SECONDARY_<index>_ variables replace
+# those of the primary repository. The
+# operator only allows the backend variables
+# of a secondary that match the primary's,
+# so the primary is still read with its own
+# credentials.
+# copy_to_secondary index
+#######################################
+function copy_to_secondary {
+    local prefix="SECONDARY_$1_"
+    export RESTIC_FROM_REPOSITORY="${RESTIC_REPOSITORY}"
+    export RESTIC_FROM_PASSWORD="${RESTIC_PASSWORD}"
+    FORGET_OPTIONS=""
+    local var
+    for var in $(compgen -v "${prefix}"); do
+        export "${var#"${prefix}"}=${!var}"
+    done
+    ensure_initialized --copy-chunker-params
+    "${RESTIC[@]}" copy --host "${RESTIC_HOST}"
+    do_forget
+    if [[ ${SECONDARY_PRUNE} == 1 ]]; then
+        do_prune
+    fi
+}
+
+#######################################
+# Checks the integrity of the repository
+# Fails unless CHECK_FAILURE_FATAL is 0, in
+# which case only the result is reported
+#######################################
+function do_check {
+    echo "=== Starting check ==="
+    local args=(check)
+    if [[ -n ${CHECK_READ_DATA_SUBSET} ]]; then
+        args+=(--read-data-subset "${CHECK_READ_DATA_SUBSET}")
+    fi
+    if "${RESTIC[@]}" "${args[@]}"; then
+        echo "Repository check passed"
+        result_update '.details.check = "Passed"'
+        return 0
+    fi
+    result_update '.details.check = "Failed"'
+    if [[ ${CHECK_FAILURE_FATAL:-1} == 0 ]]; then
+        echo "Repository check failed"
+    else
+        error 1 "Repository check failed"
+    fi
+}
+
 #######################################
 # Trims the provided timestamp and
 # returns one in the format: YYYY-MM-DD hh:mm:ss
@@ -254,15 +446,22 @@ function reverse_array() {
 # If SELECT_PREVIOUS is defined, then the n-th snapshot
 # is selected under the matching criteria.
 # If a snapshot satisfying the conditions is found, then its ID
-# is returned.
+# is returned. A snapshot selected by RESTORE_SNAPSHOT_ID is
+# returned as-is.
 #
 # Globals:
 #   SELECT_PREVIOUS
 #   RESTORE_AS_OF
+#   RESTORE_SNAPSHOT_ID
 # Arguments:
 #   None
 ################################################################
 function select_restic_snapshot_to_restore() {
+    if [[ -n ${RESTORE_SNAPSHOT_ID} ]]; then
+        echo "${RESTORE_SNAPSHOT_ID}"
+        return
+    fi
+
     # list of epochs
     declare -a epochs
     # create an associative array that maps numeric epoch to the restic snapshot IDs
@@ -343,15 +542,48 @@ function do_restore {
         if [[ -n ${RESTORE_OPTIONS} ]]; then
           echo "RESTORE_OPTIONS: ${RESTORE_OPTIONS}"
         fi
-        pushd "${DATA_DIR}"
         echo "Selected restic snapshot with id: ${snapshot_id}"
+        result_update --arg id "${snapshot_id}" '.snapshotID = $id'
+        if [[ -n ${BLOCK_DEVICE} ]]; then
+            restore_block_device "${snapshot_id}"
+            return
+        fi
+        local -a include_options=()
+        append_list_options include_options --include "${RESTORE_INCLUDES}"
+        pushd "${DATA_DIR}"
         # Running this cmd can be finicky with spaces, do not put quotes around ${RESTORE_OPTIONS}
         #shellcheck disable=SC2086
-        "${RESTIC[@]}" restore "${snapshot_id}" -t . --host "${RESTIC_HOST}" --include-xattr "user.*" ${RESTORE_OPTIONS}
+        "${RESTIC[@]}" restore "${snapshot_id}" -t . --host "${RESTIC_HOST}" --include-xattr "user.*" \
+            "${include_options[@]}" ${RESTORE_OPTIONS}
         popd
     fi
 }
 
+#######################################
+# Writes the backup of a block device
+# back to the device
+# Globals:
+#   BLOCK_DEVICE
+#   BLOCK_FILENAME
+# Arguments:
+#   Snapshot ID
+#######################################
+function restore_block_device {
+    local size
+    # The snapshot must only hold the image, otherwise it's the backup of a
+    # filesystem volume
+    size=$("${RESTIC[@]}" ls --json "$1" | jq -rs --arg name "${BLOCK_FILENAME}" \
+        '[.[] | select(.struct_type == "node")]
+        | if length == 1 and .[0].name == $name and .[0].type == "file" then .[0].size else empty end' || true)
+    if [[ -z ${size} ]]; then
+        error 1 "snapshot $1 is not a backup of a block volume, it must only contain ${BLOCK_FILENAME}"
+    fi
+    echo "Restoring ${BLOCK_FILENAME} to block device ${BLOCK_DEVICE}"
+    if ! "${RESTIC[@]}" dump "$1" "/${BLOCK_FILENAME}" | write_block_device "${size}"; then
+        error 1 "unable to restore snapshot $1 to ${BLOCK_DEVICE}"
+    fi
+}
+
 echo "Testing mandatory env variables"
 # Check the mandatory env variables
 for var in PRIVILEGED_MOVER \
@@ -362,7 +594,6 @@ for var in PRIVILEGED_MOVER \
            ; do
     check_var_defined $var
 done
-START_TIME=$SECONDS
 for op in "$@"; do
     case $op in
         "unlock")
@@ -374,13 +605,24 @@ for op in "$@"; do
             do_backup
             do_forget
             ;;
+        "forget")
+            do_forget
+            ;;
         "prune")
             do_prune
             ;;
+        "copy")
+            do_copy
+            ;;
+        "check")
+            do_check
+            ;;
         "restore")
             ensure_initialized
             do_restore
-            sync -f "${DATA_DIR}"
+            if [[ -z ${BLOCK_DEVICE} ]]; then
+                sync -f "${DATA_DIR}"
+            fi
             ;;
         *)
             error 2 "unknown operation: $op"
@@ -57,6 +57,28 @@ fi
 
 cd "$SCRIPT_DIR"
 
+# Machine-readable result of the mover, written to the termination message of
+# the container. The controller reads the amount of data transferred from it.
+MOVER_RESULT_FILE="${MOVER_RESULT_FILE:-/dev/termination-log}"
+RSYNC_STATS_FILE=/tmp/rsync-stats.log
+
+# Write the mover result from the statistics of the last rsync run. rsync's
+# human-readable numbers are in units of 1000.
+# write_mover_result rc
+function write_mover_result {
+    touch "${RSYNC_STATS_FILE}"
+    jq -nc --argjson rc "$1" --argjson d "$(( SECONDS - START_TIME ))" --rawfile stats "${RSYNC_STATS_FILE}" '
+        def rsync_number: gsub(","; "") | capture("^(?<n>[0-9.]+)(?<u>[KMGTP]?)$")
+            | .u as $u | (.n | tonumber) * pow(1000; if $u == "" then 0 else ("KMGTP" | index($u)) + 1 end) | floor;
+        def last_stat($name): [$stats | scan($name + ": ([0-9.,]+[KMGTP]?)")] | last
+            | if . then .[0] | rsync_number else null end;
+        {version: 1, durationSeconds: $d,
+         bytesProcessed: last_stat("Total transferred file size"),
+         filesProcessed: last_stat("Number of regular files transferred")}
+        | if $rc != 0 then .errors = ["Synchronization failed. rsync returned: \($rc)"] else . end
+        | with_entries(select(.value != null))' > "${MOVER_RESULT_FILE}" 2>/dev/null || true
+}
+
 # shellcheck disable=SC2317  # It's reachable due to the TRAP
 function stop_stunnel() {
     ## Terminate stunnel
@@ -133,6 +155,13 @@ stunnel -version "$STUNNEL_CONF"
 stunnel "$STUNNEL_CONF"
 trap stop_stunnel EXIT
 
+# Limit the bandwidth (KiB/s) if there's a transferPolicy
+RSYNC_BWLIMIT_OPT=()
+if [[ -n "${RSYNC_BWLIMIT}" ]]; then
+    echo "Limiting bandwidth to ${RSYNC_BWLIMIT} KiB/s"
+    RSYNC_BWLIMIT_OPT=("--bwlimit=${RSYNC_BWLIMIT}")
+fi
+
 # Sync files
 START_TIME=$SECONDS
 MAX_RETRIES=5
@@ -149,11 +178,17 @@ while [[ $rc -ne 0 && $RETRY -lt $MAX_RETRIES ]]; do
       /diskrsync-tcp $BLOCK_SOURCE --source --target-address 127.0.0.1 --port $STUNNEL_LISTEN_PORT
       rc=$?
     else
+        # Tell the destination how large the source is, so that it can stop if
+        # the data doesn't fit. Older destinations ignore the file.
+        if [[ ${SOURCE_CAPACITY:-0} -gt 0 ]]; then
+            echo "${SOURCE_CAPACITY}" > /tmp/source-capacity
+            rsync /tmp/source-capacity rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/control/source-capacity
+        fi
         # Find all files/dirs at root of pvc, prepend / to each (rsync will use SOURCE as the base dir for these files)
         find "${SOURCE}" -mindepth 1 -maxdepth 1 -printf '/%P\n' > /tmp/filelist.txt
         if [[ -s /tmp/filelist.txt ]]; then
             # 1st run preserves as much as possible, but excludes the root directory
-            rsync -aAhHSxz -r --exclude=lost+found --itemize-changes --info=stats2,misc2 --files-from=/tmp/filelist.txt ${SOURCE}/ rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/data
+            rsync -aAhHSxz -r "${RSYNC_BWLIMIT_OPT[@]}" --exclude=lost+found --itemize-changes --info=stats2,misc2 --files-from=/tmp/filelist.txt ${SOURCE}/ rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/data | tee "${RSYNC_STATS_FILE}"
         else
             echo "Skipping sync of empty source directory"
         fi
@@ -162,7 +197,7 @@ while [[ $rc -ne 0 && $RETRY -lt $MAX_RETRIES ]]; do
         # To delete extra files, must sync at the directory-level, but need to avoid
         # trying to modify the directory itself. This pass will only delete files
         # that exist on the destination but not on the source, not make updates.
-        rsync -rx --exclude=lost+found --ignore-existing --ignore-non-existing --delete --itemize-changes --info=stats2,misc2 ${SOURCE}/ rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/data
+        rsync -rx "${RSYNC_BWLIMIT_OPT[@]}" --exclude=lost+found --ignore-existing --ignore-non-existing --delete --itemize-changes --info=stats2,misc2 ${SOURCE}/ rsync://127.0.0.1:$STUNNEL_LISTEN_PORT/data
         rc_b=$?
         rc=$(( rc_a * 100 + rc_b ))
     fi
@@ -173,6 +208,7 @@ while [[ $rc -ne 0 && $RETRY -lt $MAX_RETRIES ]]; do
     fi
 done
 set -e  # Exit on command failure
+write_mover_result "$rc"
 
 if test -b $BLOCK_SOURCE; then
     echo "diskrsync completed in $(( SECONDS - START_TIME ))s"
@@ -4,6 +4,8 @@ set -e -o pipefail
 
 RSYNC_PID_FILE=/tmp/rsyncd.pid
 CONTROL_FILE=/tmp/control/complete
+SOURCE_CAPACITY_FILE=/tmp/control/source-capacity
+MOVER_RESULT_FILE="${MOVER_RESULT_FILE:-/dev/termination-log}"
 RSYNCD_CONF=/tmp/rsyncd.conf
 STUNNEL_CONF=/tmp/stunnel.conf
 STUNNEL_PID_FILE=/tmp/stunnel.pid
@@ -193,11 +195,40 @@ fi
 echo "Starting stunnel..."
 stunnel "$STUNNEL_CONF"
 
+##############################
+## Report the capacity of the source to the controller in the mover result
+# write_mover_result [error]
+function write_mover_result {
+    [[ -e $SOURCE_CAPACITY_FILE ]] || return 0
+    jq -nc --argjson c "$(<"$SOURCE_CAPACITY_FILE")" --arg err "$1" '
+        {version: 1, sourceCapacity: $c}
+        | if $err != "" then .errors = [$err] else . end' > "${MOVER_RESULT_FILE}" 2>/dev/null || true
+}
+
+function shutdown_stunnel {
+    echo "Shutting down..."
+    kill -TERM "$(<"$STUNNEL_PID_FILE")"
+    if [[ -d $TARGET ]]; then
+        kill -TERM "$TAIL_PID"
+    fi
+    wait
+    echo "Stunnel completed shut down."
+}
+
 ##############################
 ## Wait for the control file to be created, signaling that we should
 ## terminate
 echo "Waiting for control file to be created ($CONTROL_FILE)..."
 while [[ ! -e $CONTROL_FILE ]]; do
+    # Stop if the source doesn't fit, so that the volume can be expanded
+    if [[ ${DESTINATION_CAPACITY:-0} -gt 0 && -e $SOURCE_CAPACITY_FILE &&
+          $(<"$SOURCE_CAPACITY_FILE") -gt $DESTINATION_CAPACITY ]]; then
+        MSG="Source capacity $(<"$SOURCE_CAPACITY_FILE") exceeds destination capacity ${DESTINATION_CAPACITY}"
+        echo "$MSG"
+        shutdown_stunnel
+        write_mover_result "$MSG"
+        exit 1
+    fi
     sleep 1
 done
 
@@ -205,13 +236,8 @@ sleep 5  # Give time for the rsync connection to finish
 
 ##############################
 ## Terminate stunnel
-echo "Shutting down..."
-kill -TERM "$(<"$STUNNEL_PID_FILE")"
-if [[ -d $TARGET ]]; then
-    kill -TERM "$TAIL_PID"
-fi
-wait
-echo "Stunnel completed shut down."
+shutdown_stunnel
+write_mover_result
 
 if test -b $BLOCK_TARGET; then
     sync -f $BLOCK_TARGET
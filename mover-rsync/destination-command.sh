@@ -24,6 +24,21 @@ function do_diskrsync {
     diskrsync --target /dev/block
 }
 
+# The source reports its capacity before transferring. If the data can't fit
+# on the destination volume, the destination shuts down so that the volume can
+# be expanded.
+function do_capacity {
+    source_capacity="$1"
+    echo "$source_capacity" > /tmp/source_capacity
+
+    destination_capacity="$(cat /tmp/destination_capacity 2>/dev/null || echo 0)"
+    if [[ $destination_capacity -gt 0 && $source_capacity -gt $destination_capacity ]]; then
+        echo "Source capacity ${source_capacity} exceeds destination capacity ${destination_capacity}" | tee /tmp/mover_error
+        do_shutdown 1
+        exit 28
+    fi
+}
+
 #-- These are the only commands allowed to be executed by the source side:
 # Source can initiate an rsync
 if [[ "$SSH_ORIGINAL_COMMAND" =~ ^rsync( ) ]]; then
@@ -33,6 +48,9 @@ elif [[ "$SSH_ORIGINAL_COMMAND" =~ ^diskrsync( ) ]]; then
 # Source can tell us (destination) to shutdown & pass a numeric result code
 elif [[ "$SSH_ORIGINAL_COMMAND" =~ ^shutdown( )+([0-9]+)$ ]]; then
     do_shutdown "${BASH_REMATCH[2]}"
+# Source can tell us (destination) the capacity of the source volume
+elif [[ "$SSH_ORIGINAL_COMMAND" =~ ^capacity( )+([0-9]+)$ ]]; then
+    do_capacity "${BASH_REMATCH[2]}"
 # Everything else is an error
 else
     echo "Invalid command: $SSH_ORIGINAL_COMMAND"
@@ -57,6 +57,10 @@ if test -b /dev/block; then
 fi
 echo "Destination PVC volumeMode is $VOLUME_MODE"
 
+# sshd doesn't pass the environment to the forced command, so the capacity of
+# the destination is handed to it in a file
+echo "${DESTINATION_CAPACITY:-0}" > /tmp/destination_capacity
+
 # Wait for incoming rsync transfer
 echo "Waiting for connection..."
 rm -f /var/run/nologin
@@ -71,5 +75,13 @@ if [[ -e /tmp/exit_code ]]; then
     fi
 fi
 sync -f "${MOUNT_PATH}"
+
+# Report the capacity of the source to the controller in the mover result
+if [[ -e /tmp/source_capacity ]]; then
+    jq -nc --argjson c "$(</tmp/source_capacity)" --rawfile err <(cat /tmp/mover_error 2>/dev/null) '
+        {version: 1, sourceCapacity: $c}
+        | if $err != "" then .errors = [$err | rtrimstr("\n")] else . end' \
+        > "${MOVER_RESULT_FILE:-/dev/termination-log}" 2>/dev/null || true
+fi
 echo "Exiting... Exit code: $CODE"
 exit "$CODE"
@@ -45,6 +45,28 @@ if [[ $DEBUG_MOVER -eq 1 && "$SCRIPT_DIR" != "/tmp" ]]; then
   exit 0
 fi
 
+# Machine-readable result of the mover, written to the termination message of
+# the container. The controller reads the amount of data transferred from it.
+MOVER_RESULT_FILE="${MOVER_RESULT_FILE:-/dev/termination-log}"
+RSYNC_STATS_FILE=/tmp/rsync-stats.log
+
+# Write the mover result from the statistics of the last rsync run. rsync's
+# human-readable numbers are in units of 1000.
+# write_mover_result rc
+function write_mover_result {
+    touch "${RSYNC_STATS_FILE}"
+    jq -nc --argjson rc "$1" --argjson d "$(( SECONDS - START_TIME ))" --rawfile stats "${RSYNC_STATS_FILE}" '
+        def rsync_number: gsub(","; "") | capture("^(?<n>[0-9.]+)(?<u>[KMGTP]?)$")
+            | .u as $u | (.n | tonumber) * pow(1000; if $u == "" then 0 else ("KMGTP" | index($u)) + 1 end) | floor;
+        def last_stat($name): [$stats | scan($name + ": ([0-9.,]+[KMGTP]?)")] | last
+            | if . then .[0] | rsync_number else null end;
+        {version: 1, durationSeconds: $d,
+         bytesProcessed: last_stat("Total transferred file size"),
+         filesProcessed: last_stat("Number of regular files transferred")}
+        | if $rc != 0 then .errors = ["Synchronization failed. rsync returned: \($rc)"] else . end
+        | with_entries(select(.value != null))' > "${MOVER_RESULT_FILE}" 2>/dev/null || true
+}
+
 # Ensure we have connection info for the destination
 DESTINATION_PORT="${DESTINATION_PORT:-22}"
 if [[ -z "$DESTINATION_ADDRESS" ]]; then
@@ -110,6 +132,15 @@ if [[ "$DESTINATION_ADDRESS" =~ $IPV6_REGEX ]]; then
   fi
 fi
 
+# Limit the bandwidth (KiB/s) if there's a transferPolicy
+RSYNC_BWLIMIT_OPT=()
+if [[ -n "${RSYNC_BWLIMIT}" ]]; then
+    echo "Limiting bandwidth to ${RSYNC_BWLIMIT} KiB/s"
+    RSYNC_BWLIMIT_OPT=("--bwlimit=${RSYNC_BWLIMIT}")
+fi
+
+# Exit code of the destination's capacity command when the source doesn't fit
+CAPACITY_TOO_SMALL=28
 MAX_RETRIES=5
 RETRY=0
 DELAY=2
@@ -122,11 +153,22 @@ set +e
 while [[ ${rc} -ne 0 && ${RETRY} -lt ${MAX_RETRIES} ]]
 do
     RETRY=$((RETRY + 1))
+    # Tell the destination how large the source is. It exits with
+    # CAPACITY_TOO_SMALL if the data can't fit. Older destinations don't know
+    # the command, so any other failure is ignored.
+    if [[ ${SOURCE_CAPACITY:-0} -gt 0 ]]; then
+      ssh "root@${DESTINATION_ADDRESS}" capacity "${SOURCE_CAPACITY}"
+      if [[ $? -eq ${CAPACITY_TOO_SMALL} ]]; then
+        echo "Destination volume is smaller than the source (${SOURCE_CAPACITY} bytes)"
+        write_mover_result "${CAPACITY_TOO_SMALL}"
+        exit "${CAPACITY_TOO_SMALL}"
+      fi
+    fi
     if test -b $BLOCK_SOURCE; then
       echo "calling diskrsync $BLOCK_SOURCE root@${URL_DESTINATION_ADDRESS}:/dev/block"
       diskrsync $BLOCK_SOURCE "root@${URL_DESTINATION_ADDRESS}":/dev/block
     else
-      rsync -aAhHSxz --delete --itemize-changes --info=stats2,misc2 $SOURCE/ "root@${URL_DESTINATION_ADDRESS}":.
+      rsync -aAhHSxz "${RSYNC_BWLIMIT_OPT[@]}" --delete --itemize-changes --info=stats2,misc2 $SOURCE/ "root@${URL_DESTINATION_ADDRESS}":. | tee "${RSYNC_STATS_FILE}"
     fi
     rc=$?
     if [[ ${rc} -ne 0 ]]; then
@@ -137,6 +179,7 @@ do
 done
 set -e
 echo "Rsync completed in $(( SECONDS - START_TIME ))s"
+write_mover_result "$rc"
 if [[ $rc -eq 0 ]]; then
     echo "Synchronization completed successfully. Notifying destination..."
     # ssh does not take [ip] format for ipv6, so use DESTINATION_ADDRESS rather than URL_DESTINATION_ADDRESS